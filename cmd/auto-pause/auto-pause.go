@@ -21,9 +21,13 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
+	// the node may have no time zone database for the never-pause windows
+	_ "time/tzdata"
 
+	"k8s.io/minikube/pkg/minikube/autopause"
 	"k8s.io/minikube/pkg/minikube/cluster"
 	"k8s.io/minikube/pkg/minikube/command"
 	"k8s.io/minikube/pkg/minikube/cruntime"
@@ -31,37 +35,69 @@ import (
 	"k8s.io/minikube/pkg/minikube/reason"
 )
 
+// checkInterval is how often the idle time, the service traffic and the never-pause windows are checked
+const checkInterval = 5 * time.Second
+
 var (
 	unpauseRequests = make(chan struct{})
 	done            = make(chan struct{})
 	mu              sync.Mutex
 	runtimePaused   bool
-	version         = "0.0.1"
+	version         = "0.0.2"
+
+	// lastActivity is when an API request or service traffic was last seen
+	lastActivity time.Time
+	// lastBlocked is when a never-pause window was last in effect, the idle time counts from its end
+	lastBlocked time.Time
+	// connections is the last count of connections to NodePort and LoadBalancer services
+	connections uint64
+	windows     []autopause.Window
+	// location is the time zone of the windows, its offset from UTC is looked up at each check so that it follows daylight saving time
+	location *time.Location
 
-	runtime  = flag.String("container-runtime", "docker", "Container runtime to use for (un)pausing")
-	interval = flag.Duration("interval", time.Minute*1, "Interval of inactivity for pause to occur")
+	runtime          = flag.String("container-runtime", "docker", "Container runtime to use for (un)pausing")
+	interval         = flag.Duration("interval", time.Minute*1, "Interval of inactivity for pause to occur")
+	namespaces       = flag.String("namespaces", "kube-system", "Comma separated namespaces to pause")
+	exemptNamespaces = flag.String("exempt-namespaces", "", "Comma separated namespaces never to pause")
+	exemptSelector   = flag.String("exempt-selector", "", "Label selector of the pods never to pause, like app=db,tier=backend")
+	wakeOnServices   = flag.Bool("wake-on-services", false, "Count connections to NodePort and LoadBalancer services as activity, only with kube-proxy in iptables mode")
+	neverPause       = flag.String("never-pause", "", "Semicolon separated windows during which never to pause, like 'Mon-Fri 09:00-18:00;Sat 10:00-12:00'")
+	timeZone         = flag.String("time-zone", "UTC", "Time zone of the never-pause windows, an IANA name like Europe/Berlin or a fixed offset like UTC+02:00")
 )
 
 func main() {
 	flag.Parse()
 
-	tickerChannel := time.NewTicker(*interval)
+	var err error
+	if windows, err = autopause.ParseWindows(splitList(*neverPause, ";")); err != nil {
+		exit.Error(reason.Usage, "Invalid never-pause window", err)
+	}
+	if location, err = autopause.LoadTimeZone(*timeZone); err != nil {
+		exit.Error(reason.Usage, "Invalid time zone", err)
+	}
 
 	// Check current state
 	alreadyPaused()
+	lastActivity = time.Now()
+	if *wakeOnServices {
+		connections = serviceConnections()
+	}
+
+	tick := *interval
+	if tick > checkInterval {
+		tick = checkInterval
+	}
+	ticker := time.NewTicker(tick)
 
 	// channel for incoming messages
 	go func() {
 		for {
 			select {
-			case <-tickerChannel.C:
-				tickerChannel.Stop()
-				runPause()
+			case now := <-ticker.C:
+				check(now)
 			case <-unpauseRequests:
-				tickerChannel.Stop()
 				log.Println("Got request")
-				runUnpause()
-				tickerChannel.Reset(*interval)
+				activity(time.Now(), "API request")
 
 				done <- struct{}{}
 			}
@@ -80,14 +116,73 @@ func handler(w http.ResponseWriter, _ *http.Request) {
 	fmt.Fprintf(w, "allow")
 }
 
-func runPause() {
+// activity records activity, unpausing the cluster if needed
+func activity(now time.Time, why string) {
+	lastActivity = now
+	runUnpause(why)
+	saveState(now)
+}
+
+// check pauses the cluster if it has been idle long enough, or unpauses it if a never-pause window began
+func check(now time.Time) {
+	if *wakeOnServices {
+		n := serviceConnections()
+		// kube-proxy resets the counters when it rewrites its rules, the count starts over from there
+		prev := connections
+		connections = n
+		if n > prev {
+			activity(now, "service traffic")
+			return
+		}
+	}
+
+	if w, ok := autopause.Active(windows, now.In(location)); ok {
+		lastBlocked = now
+		runUnpause(fmt.Sprintf("never-pause window %s", w))
+	} else if idle := now.Sub(idleSince()); idle >= *interval {
+		runPause(fmt.Sprintf("idle for %s", idle.Round(time.Second)))
+	}
+	saveState(now)
+}
+
+func idleSince() time.Time {
+	if lastBlocked.After(lastActivity) {
+		return lastBlocked
+	}
+	return lastActivity
+}
+
+func saveState(now time.Time) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	s := autopause.State{
+		Paused:       runtimePaused,
+		LastActivity: lastActivity,
+		PauseAt:      idleSince().Add(*interval),
+	}
+	if w, ok := autopause.Active(windows, now.In(location)); ok {
+		s.Blocked = w.String()
+	}
+	if err := autopause.WriteState(s); err != nil {
+		log.Printf("failed to save state: %v", err)
+	}
+}
+
+func record(action, why string, containers int) {
+	e := autopause.Entry{Time: time.Now(), Action: action, Reason: why, Containers: containers}
+	if err := autopause.Record(e); err != nil {
+		log.Printf("failed to record %s: %v", action, err)
+	}
+}
+
+func runPause(why string) {
 	mu.Lock()
 	defer mu.Unlock()
 	if runtimePaused {
-		log.Println("Already paused, skipping")
 		return
 	}
-	log.Println("Pausing...")
+	log.Printf("Pausing (%s)...", why)
 
 	r := command.NewExecRunner(true)
 
@@ -96,24 +191,24 @@ func runPause() {
 		exit.Error(reason.InternalNewRuntime, "Failed runtime", err)
 	}
 
-	uids, err := cluster.Pause(cr, r, []string{"kube-system"})
+	uids, err := cluster.PauseExcept(cr, r, splitList(*namespaces, ","), exemptContainers(cr))
 	if err != nil {
 		exit.Error(reason.GuestPause, "Pause", err)
 	}
 
 	runtimePaused = true
+	record(autopause.Paused, why, len(uids))
 
 	log.Printf("Paused %d containers", len(uids))
 }
 
-func runUnpause() {
+func runUnpause(why string) {
 	mu.Lock()
 	defer mu.Unlock()
 	if !runtimePaused {
-		log.Println("Already unpaused, skipping")
 		return
 	}
-	log.Println("Unpausing...")
+	log.Printf("Unpausing (%s)...", why)
 
 	r := command.NewExecRunner(true)
 
@@ -127,6 +222,7 @@ func runUnpause() {
 		exit.Error(reason.GuestUnpause, "Unpause", err)
 	}
 	runtimePaused = false
+	record(autopause.Unpaused, why, len(uids))
 
 	log.Printf("Unpaused %d containers", len(uids))
 }
@@ -141,9 +237,49 @@ func alreadyPaused() {
 		exit.Error(reason.InternalNewRuntime, "Failed runtime", err)
 	}
 
-	runtimePaused, err = cluster.CheckIfPaused(cr, []string{"kube-system"})
+	runtimePaused, err = cluster.CheckIfPaused(cr, splitList(*namespaces, ","))
 	if err != nil {
 		exit.Error(reason.GuestCheckPaused, "Fail check if container paused", err)
 	}
 	log.Printf("containers paused status: %t", runtimePaused)
 }
+
+// exemptContainers returns the containers of the exempt namespaces and of the pods matching the exempt selector
+func exemptContainers(cr cruntime.Manager) []string {
+	var ids []string
+	if ns := splitList(*exemptNamespaces, ","); len(ns) > 0 {
+		nids, err := cr.ListContainers(cruntime.ListContainersOptions{State: cruntime.Running, Namespaces: ns})
+		if err != nil {
+			log.Printf("failed to list containers of exempt namespaces: %v", err)
+		}
+		ids = append(ids, nids...)
+	}
+	if *exemptSelector == "" {
+		return ids
+	}
+
+	sids, err := cr.ListContainers(cruntime.ListContainersOptions{State: cruntime.Running, Labels: splitList(*exemptSelector, ",")})
+	if err != nil {
+		log.Printf("failed to list containers of exempt pods: %v", err)
+	}
+	return append(ids, sids...)
+}
+
+func serviceConnections() uint64 {
+	n, err := autopause.ServiceConnections(command.NewExecRunner(true))
+	if err != nil {
+		log.Printf("failed to count service connections: %v", err)
+		return connections
+	}
+	return n
+}
+
+func splitList(s, sep string) []string {
+	var l []string
+	for _, e := range strings.Split(s, sep) {
+		if e = strings.TrimSpace(e); e != "" {
+			l = append(l, e)
+		}
+	}
+	return l
+}
@@ -19,6 +19,8 @@ limitations under the License.
 package main
 
 import (
+	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
 
@@ -33,5 +35,32 @@ func main() {
 		return
 	}
 
+	if len(os.Args) > 1 && (os.Args[1] == "suspend" || os.Args[1] == "resume") {
+		if err := suspendOrResume(os.Args[1], os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", os.Args[1], err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	plugin.RegisterDriver(kvm.NewDriver("", ""))
 }
+
+// suspendOrResume suspends or resumes the domain of the driver whose config is read from stdin.
+// The plugin protocol only carries the methods of the libmachine driver interface, hence these subcommands.
+func suspendOrResume(cmd string, args []string) error {
+	fs := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	toDisk := fs.Bool("to-disk", false, "Save the memory of the domain to disk and stop it, rather than keeping it in RAM")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	d := kvm.NewDriver("", "")
+	if err := json.NewDecoder(os.Stdin).Decode(d); err != nil {
+		return fmt.Errorf("reading driver config: %w", err)
+	}
+	if cmd == "suspend" {
+		return d.Suspend(*toDisk)
+	}
+	return d.Resume()
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/audit"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var (
+	auditCommand    string
+	auditUser       string
+	auditSince      string
+	auditUntil      string
+	auditStatus     string
+	auditOutput     string
+	auditOutputFile string
+)
+
+// auditCmd represents the audit command
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Queries and exports the audit log of minikube commands",
+	Long: `Queries the audit log of the minikube commands run on this host, including its rotated archives.
+Entries can be filtered by profile, command, user, time range and exit status, and exported as a table, JSON Lines, CSV or CloudEvents.`,
+	Example: `minikube audit --since 24h
+minikube audit -p minikube --command start --status failure
+minikube audit --since 2024-06-01T00:00:00Z -o csv --output-file audit.csv`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		format := strings.ToLower(auditOutput)
+		switch format {
+		case audit.FormatTable, audit.FormatJSONLines, audit.FormatCSV, audit.FormatCloudEvents:
+		default:
+			exit.Message(reason.Usage, "invalid output format: {{.output}}. Valid values: 'table', 'jsonl', 'csv', 'cloudevents'", out.V{"output": auditOutput})
+		}
+		if err := audit.ValidateStatus(auditStatus); err != nil {
+			exit.Message(reason.Usage, "Invalid --status value: {{.error}}", out.V{"error": err})
+		}
+		now := time.Now()
+		since, err := parseSince(auditSince, now)
+		if err != nil {
+			exit.Message(reason.Usage, "Invalid --since value: {{.error}}", out.V{"error": err})
+		}
+		until, err := parseSince(auditUntil, now)
+		if err != nil {
+			exit.Message(reason.Usage, "Invalid --until value: {{.error}}", out.V{"error": err})
+		}
+
+		f := audit.Filter{
+			Command: auditCommand,
+			User:    auditUser,
+			Since:   since,
+			Until:   until,
+			Status:  auditStatus,
+		}
+		// the profile flag always has a value, only filter by it when it is given explicitly
+		if cmd.Flags().Changed("profile") {
+			f.Profile = ClusterFlagValue()
+		}
+
+		entries, err := audit.Query(f)
+		if err != nil {
+			exit.Error(reason.HostAuditLog, "reading the audit log", err)
+		}
+
+		var w io.Writer = os.Stdout
+		if auditOutputFile != "" {
+			file, err := os.Create(auditOutputFile)
+			if err != nil {
+				exit.Error(reason.HostAuditLog, "creating the audit export file", err)
+			}
+			defer file.Close()
+			w = file
+		}
+		if err := audit.Export(w, entries, format); err != nil {
+			exit.Error(reason.HostAuditLog, "exporting the audit log", err)
+		}
+		if auditOutputFile != "" {
+			out.Styled(style.Check, "Exported {{.count}} audit log entries to {{.file}}", out.V{"count": len(entries), "file": auditOutputFile})
+		}
+	},
+}
+
+func init() {
+	auditCmd.Flags().StringVar(&auditCommand, "command", "", "Only show entries of the given minikube command, like start")
+	auditCmd.Flags().StringVar(&auditUser, "user", "", "Only show entries of the given user")
+	auditCmd.Flags().StringVar(&auditSince, "since", "", "Only show entries newer than a duration like 24h, or a timestamp like 2006-01-02T15:04:05Z")
+	auditCmd.Flags().StringVar(&auditUntil, "until", "", "Only show entries older than a duration like 1h, or a timestamp like 2006-01-02T15:04:05Z")
+	auditCmd.Flags().StringVar(&auditStatus, "status", "", "Only show entries with the given exit status. One of 'success', 'failure', 'unfinished' or an exit code")
+	auditCmd.Flags().StringVarP(&auditOutput, "output", "o", "table", "The output format. One of 'table', 'jsonl', 'csv', 'cloudevents'")
+	auditCmd.Flags().StringVar(&auditOutputFile, "output-file", "", "Write the entries to the given file instead of stdout")
+}
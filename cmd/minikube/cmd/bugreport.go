@@ -0,0 +1,259 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/docker/machine/libmachine/state"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"k8s.io/klog/v2"
+	cmdcfg "k8s.io/minikube/cmd/minikube/cmd/config"
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+	"k8s.io/minikube/pkg/kapi"
+	"k8s.io/minikube/pkg/minikube/audit"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/bugreport"
+	"k8s.io/minikube/pkg/minikube/cluster"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/logs"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+	"k8s.io/minikube/pkg/version"
+)
+
+var (
+	bugreportOutput string
+	bugreportLines  int
+)
+
+// bugreportCmd represents the bugreport command
+var bugreportCmd = &cobra.Command{
+	Use:   "bugreport",
+	Short: "Collects the diagnostics of a cluster into a tarball to attach to a bug report",
+	Long: `Collects the diagnostics of a cluster into a gzipped tarball: the cluster config, the logs of every node, the audit log, the last start log,
+driver information, Kubernetes events and node descriptions, and the container runtime state.
+Passwords, tokens, secrets and private keys are redacted, and a manifest.json describes the contents of the tarball.`,
+	Example: `minikube bugreport
+minikube bugreport -p cluster2 --output-file /tmp/cluster2-report.tar.gz`,
+	Run: func(_ *cobra.Command, _ []string) {
+		cname := ClusterFlagValue()
+		api, cc := mustload.Partial(cname)
+		defer api.Close()
+
+		outputFile := bugreportOutput
+		if outputFile == "" {
+			outputFile = fmt.Sprintf("minikube-bugreport-%s-%s.tar.gz", cname, time.Now().Format("20060102-150405"))
+		}
+
+		out.Styled(style.HealthCheck, "Collecting diagnostics of {{.profile}} ...", out.V{"profile": cname})
+		b := bugreport.New(cname, version.GetVersion())
+		collectHostDiagnostics(b, cc)
+		for _, n := range cc.Nodes {
+			collectNodeDiagnostics(b, api, cc, n)
+		}
+
+		f, err := os.Create(outputFile)
+		if err != nil {
+			exit.Error(reason.HostBugReport, "Failed to create the bug report", err)
+		}
+		defer f.Close()
+		if err := b.Write(f); err != nil {
+			exit.Error(reason.HostBugReport, "Failed to write the bug report", err)
+		}
+
+		failed := 0
+		for _, file := range b.Manifest().Files {
+			if file.Error != "" {
+				failed++
+			}
+		}
+		if failed > 0 {
+			out.WarningT("{{.count}} diagnostics could not be collected, see manifest.json in the bug report", out.V{"count": failed})
+		}
+		out.Styled(style.Success, "Bug report written to {{.path}}, please check it before attaching it to an issue", out.V{"path": outputFile})
+	},
+}
+
+// collectHostDiagnostics adds the diagnostics found on the host: the config, audit and last start logs and the driver info
+func collectHostDiagnostics(b *bugreport.Bundle, cc *config.ClusterConfig) {
+	if c, err := os.ReadFile(filepath.Join(config.ProfileFolderPath(cc.Name), "config.json")); err == nil {
+		b.AddJSON("config.json", "the cluster config of the profile", c)
+	} else {
+		b.AddError("config.json", "the cluster config of the profile", err)
+	}
+
+	if r, err := audit.Report(bugreportLines); err == nil {
+		b.Add("audit.txt", "the last minikube commands run on this host", []byte(r.ASCIITable()))
+	} else {
+		b.AddError("audit.txt", "the last minikube commands run on this host", err)
+	}
+
+	if c, err := os.ReadFile(localpath.LastStartLog()); err == nil {
+		b.Add("last-start.txt", "the log of the last minikube start", c)
+	} else {
+		b.AddError("last-start.txt", "the log of the last minikube start", err)
+	}
+
+	if driver.IsKIC(cc.Driver) {
+		info, err := oci.DaemonInfo(cc.Driver)
+		if err != nil {
+			b.AddError("driver/info.json", fmt.Sprintf("the %s daemon info", cc.Driver), err)
+			return
+		}
+		c, err := json.MarshalIndent(info, "", "    ")
+		if err != nil {
+			b.AddError("driver/info.json", fmt.Sprintf("the %s daemon info", cc.Driver), err)
+			return
+		}
+		b.AddJSON("driver/info.json", fmt.Sprintf("the %s daemon info", cc.Driver), c)
+	}
+}
+
+// collectNodeDiagnostics adds the diagnostics of a node: its machine and driver config, logs, runtime state and, for the primary control plane, the Kubernetes events and nodes
+func collectNodeDiagnostics(b *bugreport.Bundle, api libmachine.API, cc *config.ClusterConfig, n config.Node) {
+	name := config.MachineName(*cc, n)
+	dir := path.Join("nodes", name)
+
+	if c, err := os.ReadFile(filepath.Join(localpath.MiniPath(), "machines", name, "config.json")); err == nil {
+		b.AddJSON(path.Join(dir, "machine.json"), "the machine config of the node", c)
+	} else {
+		b.AddError(path.Join(dir, "machine.json"), "the machine config of the node", err)
+	}
+	collectDriverDiagnostics(b, cc, name, dir)
+
+	st, err := machine.Status(api, name)
+	if err != nil || st != state.Running.String() {
+		b.AddError(path.Join(dir, "logs.txt"), "the logs of the node", fmt.Errorf("node is not running: %s %v", st, err))
+		return
+	}
+	h, err := machine.LoadHost(api, name)
+	if err != nil {
+		b.AddError(path.Join(dir, "logs.txt"), "the logs of the node", err)
+		return
+	}
+	r, err := machine.CommandRunner(h)
+	if err != nil {
+		b.AddError(path.Join(dir, "logs.txt"), "the logs of the node", err)
+		return
+	}
+
+	cr, err := cruntime.New(cruntime.Config{Type: cc.KubernetesConfig.ContainerRuntime, Runner: r})
+	if err != nil {
+		b.AddError(path.Join(dir, "logs.txt"), "the logs of the node", err)
+		return
+	}
+	bs, err := cluster.Bootstrapper(api, viper.GetString(cmdcfg.Bootstrapper), *cc, r)
+	if err != nil {
+		b.AddError(path.Join(dir, "logs.txt"), "the logs of the node", err)
+		return
+	}
+	if c, err := nodeLogs(cr, bs, cc, r); err == nil {
+		b.Add(path.Join(dir, "logs.txt"), "the logs of the node, as shown by minikube logs", c)
+	} else {
+		b.AddError(path.Join(dir, "logs.txt"), "the logs of the node", err)
+	}
+
+	addNodeCommand(b, r, path.Join(dir, "runtime-ps.txt"), "the containers of the runtime", cruntime.ContainerStatusCommand())
+	addNodeCommand(b, r, path.Join(dir, "runtime-info.txt"), "the info of the runtime", "sudo `which crictl || echo crictl` info || sudo docker info")
+
+	if config.IsPrimaryControlPlane(*cc, n) {
+		kubectl := fmt.Sprintf("sudo %s --kubeconfig=%s", kapi.KubectlBinaryPath(cc.KubernetesConfig.KubernetesVersion), path.Join(vmpath.GuestPersistentDir, "kubeconfig"))
+		addNodeCommand(b, r, "kubernetes/events.txt", "the events of all namespaces", kubectl+" get events -A --sort-by=.lastTimestamp")
+		addNodeCommand(b, r, "kubernetes/describe-nodes.txt", "the description of all nodes", kubectl+" describe nodes")
+		addNodeCommand(b, r, "kubernetes/pods.txt", "the pods of all namespaces", kubectl+" get pods -A -o wide")
+	}
+}
+
+// collectDriverDiagnostics adds what the driver knows about the machine of a node, its container or domain
+func collectDriverDiagnostics(b *bugreport.Bundle, cc *config.ClusterConfig, name, dir string) {
+	var c *exec.Cmd
+	var file, desc string
+	switch {
+	case driver.IsKIC(cc.Driver):
+		c = oci.PrefixCmd(exec.Command(cc.Driver, "container", "inspect", name))
+		file, desc = "container.json", fmt.Sprintf("the %s container of the node", cc.Driver)
+	case driver.IsKVM(cc.Driver):
+		c = exec.Command("virsh", "-c", cc.KVMQemuURI, "dumpxml", name)
+		file, desc = "domain.xml", "the libvirt domain of the node"
+	default:
+		return
+	}
+	o, err := c.CombinedOutput()
+	if err != nil {
+		b.AddError(path.Join(dir, file), desc, fmt.Errorf("%s: %v: %s", c.Args, err, o))
+		return
+	}
+	if driver.IsKIC(cc.Driver) {
+		b.AddJSON(path.Join(dir, file), desc, o)
+		return
+	}
+	b.Add(path.Join(dir, file), desc, o)
+}
+
+// nodeLogs returns the logs of a node as written by minikube logs
+func nodeLogs(cr cruntime.Manager, bs bootstrapper.Bootstrapper, cc *config.ClusterConfig, r command.Runner) ([]byte, error) {
+	f, err := os.CreateTemp("", "minikube-bugreport-logs")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+	logs.Output(cr, bs, *cc, r, bugreportLines, f)
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+	return os.ReadFile(f.Name())
+}
+
+// addNodeCommand adds the output of a shell command run on a node
+func addNodeCommand(b *bugreport.Bundle, r command.Runner, name, description, cmd string) {
+	var o bytes.Buffer
+	c := exec.Command("/bin/bash", "-c", cmd)
+	c.Stdout = &o
+	c.Stderr = &o
+	if _, err := r.RunCmd(c); err != nil {
+		klog.Warningf("%s: %v", cmd, err)
+		if o.Len() == 0 {
+			b.AddError(name, description, err)
+			return
+		}
+	}
+	b.Add(name, description, o.Bytes())
+}
+
+func init() {
+	bugreportCmd.Flags().StringVar(&bugreportOutput, "output-file", "", "The path of the bug report tarball. Defaults to minikube-bugreport-<profile>-<time>.tar.gz in the current directory")
+	bugreportCmd.Flags().IntVarP(&bugreportLines, "length", "n", 200, "Number of lines back to go within the logs")
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/addons"
+	"k8s.io/minikube/pkg/addons/plugin"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var addonsInstallCmd = &cobra.Command{
+	Use:   "install SOURCE",
+	Short: "Installs an addon plugin from a directory, a Git repository or an OCI artifact",
+	Long: `Installs an addon plugin, an external addon defined by an addon.yaml manifest next to its Kubernetes manifests. Once installed, it is listed, enabled and disabled like the built-in addons in every profile.
+
+SOURCE is a local directory, a Git repository URL ending in .git or prefixed with git::, optionally followed by #BRANCH_OR_TAG, or an OCI artifact as oci://REFERENCE whose layers hold the plugin directory. Installing a plugin again replaces it.`,
+	Example: `minikube addons install ./mesh-config
+minikube addons install https://github.com/example/mesh-config.git#v1.2.0
+minikube addons install oci://ghcr.io/example/mesh-config:v1.2.0`,
+	Run: func(_ *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.Message(reason.Usage, "usage: minikube addons install SOURCE")
+		}
+		p, err := plugin.Install(args[0])
+		if err != nil {
+			exit.Error(reason.InternalAddonInstall, "install failed", err)
+		}
+		// built-in addons cannot be replaced
+		if err := addons.RegisterPlugin(p); err != nil {
+			if uerr := plugin.Uninstall(p.Name); uerr != nil {
+				out.WarningT("Failed to remove the addon plugin: {{.error}}", out.V{"error": uerr})
+			}
+			exit.Error(reason.InternalAddonInstall, "install failed", err)
+		}
+		out.Step(style.AddonEnable, "The '{{.name}}' addon plugin is installed, enable it with: minikube addons enable {{.name}}", out.V{"name": p.Name})
+	},
+}
+
+func init() {
+	AddonsCmd.AddCommand(addonsInstallCmd)
+}
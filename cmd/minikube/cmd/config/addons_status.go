@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/addons"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var addonStatusOutput string
+
+var addonsStatusCmd = &cobra.Command{
+	Use:   "status [ADDON_NAME...]",
+	Short: "Shows the health of the enabled addons",
+	Long: `Shows the health of the enabled addons, or of the given ones: whether their workloads are ready, whether their containers run the images the current minikube deploys, and the fields of their objects which drifted from the manifests the current minikube renders.
+
+Outdated images and drift are fixed by minikube addons upgrade.`,
+	Example: `minikube addons status
+minikube addons status ingress -o json`,
+	Run: func(_ *cobra.Command, args []string) {
+		co := mustload.Running(ClusterFlagValue())
+		names := selectedAddons(co.Config, args)
+
+		var statuses []*addons.Status
+		for _, name := range names {
+			s, err := addons.AddonStatus(co.Config, co.CP.Runner, name)
+			if err != nil {
+				out.FailureT("Failed to get the status of the '{{.name}}' addon: {{.error}}", out.V{"name": name, "error": err})
+				continue
+			}
+			statuses = append(statuses, s)
+		}
+
+		switch strings.ToLower(addonStatusOutput) {
+		case "table":
+			printAddonsStatus(statuses)
+		case "json":
+			b, err := json.Marshal(statuses)
+			if err != nil {
+				exit.Error(reason.InternalJSONMarshal, "addons status json marshal", err)
+			}
+			out.String(string(b))
+		default:
+			exit.Message(reason.Usage, fmt.Sprintf("invalid output format: %s. Valid values: 'table', 'json'", addonStatusOutput))
+		}
+	},
+}
+
+// selectedAddons returns the addons named on the command line, which must be enabled, or all the enabled addons
+func selectedAddons(cc *config.ClusterConfig, args []string) []string {
+	if len(args) == 0 {
+		return addons.EnabledAddons(cc)
+	}
+	for _, name := range args {
+		a, ok := assets.Addons[name]
+		if !ok {
+			exit.Message(reason.Usage, "{{.name}} is not a valid addon", out.V{"name": name})
+		}
+		if !a.IsEnabled(cc) {
+			exit.Message(reason.Usage, "The '{{.name}}' addon is not enabled", out.V{"name": name})
+		}
+	}
+	return args
+}
+
+func printAddonsStatus(statuses []*addons.Status) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetAutoFormatHeaders(true)
+	table.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+	table.SetCenterSeparator("|")
+	table.SetHeader([]string{"Addon Name", "Ready", "Images", "Drift"})
+
+	var details []string
+	unhealthy := false
+	for _, s := range statuses {
+		ready := "yes"
+		if !s.Ready {
+			ready = fmt.Sprintf("no (%d/%d workloads)", s.ReadyWorkloads, s.Workloads)
+		}
+		images := "up to date"
+		if outdated := s.OutdatedImages(); len(outdated) > 0 {
+			images = fmt.Sprintf("%d outdated", len(outdated))
+			for _, i := range outdated {
+				details = append(details, fmt.Sprintf("%s: %s container %s runs %s instead of %s", s.Name, i.Object, i.Container, imageName(i.Running), imageName(i.Expected)))
+			}
+		}
+		drift := "none"
+		if len(s.Drift) > 0 {
+			drift = fmt.Sprintf("%d fields", len(s.Drift))
+			for _, d := range s.Drift {
+				details = append(details, fmt.Sprintf("%s: %s differs from the manifest", s.Name, d))
+			}
+		}
+		for _, m := range s.Missing {
+			details = append(details, fmt.Sprintf("%s: %s is missing", s.Name, m))
+		}
+		if !s.Ready || images != "up to date" || len(s.Drift) > 0 {
+			unhealthy = true
+		}
+		table.Append([]string{s.Name, ready, images, drift})
+	}
+	table.Render()
+
+	for _, d := range details {
+		out.Styled(style.Indent, d)
+	}
+	if unhealthy {
+		out.Styled(style.Tip, "To apply the manifests of the current minikube to the addons, run: minikube addons upgrade")
+	}
+}
+
+// imageName returns an image without its digest
+func imageName(image string) string {
+	if image == "" {
+		return "nothing"
+	}
+	return strings.Split(image, "@")[0]
+}
+
+func init() {
+	addonsStatusCmd.Flags().StringVarP(&addonStatusOutput, "output", "o", "table", "minikube addons status --output OUTPUT. json, table")
+	AddonsCmd.AddCommand(addonsStatusCmd)
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/addons"
+	"k8s.io/minikube/pkg/addons/plugin"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var addonsUninstallCmd = &cobra.Command{
+	Use:     "uninstall ADDON_NAME",
+	Short:   "Uninstalls an addon plugin installed with minikube addons install",
+	Long:    "Uninstalls an addon plugin installed with minikube addons install. It must be disabled in every profile first.",
+	Example: "minikube addons uninstall mesh-config",
+	Run: func(_ *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.Message(reason.Usage, "usage: minikube addons uninstall ADDON_NAME")
+		}
+		name := args[0]
+		if !addons.IsPlugin(name) {
+			exit.Message(reason.AddonUnsupported, "'{{.name}}' is not an installed addon plugin", out.V{"name": name})
+		}
+
+		validProfiles, _, err := config.ListProfiles()
+		if err != nil {
+			klog.Warningf("list profiles: %v", err)
+		}
+		for _, p := range validProfiles {
+			if p.Config != nil && p.Config.Addons[name] {
+				exit.Message(reason.Usage, "The '{{.name}}' addon is enabled in profile {{.profile}}, disable it first: minikube addons disable {{.name}} -p {{.profile}}", out.V{"name": name, "profile": p.Name})
+			}
+		}
+
+		if err := plugin.Uninstall(name); err != nil {
+			exit.Error(reason.InternalAddonInstall, "uninstall failed", err)
+		}
+		out.Step(style.Deleted, "The '{{.name}}' addon plugin is uninstalled", out.V{"name": name})
+	},
+}
+
+func init() {
+	AddonsCmd.AddCommand(addonsUninstallCmd)
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/addons"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var addonsUpgradeCmd = &cobra.Command{
+	Use:   "upgrade [ADDON_NAME...]",
+	Short: "Applies the manifests of the current minikube to the enabled addons",
+	Long: `Re-renders the manifests of the enabled addons, or of the given ones, with the current minikube and applies them in place with server-side apply. Objects of the previously applied manifests which the addons no longer have are deleted.
+
+Addons are upgraded after the addons they depend on.`,
+	Example: `minikube addons upgrade
+minikube addons upgrade ingress metrics-server`,
+	Run: func(_ *cobra.Command, args []string) {
+		profile := ClusterFlagValue()
+		co := mustload.Running(profile)
+		if err := addons.VerifyNotPaused(profile, true); err != nil {
+			exit.Error(reason.InternalAddonEnablePaused, "upgrade failed", err)
+		}
+
+		names := selectedAddons(co.Config, args)
+		if len(args) > 0 {
+			// upgrade the dependencies first
+			names = orderedSubset(addons.EnabledAddons(co.Config), names)
+		}
+		for _, name := range names {
+			out.Step(style.AddonEnable, "Upgrading the '{{.name}}' addon ...", out.V{"name": name})
+			pruned, err := addons.Upgrade(co.Config, co.CP.Runner, name)
+			for _, o := range pruned {
+				out.Styled(style.Deleted, "Deleted {{.object}}, which the addon no longer has", out.V{"object": o})
+			}
+			if err != nil {
+				exit.Error(reason.InternalAddonEnable, "upgrade failed", err)
+			}
+		}
+		out.Step(style.Ready, "The addons are upgraded, check them with: minikube addons status")
+	},
+}
+
+// orderedSubset returns the names of subset in the order of all, followed by the ones all does not have
+func orderedSubset(all, subset []string) []string {
+	want := map[string]bool{}
+	for _, name := range subset {
+		want[name] = true
+	}
+	var names []string
+	for _, name := range all {
+		if want[name] {
+			names = append(names, name)
+			delete(want, name)
+		}
+	}
+	for _, name := range subset {
+		if want[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func init() {
+	AddonsCmd.AddCommand(addonsUpgradeCmd)
+}
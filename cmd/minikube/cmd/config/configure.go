@@ -17,10 +17,9 @@ limitations under the License.
 package config
 
 import (
-	"net"
+	"fmt"
 	"os"
-	"regexp"
-	"time"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"k8s.io/minikube/pkg/addons"
@@ -31,16 +30,25 @@ import (
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/reason"
 	"k8s.io/minikube/pkg/minikube/service"
-	"k8s.io/minikube/pkg/minikube/style"
 )
 
 var posResponses = []string{"yes", "y"}
 var negResponses = []string{"no", "n"}
 
+var (
+	valueFiles []string
+	valueSets  []string
+)
+
 var addonsConfigureCmd = &cobra.Command{
 	Use:   "configure ADDON_NAME",
 	Short: "Configures the addon w/ADDON_NAME within minikube (example: minikube addons configure registry-creds). For a list of available addons use: minikube addons list",
-	Long:  "Configures the addon w/ADDON_NAME within minikube (example: minikube addons configure registry-creds). For a list of available addons use: minikube addons list",
+	Long: `Configures the addon w/ADDON_NAME within minikube (example: minikube addons configure registry-creds). For a list of available addons use: minikube addons list
+
+The values of the addon are read from --values files and --set flags, or prompted for when neither is given on a terminal. They are saved to the profile and rendered into the addon, which is updated if it is enabled.`,
+	Example: `minikube addons configure metallb --set startIP=192.168.49.100 --set endIP=192.168.49.120
+minikube addons configure registry-aliases --set aliases=example.com,example.org
+minikube addons configure registry-creds --values registry-creds.yaml`,
 	Run: func(_ *cobra.Command, args []string) {
 		if len(args) != 1 {
 			exit.Message(reason.Usage, "usage: minikube addons configure ADDON_NAME")
@@ -48,242 +56,199 @@ var addonsConfigureCmd = &cobra.Command{
 
 		profile := ClusterFlagValue()
 
-		addon := args[0]
-		// allows for additional prompting of information when enabling addons
-		switch addon {
-		case "registry-creds":
-
-			// Default values
-			awsAccessID := "changeme"
-			awsAccessKey := "changeme"
-			awsSessionToken := ""
-			awsRegion := "changeme"
-			awsAccount := "changeme"
-			awsRole := "changeme"
-			gcrApplicationDefaultCredentials := "changeme"
-			dockerServer := "changeme"
-			dockerUser := "changeme"
-			dockerPass := "changeme"
-			gcrURL := "https://gcr.io"
-			acrURL := "changeme"
-			acrClientID := "changeme"
-			acrPassword := "changeme"
-
-			enableAWSECR := AskForYesNoConfirmation("\nDo you want to enable AWS Elastic Container Registry?", posResponses, negResponses)
-			if enableAWSECR {
-				awsAccessID = AskForStaticValue("-- Enter AWS Access Key ID: ")
-				awsAccessKey = AskForStaticValue("-- Enter AWS Secret Access Key: ")
-				awsSessionToken = AskForStaticValueOptional("-- (Optional) Enter AWS Session Token: ")
-				awsRegion = AskForStaticValue("-- Enter AWS Region: ")
-				awsAccount = AskForStaticValue("-- Enter 12 digit AWS Account ID (Comma separated list): ")
-				awsRole = AskForStaticValueOptional("-- (Optional) Enter ARN of AWS role to assume: ")
-			}
-
-			enableGCR := AskForYesNoConfirmation("\nDo you want to enable Google Container Registry?", posResponses, negResponses)
-			if enableGCR {
-				gcrPath := AskForStaticValue("-- Enter path to credentials (e.g. /home/user/.config/gcloud/application_default_credentials.json):")
-				gcrchangeURL := AskForYesNoConfirmation("-- Do you want to change the GCR URL (Default https://gcr.io)?", posResponses, negResponses)
-
-				if gcrchangeURL {
-					gcrURL = AskForStaticValue("-- Enter GCR URL (e.g. https://asia.gcr.io):")
-				}
-
-				// Read file from disk
-				dat, err := os.ReadFile(gcrPath)
-
-				if err != nil {
-					out.FailureT("Error reading {{.path}}: {{.error}}", out.V{"path": gcrPath, "error": err})
-				} else {
-					gcrApplicationDefaultCredentials = string(dat)
-				}
-			}
-
-			enableDR := AskForYesNoConfirmation("\nDo you want to enable Docker Registry?", posResponses, negResponses)
-			if enableDR {
-				dockerServer = AskForStaticValue("-- Enter docker registry server url: ")
-				dockerUser = AskForStaticValue("-- Enter docker registry username: ")
-				dockerPass = AskForPasswordValue("-- Enter docker registry password: ")
-			}
-
-			enableACR := AskForYesNoConfirmation("\nDo you want to enable Azure Container Registry?", posResponses, negResponses)
-			if enableACR {
-				acrURL = AskForStaticValue("-- Enter Azure Container Registry (ACR) URL: ")
-				acrClientID = AskForStaticValue("-- Enter client ID (service principal ID) to access ACR: ")
-				acrPassword = AskForPasswordValue("-- Enter service principal password to access Azure Container Registry: ")
-			}
-
-			namespace := "kube-system"
-
-			// Create ECR Secret
-			err := service.CreateSecret(
-				profile,
-				namespace,
-				"registry-creds-ecr",
-				map[string]string{
-					"AWS_ACCESS_KEY_ID":     awsAccessID,
-					"AWS_SECRET_ACCESS_KEY": awsAccessKey,
-					"AWS_SESSION_TOKEN":     awsSessionToken,
-					"aws-account":           awsAccount,
-					"aws-region":            awsRegion,
-					"aws-assume-role":       awsRole,
-				},
-				map[string]string{
-					"app":                           "registry-creds",
-					"cloud":                         "ecr",
-					"kubernetes.io/minikube-addons": "registry-creds",
-				})
-			if err != nil {
-				out.FailureT("ERROR creating `registry-creds-ecr` secret: {{.error}}", out.V{"error": err})
-			}
-
-			// Create GCR Secret
-			err = service.CreateSecret(
-				profile,
-				namespace,
-				"registry-creds-gcr",
-				map[string]string{
-					"application_default_credentials.json": gcrApplicationDefaultCredentials,
-					"gcrurl":                               gcrURL,
-				},
-				map[string]string{
-					"app":                           "registry-creds",
-					"cloud":                         "gcr",
-					"kubernetes.io/minikube-addons": "registry-creds",
-				})
-
-			if err != nil {
-				out.FailureT("ERROR creating `registry-creds-gcr` secret: {{.error}}", out.V{"error": err})
-			}
-
-			// Create Docker Secret
-			err = service.CreateSecret(
-				profile,
-				namespace,
-				"registry-creds-dpr",
-				map[string]string{
-					"DOCKER_PRIVATE_REGISTRY_SERVER":   dockerServer,
-					"DOCKER_PRIVATE_REGISTRY_USER":     dockerUser,
-					"DOCKER_PRIVATE_REGISTRY_PASSWORD": dockerPass,
-				},
-				map[string]string{
-					"app":                           "registry-creds",
-					"cloud":                         "dpr",
-					"kubernetes.io/minikube-addons": "registry-creds",
-				})
-
-			if err != nil {
-				out.WarningT("ERROR creating `registry-creds-dpr` secret")
-			}
-
-			// Create Azure Container Registry Secret
-			err = service.CreateSecret(
-				profile,
-				namespace,
-				"registry-creds-acr",
-				map[string]string{
-					"ACR_URL":       acrURL,
-					"ACR_CLIENT_ID": acrClientID,
-					"ACR_PASSWORD":  acrPassword,
-				},
-				map[string]string{
-					"app":                           "registry-creds",
-					"cloud":                         "acr",
-					"kubernetes.io/minikube-addons": "registry-creds",
-				})
-
-			if err != nil {
-				out.WarningT("ERROR creating `registry-creds-acr` secret")
-			}
-
-		case "metallb":
-			_, cfg := mustload.Partial(profile)
+		name := args[0]
+		addon, ok := assets.Addons[name]
+		if !ok || len(addon.Values) == 0 {
+			out.FailureT("{{.name}} has no available configuration options", out.V{"name": name})
+			return
+		}
 
-			validator := func(s string) bool {
-				return net.ParseIP(s) != nil
+		if !setAddonValues(profile, name) {
+			if !out.IsTerminal(os.Stdin) {
+				exit.Message(reason.Usage, "No values given, configure {{.name}} with --values or --set, its values are: {{.values}}", out.V{"name": name, "values": strings.Join(addon.ValueNames(), ", ")})
 			}
+			promptAddonValues(profile, addon)
+		}
 
-			cfg.KubernetesConfig.LoadBalancerStartIP = AskForStaticValidatedValue("-- Enter Load Balancer Start IP: ", validator)
-
-			cfg.KubernetesConfig.LoadBalancerEndIP = AskForStaticValidatedValue("-- Enter Load Balancer End IP: ", validator)
-
-			if err := config.SaveProfile(profile, cfg); err != nil {
-				out.ErrT(style.Fatal, "Failed to save config {{.profile}}", out.V{"profile": profile})
+		_, cc := mustload.Partial(profile)
+		if name == "registry-creds" {
+			configureRegistryCreds(profile, addon, cc)
+		} else if addon.IsEnabled(cc) {
+			// Re-enable the addon in order to render its templates with the new values
+			if err := addons.EnableOrDisableAddon(cc, name, "true"); err != nil {
+				exit.Error(reason.InternalAddonEnable, fmt.Sprintf("Failed to configure %s", name), err)
 			}
+		}
 
-			// Re-enable metallb addon in order to generate template manifest files with Load Balancer Start/End IP
-			if err := addons.EnableOrDisableAddon(cfg, "metallb", "true"); err != nil {
-				out.ErrT(style.Fatal, "Failed to configure metallb IP {{.profile}}", out.V{"profile": profile})
-			}
-		case "ingress":
-			_, cfg := mustload.Partial(profile)
+		out.SuccessT("{{.name}} was successfully configured", out.V{"name": name})
+	},
+}
 
-			validator := func(s string) bool {
-				format := regexp.MustCompile("^.+/.+$")
-				return format.MatchString(s)
-			}
+// setAddonValues saves the values of the --values and --set flags to the profile, and returns false if there are none
+func setAddonValues(profile, name string) bool {
+	if len(valueFiles) == 0 && len(valueSets) == 0 {
+		return false
+	}
+	values, err := addons.ParseValues(valueFiles, valueSets)
+	if err != nil {
+		exit.Message(reason.Usage, "Invalid addon values: {{.error}}", out.V{"error": err})
+	}
+	_, cc := mustload.Partial(profile)
+	if err := addons.SetValues(cc, name, values); err != nil {
+		exit.Message(reason.Usage, "Invalid addon values: {{.error}}", out.V{"error": err})
+	}
+	if err := config.SaveProfile(profile, cc); err != nil {
+		exit.Error(reason.HostSaveProfile, "Failed to save config", err)
+	}
+	return true
+}
 
-			customCert := AskForStaticValidatedValue("-- Enter custom cert (format is \"namespace/secret\"): ", validator)
-			if cfg.KubernetesConfig.CustomIngressCert != "" {
-				overwrite := AskForYesNoConfirmation("A custom cert for ingress has already been set. Do you want overwrite it?", posResponses, negResponses)
-				if !overwrite {
+// promptAddonValues asks for each value of the addon and saves them to the profile, keeping the current ones on empty input
+func promptAddonValues(profile string, addon *assets.Addon) {
+	_, cc := mustload.Partial(profile)
+	values := map[string]string{}
+	for _, v := range addon.Values {
+		desc := v.Description
+		if desc == "" {
+			desc = v.Name
+		}
+		for {
+			var s string
+			if v.Secret {
+				if !AskForYesNoConfirmation(fmt.Sprintf("-- Do you want to set the %s?", desc), posResponses, negResponses) {
 					break
 				}
-			}
-
-			cfg.KubernetesConfig.CustomIngressCert = customCert
-
-			if err := config.SaveProfile(profile, cfg); err != nil {
-				out.ErrT(style.Fatal, "Failed to save config {{.profile}}", out.V{"profile": profile})
-			}
-		case "registry-aliases":
-			_, cfg := mustload.Partial(profile)
-			validator := func(s string) bool {
-				format := regexp.MustCompile(`^([a-zA-Z0-9-_]+\.[a-zA-Z0-9-_]+)+(\ [a-zA-Z0-9-_]+\.[a-zA-Z0-9-_]+)*$`)
-				return format.MatchString(s)
-			}
-			registryAliases := AskForStaticValidatedValue("-- Enter registry aliases separated by space: ", validator)
-			cfg.KubernetesConfig.RegistryAliases = registryAliases
-
-			if err := config.SaveProfile(profile, cfg); err != nil {
-				out.ErrT(style.Fatal, "Failed to save config {{.profile}}", out.V{"profile": profile})
-			}
-			addon := assets.Addons["registry-aliases"]
-			if addon.IsEnabled(cfg) {
-				// Re-enable registry-aliases addon in order to generate template manifest files with custom hosts
-				if err := addons.EnableOrDisableAddon(cfg, "registry-aliases", "true"); err != nil {
-					out.ErrT(style.Fatal, "Failed to configure registry-aliases {{.profile}}", out.V{"profile": profile})
+				s = AskForPasswordValue(fmt.Sprintf("-- Enter %s: ", desc))
+			} else {
+				s = AskForStaticValueOptional(fmt.Sprintf("-- Enter %s [%s]: ", desc, addon.RawValue(cc, v)))
+				if s == "" {
+					break
 				}
 			}
-		case "auto-pause":
-			_, cfg := mustload.Partial(profile)
-			intervalInput := AskForStaticValue("-- Enter interval time of auto-pause-interval (ex. 1m0s): ")
-			intervalTime, err := time.ParseDuration(intervalInput)
-			if err != nil {
-				out.ErrT(style.Fatal, "Interval is an invalid duration: {{.error}}", out.V{"error": err})
-			}
-			if intervalTime != intervalTime.Abs() || intervalTime.String() == "0s" {
-				out.ErrT(style.Fatal, "Interval must be greater than 0s")
+			if _, err := v.Parse(s); err != nil {
+				out.FailureT("Invalid value: {{.error}}", out.V{"error": err})
+				continue
 			}
-			cfg.AutoPauseInterval = intervalTime
-			if err := config.SaveProfile(profile, cfg); err != nil {
-				out.ErrT(style.Fatal, "Failed to save config {{.profile}}", out.V{"profile": profile})
-			}
-			addon := assets.Addons["auto-pause"]
-			if addon.IsEnabled(cfg) {
-				// Re-enable auto-pause addon in order to update interval time
-				if err := addons.EnableOrDisableAddon(cfg, "auto-pause", "true"); err != nil {
-					out.ErrT(style.Fatal, "Failed to configure auto-pause {{.profile}}", out.V{"profile": profile})
-				}
-			}
-		default:
-			out.FailureT("{{.name}} has no available configuration options", out.V{"name": addon})
-			return
+			values[v.Name] = s
+			break
 		}
+	}
+	if err := addons.SetValues(cc, addon.Name(), values); err != nil {
+		exit.Message(reason.Usage, "Invalid addon values: {{.error}}", out.V{"error": err})
+	}
+	if err := config.SaveProfile(profile, cc); err != nil {
+		exit.Error(reason.HostSaveProfile, "Failed to save config", err)
+	}
+}
 
-		out.SuccessT("{{.name}} was successfully configured", out.V{"name": addon})
-	},
+// configureRegistryCreds creates the secrets read by the registry-creds addon from its values
+func configureRegistryCreds(profile string, addon *assets.Addon, cc *config.ClusterConfig) {
+	values, err := addon.ResolveValues(cc)
+	if err != nil {
+		exit.Message(reason.Usage, "Invalid addon values: {{.error}}", out.V{"error": err})
+	}
+	value := func(name string) string {
+		return values[name].(string)
+	}
+
+	gcrApplicationDefaultCredentials := "changeme"
+	if gcrPath := value("gcrCredentialsFile"); gcrPath != "" {
+		// Read file from disk
+		dat, err := os.ReadFile(gcrPath)
+
+		if err != nil {
+			out.FailureT("Error reading {{.path}}: {{.error}}", out.V{"path": gcrPath, "error": err})
+		} else {
+			gcrApplicationDefaultCredentials = string(dat)
+		}
+	}
+
+	namespace := "kube-system"
+
+	// Create ECR Secret
+	err = service.CreateSecret(
+		profile,
+		namespace,
+		"registry-creds-ecr",
+		map[string]string{
+			"AWS_ACCESS_KEY_ID":     value("awsAccessKeyID"),
+			"AWS_SECRET_ACCESS_KEY": value("awsSecretAccessKey"),
+			"AWS_SESSION_TOKEN":     value("awsSessionToken"),
+			"aws-account":           value("awsAccount"),
+			"aws-region":            value("awsRegion"),
+			"aws-assume-role":       value("awsRole"),
+		},
+		map[string]string{
+			"app":                           "registry-creds",
+			"cloud":                         "ecr",
+			"kubernetes.io/minikube-addons": "registry-creds",
+		})
+	if err != nil {
+		out.FailureT("ERROR creating `registry-creds-ecr` secret: {{.error}}", out.V{"error": err})
+	}
+
+	// Create GCR Secret
+	err = service.CreateSecret(
+		profile,
+		namespace,
+		"registry-creds-gcr",
+		map[string]string{
+			"application_default_credentials.json": gcrApplicationDefaultCredentials,
+			"gcrurl":                               value("gcrURL"),
+		},
+		map[string]string{
+			"app":                           "registry-creds",
+			"cloud":                         "gcr",
+			"kubernetes.io/minikube-addons": "registry-creds",
+		})
+
+	if err != nil {
+		out.FailureT("ERROR creating `registry-creds-gcr` secret: {{.error}}", out.V{"error": err})
+	}
+
+	// Create Docker Secret
+	err = service.CreateSecret(
+		profile,
+		namespace,
+		"registry-creds-dpr",
+		map[string]string{
+			"DOCKER_PRIVATE_REGISTRY_SERVER":   value("dockerServer"),
+			"DOCKER_PRIVATE_REGISTRY_USER":     value("dockerUser"),
+			"DOCKER_PRIVATE_REGISTRY_PASSWORD": value("dockerPassword"),
+		},
+		map[string]string{
+			"app":                           "registry-creds",
+			"cloud":                         "dpr",
+			"kubernetes.io/minikube-addons": "registry-creds",
+		})
+
+	if err != nil {
+		out.WarningT("ERROR creating `registry-creds-dpr` secret")
+	}
+
+	// Create Azure Container Registry Secret
+	err = service.CreateSecret(
+		profile,
+		namespace,
+		"registry-creds-acr",
+		map[string]string{
+			"ACR_URL":       value("acrURL"),
+			"ACR_CLIENT_ID": value("acrClientID"),
+			"ACR_PASSWORD":  value("acrPassword"),
+		},
+		map[string]string{
+			"app":                           "registry-creds",
+			"cloud":                         "acr",
+			"kubernetes.io/minikube-addons": "registry-creds",
+		})
+
+	if err != nil {
+		out.WarningT("ERROR creating `registry-creds-acr` secret")
+	}
 }
 
 func init() {
+	addonsConfigureCmd.Flags().StringArrayVarP(&valueFiles, "values", "f", nil, "YAML file of the addon values, may be given multiple times")
+	addonsConfigureCmd.Flags().StringArrayVar(&valueSets, "set", nil, "Addon value as name=value, lists are separated by commas. May be given multiple times and overrides --values")
 	AddonsCmd.AddCommand(addonsConfigureCmd)
 }
@@ -59,5 +59,6 @@ var addonsDisableCmd = &cobra.Command{
 }
 
 func init() {
+	addonsDisableCmd.Flags().BoolVar(&addons.Force, "force", false, "If true, disable the addon even if enabled addons depend on it")
 	AddonsCmd.AddCommand(addonsDisableCmd)
 }
@@ -33,10 +33,11 @@ import (
 )
 
 var addonsEnableCmd = &cobra.Command{
-	Use:     "enable ADDON_NAME",
-	Short:   "Enables the addon w/ADDON_NAME within minikube. For a list of available addons use: minikube addons list ",
-	Long:    "Enables the addon w/ADDON_NAME within minikube. For a list of available addons use: minikube addons list ",
-	Example: "minikube addons enable dashboard",
+	Use:   "enable ADDON_NAME",
+	Short: "Enables the addon w/ADDON_NAME within minikube. For a list of available addons use: minikube addons list ",
+	Long:  "Enables the addon w/ADDON_NAME within minikube. For a list of available addons use: minikube addons list ",
+	Example: `minikube addons enable dashboard
+minikube addons enable metallb --set startIP=192.168.49.100 --set endIP=192.168.49.120`,
 	Run: func(_ *cobra.Command, args []string) {
 		if len(args) != 1 {
 			exit.Message(reason.Usage, "usage: minikube addons enable ADDON_NAME")
@@ -83,6 +84,7 @@ You can view the list of minikube maintainers at: https://github.com/kubernetes/
 		if registries != "" {
 			viper.Set(config.AddonRegistries, registries)
 		}
+		setAddonValues(ClusterFlagValue(), addon)
 		err = addons.SetAndSave(ClusterFlagValue(), addon, "true")
 		if err != nil && !errors.Is(err, addons.ErrSkipThisAddon) {
 			exit.Error(reason.InternalAddonEnable, "enable failed", err)
@@ -110,5 +112,7 @@ func init() {
 	addonsEnableCmd.Flags().StringVar(&registries, "registries", "", "Registries used by this addon. Separated by commas.")
 	addonsEnableCmd.Flags().BoolVar(&addons.Force, "force", false, "If true, will perform potentially dangerous operations. Use with discretion.")
 	addonsEnableCmd.Flags().BoolVar(&addons.Refresh, "refresh", false, "If true, pods might get deleted and restarted on addon enable")
+	addonsEnableCmd.Flags().StringArrayVarP(&valueFiles, "values", "f", nil, "YAML file of the addon values, may be given multiple times")
+	addonsEnableCmd.Flags().StringArrayVar(&valueSets, "set", nil, "Addon value as name=value, lists are separated by commas. May be given multiple times and overrides --values")
 	AddonsCmd.AddCommand(addonsEnableCmd)
 }
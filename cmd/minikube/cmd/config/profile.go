@@ -17,8 +17,6 @@ limitations under the License.
 package config
 
 import (
-	"os"
-
 	"github.com/spf13/cobra"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/exit"
@@ -38,7 +36,7 @@ var ProfileCmd = &cobra.Command{
 		if len(args) == 0 {
 			profile := ClusterFlagValue()
 			out.Styled(style.Empty, profile)
-			os.Exit(0)
+			exit.Code(0)
 		}
 
 		if len(args) > 1 {
@@ -71,7 +69,7 @@ var ProfileCmd = &cobra.Command{
 
 		if !config.ProfileExists(profile) {
 			out.ErrT(style.Tip, `if you want to create a profile you can by this command: minikube start -p {{.profile_name}}`, out.V{"profile_name": profile})
-			os.Exit(0)
+			exit.Code(0)
 		}
 
 		err := Set(config.ProfileName, profile)
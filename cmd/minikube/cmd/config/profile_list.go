@@ -22,7 +22,9 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
+	"k8s.io/minikube/pkg/addons"
 	"k8s.io/minikube/pkg/minikube/cluster"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/constants"
@@ -31,6 +33,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/notify"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/schedule"
 	"k8s.io/minikube/pkg/minikube/style"
 
 	"github.com/docker/machine/libmachine"
@@ -125,7 +128,7 @@ func profileStatus(p *config.Profile, api libmachine.API) string {
 
 func renderProfilesTable(ps [][]string) {
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Profile", "VM Driver", "Runtime", "IP", "Port", "Version", "Status", "Nodes", "Active Profile", "Active Kubecontext"})
+	table.SetHeader([]string{"Profile", "VM Driver", "Runtime", "IP", "Port", "Version", "Status", "Idle", "Nodes", "Active Profile", "Active Kubecontext"})
 	table.SetAutoFormatHeaders(false)
 	table.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
 	table.SetCenterSeparator("|")
@@ -159,11 +162,21 @@ func profilesToTableData(profiles []*config.Profile) [][]string {
 		if p.ActiveKubeContext {
 			k = "*"
 		}
-		data = append(data, []string{p.Name, p.Config.Driver, p.Config.KubernetesConfig.ContainerRuntime, cpIP, strconv.Itoa(cpPort), k8sVersion, p.Status, strconv.Itoa(len(p.Config.Nodes)), c, k})
+		data = append(data, []string{p.Name, p.Config.Driver, p.Config.KubernetesConfig.ContainerRuntime, cpIP, strconv.Itoa(cpPort), k8sVersion, p.Status, profileIdle(p.Name), strconv.Itoa(len(p.Config.Nodes)), c, k})
 	}
 	return data
 }
 
+// profileIdle returns how long the API server of a profile saw no client activity, as measured for its idle schedules
+func profileIdle(name string) string {
+	now := time.Now()
+	st, ok := schedule.ReadIdleState(name)
+	if !ok || !st.Current(now) {
+		return "-"
+	}
+	return st.Idle(now).Round(time.Minute).String()
+}
+
 func warnInvalidProfiles(invalidProfiles []*config.Profile) {
 	if invalidProfiles == nil {
 		return
@@ -184,6 +197,13 @@ func printProfilesJSON() {
 	validProfiles, invalidProfiles, err := listProfiles()
 	updateProfilesStatus(validProfiles)
 
+	// profiles which were not started since secret addon values were moved out of their config may still have them
+	for _, p := range append(validProfiles, invalidProfiles...) {
+		if p.Config != nil {
+			addons.RedactSecretValues(p.Config)
+		}
+	}
+
 	var body = map[string]interface{}{}
 	if err == nil || config.IsNotExist(err) {
 		body["valid"] = profilesOrDefault(validProfiles)
@@ -194,7 +214,7 @@ func printProfilesJSON() {
 		body["error"] = err
 		jsonString, _ := json.Marshal(body)
 		os.Stdout.Write(jsonString)
-		os.Exit(reason.ExGuestError)
+		exit.Code(reason.ExGuestError)
 	}
 }
 
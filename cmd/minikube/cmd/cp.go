@@ -152,13 +152,13 @@ func remoteCommandRunner(co *mustload.ClusterController, nodeName string) comman
 	h, err := machine.GetHost(co.API, *co.Config, *n)
 	if err != nil {
 		out.ErrLn("%v", errors.Wrap(err, "getting host"))
-		os.Exit(1)
+		exit.Code(1)
 	}
 
 	runner, err := machine.CommandRunner(h)
 	if err != nil {
 		out.ErrLn("%v", errors.Wrap(err, "getting command runner"))
-		os.Exit(1)
+		exit.Code(1)
 	}
 
 	return runner
@@ -171,7 +171,7 @@ func copyableFile(co *mustload.ClusterController, src, dst *remotePath) assets.C
 		f, err := runner.ReadableFile(src.path)
 		if err != nil {
 			out.ErrLn("%v", errors.Wrapf(err, "getting file from %s node", src.node))
-			os.Exit(1)
+			exit.Code(1)
 		}
 
 		fakeWriter := func(_ []byte) (n int, err error) {
@@ -192,7 +192,7 @@ func copyableFile(co *mustload.ClusterController, src, dst *remotePath) assets.C
 	fa, err := assets.NewFileAsset(src.path, pt.Dir(dst.path), pt.Base(dst.path), "0644")
 	if err != nil {
 		out.ErrLn("%v", errors.Wrap(err, "getting file asset"))
-		os.Exit(1)
+		exit.Code(1)
 	}
 
 	return fa
@@ -542,14 +542,14 @@ func handleSingleDeletionError(err error) {
 		switch deletionError.Errtype {
 		case Fatal:
 			out.ErrT(style.Fatal, "Failed to delete profile(s): {{.error}}", out.V{"error": deletionError.Error()})
-			os.Exit(reason.ExGuestError)
+			exit.Code(reason.ExGuestError)
 		case MissingProfile:
 			out.ErrT(style.Sad, deletionError.Error())
 		case MissingCluster:
 			out.ErrT(style.Meh, deletionError.Error())
 		default:
 			out.ErrT(style.Fatal, "Unable to delete profile(s): {{.error}}", out.V{"error": deletionError.Error()})
-			os.Exit(reason.ExGuestError)
+			exit.Code(reason.ExGuestError)
 		}
 	} else {
 		exit.Error(reason.GuestDeletion, "Could not process error from failed deletion", err)
@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/docker/machine/libmachine/state"
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/autopause"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/journal"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+)
+
+var (
+	eventsSince  string
+	eventsFollow bool
+	eventsOutput string
+)
+
+// eventsCmd represents the events command
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Shows the timeline of cluster events",
+	Long: `Shows the timeline of lifecycle events of the cluster: starts, stops, pauses, addon changes, node changes, tunnels and mounts.
+Events are kept in a rotating journal in the profile directory, in the CloudEvents format.
+When the auto-pause addon is enabled, its pauses and unpauses, recorded within the control plane, are shown too.`,
+	Example: `minikube events --since 24h
+minikube events --since 2024-06-01T10:00:00Z -o json
+minikube events --follow`,
+	Run: func(_ *cobra.Command, _ []string) {
+		output := strings.ToLower(eventsOutput)
+		if output != "text" && output != "json" {
+			exit.Message(reason.Usage, fmt.Sprintf("invalid output format: %s. Valid values: 'text', 'json'", eventsOutput))
+		}
+		since, err := parseSince(eventsSince, time.Now())
+		if err != nil {
+			exit.Message(reason.Usage, "Invalid --since value: {{.error}}", out.V{"error": err})
+		}
+
+		cname := ClusterFlagValue()
+		api, cc := mustload.Partial(cname)
+
+		printEvent := func(ev journal.Event) {
+			if output == "json" {
+				out.String(ev.CloudEvent() + "\n")
+				return
+			}
+			out.String(ev.String() + "\n")
+		}
+
+		events, err := journal.Read(cname, since)
+		if err != nil {
+			exit.Error(reason.HostConfigLoad, "reading the event journal", err)
+		}
+		if cc.Addons["auto-pause"] {
+			events = append(events, autoPauseEvents(api, cc, since)...)
+			sort.SliceStable(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+		}
+		for _, ev := range events {
+			printEvent(ev)
+		}
+		if !eventsFollow {
+			return
+		}
+
+		ctrlC := make(chan os.Signal, 1)
+		signal.Notify(ctrlC, os.Interrupt)
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-ctrlC
+			cancel()
+		}()
+		if err := journal.Follow(ctx, cname, printEvent); err != nil {
+			exit.Error(reason.HostConfigLoad, "following the event journal", err)
+		}
+	},
+}
+
+// autoPauseEvents returns the pauses and unpauses of the auto-pause addon since the given time, which are recorded within the control plane
+func autoPauseEvents(api libmachine.API, cc *config.ClusterConfig, since time.Time) []journal.Event {
+	cp, err := config.ControlPlane(*cc)
+	if err != nil {
+		klog.Warningf("unable to get control plane: %v", err)
+		return nil
+	}
+	name := config.MachineName(*cc, cp)
+	if st, err := machine.Status(api, name); err != nil || st != state.Running.String() {
+		klog.Infof("not reading the auto-pause history, %s is not running: %s %v", name, st, err)
+		return nil
+	}
+	h, err := machine.LoadHost(api, name)
+	if err != nil {
+		klog.Warningf("unable to load host %s: %v", name, err)
+		return nil
+	}
+	r, err := machine.CommandRunner(h)
+	if err != nil {
+		klog.Warningf("unable to get command runner of %s: %v", name, err)
+		return nil
+	}
+	entries, err := autopause.ReadHistory(r)
+	if err != nil {
+		klog.Warningf("unable to read the auto-pause history: %v", err)
+		return nil
+	}
+
+	var events []journal.Event
+	for _, e := range entries {
+		if e.Time.Before(since) {
+			continue
+		}
+		kind, message := journal.ClusterPaused, "Paused by auto-pause"
+		if e.Action == autopause.Unpaused {
+			kind, message = journal.ClusterUnpaused, "Unpaused by auto-pause"
+		}
+		data := map[string]string{"source": "auto-pause", "reason": e.Reason, "containers": strconv.Itoa(e.Containers)}
+		events = append(events, journal.NewEvent(e.Time, kind, message, data))
+	}
+	return events
+}
+
+// parseSince parses a --since value, a duration before now or an RFC3339 timestamp
+func parseSince(since string, now time.Time) (time.Time, error) {
+	if since == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(since); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither a duration like 24h nor a timestamp like 2006-01-02T15:04:05Z", since)
+	}
+	return t, nil
+}
+
+func init() {
+	eventsCmd.Flags().StringVar(&eventsSince, "since", "", "Only show events newer than a duration like 24h, or a timestamp like 2006-01-02T15:04:05Z")
+	eventsCmd.Flags().BoolVarP(&eventsFollow, "follow", "f", false, "Keep showing new events as they are recorded")
+	eventsCmd.Flags().StringVarP(&eventsOutput, "output", "o", "text", "The output format. One of 'text', 'json'")
+}
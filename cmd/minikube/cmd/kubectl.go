@@ -29,6 +29,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/detect"
+	"k8s.io/minikube/pkg/minikube/exit"
 	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/mustload"
 	"k8s.io/minikube/pkg/minikube/node"
@@ -79,7 +80,7 @@ host. Please be aware that when using --ssh all paths will apply to the remote m
 			err := machine.CreateSSHShell(co.API, *co.Config, *n, args, false)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error running kubectl: %v", err)
-				os.Exit(1)
+				exit.Code(1)
 			}
 			return
 		}
@@ -94,7 +95,7 @@ host. Please be aware that when using --ssh all paths will apply to the remote m
 		}
 		if !supported {
 			fmt.Fprintf(os.Stderr, "Not supported on: %s\n", arch)
-			os.Exit(1)
+			exit.Code(1)
 		}
 
 		if len(args) > 0 {
@@ -120,7 +121,7 @@ host. Please be aware that when using --ssh all paths will apply to the remote m
 		c, err := KubectlCommand(version, binaryMirror, args...)
 		if err != nil {
 			out.ErrLn("Error caching kubectl: %v", err)
-			os.Exit(1)
+			exit.Code(1)
 		}
 
 		klog.Infof("Running %s %v", c.Path, args)
@@ -136,7 +137,7 @@ host. Please be aware that when using --ssh all paths will apply to the remote m
 				fmt.Fprintf(os.Stderr, "Error running %s: %v\n", c.Path, err)
 				rc = 1
 			}
-			os.Exit(rc)
+			exit.Code(rc)
 		}
 	},
 }
@@ -18,12 +18,17 @@ package cmd
 
 import (
 	"os"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/docker/machine/libmachine/state"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"k8s.io/klog/v2"
 	cmdcfg "k8s.io/minikube/cmd/minikube/cmd/config"
+	"k8s.io/minikube/pkg/kapi"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil/kverify"
 	"k8s.io/minikube/pkg/minikube/cluster"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/cruntime"
@@ -31,7 +36,9 @@ import (
 	"k8s.io/minikube/pkg/minikube/logs"
 	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/node"
 	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/problem"
 	"k8s.io/minikube/pkg/minikube/reason"
 	"k8s.io/minikube/pkg/minikube/style"
 )
@@ -55,6 +62,14 @@ var (
 	auditLogs bool
 	// lastStartOnly shows logs from last start
 	lastStartOnly bool
+	// logComponents are the components followed with --follow
+	logComponents []string
+	// logGrep only outputs the followed lines matching it
+	logGrep string
+	// logSince starts the followed logs at a time
+	logSince string
+	// logOutputFormat is the format of the followed lines
+	logOutputFormat string
 )
 
 // logsCmd represents the logs command
@@ -110,15 +125,34 @@ var logsCmd = &cobra.Command{
 			exit.Error(reason.InternalNewRuntime, "Unable to get runtime", err)
 		}
 		if followLogs {
-			err := logs.Follow(cr, bs, *co.Config, co.CP.Runner, logOutput)
-			if err != nil {
+			opts := followOptions()
+			nodes := followNodes(co)
+			var srcs []logs.Source
+			for _, n := range nodes {
+				srcs = append(srcs, logs.FollowSources(n, bs, *co.Config, opts)...)
+			}
+			// containers created while following, like restarted ones, are followed from their start
+			rescan := func() []logs.Source {
+				var srcs []logs.Source
+				for _, n := range nodes {
+					srcs = append(srcs, logs.ContainerSources(n, *co.Config, opts, 0)...)
+				}
+				return srcs
+			}
+			if err := logs.FollowNodes(srcs, rescan, opts, logOutput); err != nil {
 				exit.Error(reason.InternalLogFollow, "Follow", err)
 			}
 			return
 		}
 		if showProblems {
-			problems := logs.FindProblems(cr, bs, *co.Config, co.CP.Runner)
-			logs.OutputProblems(problems, numberOfProblems, logOutput)
+			problems := logs.DetectProblems(cr, bs, *co.Config, co.CP.Runner)
+			if client, err := kapi.Client(co.Config.Name); err == nil {
+				version := problem.ParseKubernetesVersion(co.Config.KubernetesConfig.KubernetesVersion)
+				problems = append(problems, kverify.NodeProblems(client, problem.Default(), version)...)
+			} else {
+				klog.Warningf("unable to get a client for the node problems: %v", err)
+			}
+			logs.OutputMatches(problems, numberOfProblems, logOutput)
 			return
 		}
 		logs.Output(cr, bs, *co.Config, co.CP.Runner, numberOfLines, logOutput)
@@ -128,6 +162,63 @@ var logsCmd = &cobra.Command{
 	},
 }
 
+// followOptions returns the filters of the followed logs given by the flags
+func followOptions() logs.FollowOptions {
+	format := strings.ToLower(logOutputFormat)
+	if format != "text" && format != "json" {
+		exit.Message(reason.Usage, "invalid output format: {{.output}}. Valid values: 'text', 'json'", out.V{"output": logOutputFormat})
+	}
+	opts := logs.FollowOptions{Components: logComponents, Lines: numberOfLines, JSON: format == "json"}
+	if logGrep != "" {
+		re, err := regexp.Compile(logGrep)
+		if err != nil {
+			exit.Message(reason.Usage, "Invalid --grep value: {{.error}}", out.V{"error": err})
+		}
+		opts.Grep = re
+	}
+	since, err := parseSince(logSince, time.Now())
+	if err != nil {
+		exit.Message(reason.Usage, "Invalid --since value: {{.error}}", out.V{"error": err})
+	}
+	opts.Since = since
+	return opts
+}
+
+// followNodes returns the node given by --node, or all running nodes, to follow the logs of
+func followNodes(co mustload.ClusterController) []logs.NodeLogs {
+	nodes := co.Config.Nodes
+	if nodeName != "" {
+		n, _, err := node.Retrieve(*co.Config, nodeName)
+		if err != nil {
+			exit.Message(reason.GuestNodeRetrieve, "Node {{.nodeName}} does not exist.", out.V{"nodeName": nodeName})
+		}
+		nodes = []config.Node{*n}
+	}
+
+	var followed []logs.NodeLogs
+	for _, n := range nodes {
+		name := config.MachineName(*co.Config, n)
+		if st, err := machine.Status(co.API, name); err != nil || st != state.Running.String() {
+			out.WarningT("Skipping the logs of node {{.name}}, it is not running", out.V{"name": name})
+			continue
+		}
+		h, err := machine.LoadHost(co.API, name)
+		if err != nil {
+			exit.Error(reason.GuestLoadHost, "Error getting host", err)
+		}
+		runner, err := machine.CommandRunner(h)
+		if err != nil {
+			exit.Error(reason.InternalCommandRunner, "Failed to get command runner", err)
+		}
+		cr, err := cruntime.New(cruntime.Config{Type: co.Config.KubernetesConfig.ContainerRuntime, Runner: runner})
+		if err != nil {
+			exit.Error(reason.InternalNewRuntime, "Unable to get runtime", err)
+		}
+		followed = append(followed, logs.NodeLogs{Name: name, Runtime: cr, Runner: runner})
+	}
+	return followed
+}
+
 // shouldSilentFail returns true if the user specifies the --file flag and the host isn't running
 // This is to prevent outputting the message 'The control plane node must be running for this command' which confuses
 // many users while gathering logs to report their issue as the message makes them think the log file wasn't generated
@@ -153,10 +244,14 @@ func shouldSilentFail() bool {
 }
 
 func init() {
-	logsCmd.Flags().BoolVarP(&followLogs, "follow", "f", false, "Show only the most recent journal entries, and continuously print new entries as they are appended to the journal.")
-	logsCmd.Flags().BoolVar(&showProblems, "problems", false, "Show only log entries which point to known problems")
+	logsCmd.Flags().BoolVarP(&followLogs, "follow", "f", false, "Show only the most recent log entries of kubelet, the container runtime and the system containers of all nodes, and continuously print new entries as they are appended. Each line is prefixed with its node and source.")
+	logsCmd.Flags().StringSliceVar(&logComponents, "component", nil, "With --follow, only follow the given components: kubelet, runtime or pod names like kube-proxy. Defaults to all of them.")
+	logsCmd.Flags().StringVar(&logGrep, "grep", "", "With --follow, only show the lines matching the given regular expression")
+	logsCmd.Flags().StringVar(&logSince, "since", "", "With --follow, start the logs at a duration like 10m ago, or a timestamp like 2006-01-02T15:04:05Z, instead of the last --length lines")
+	logsCmd.Flags().StringVarP(&logOutputFormat, "output", "o", "text", "With --follow, the output format of the lines. One of 'text', 'json'")
+	logsCmd.Flags().BoolVar(&showProblems, "problems", false, "Show only log entries which point to known problems, with their remediation. Extra problem rules are loaded from the YAML files in $MINIKUBE_HOME/rules.")
 	logsCmd.Flags().IntVarP(&numberOfLines, "length", "n", 60, "Number of lines back to go within the log")
-	logsCmd.Flags().StringVar(&nodeName, "node", "", "The node to get logs from. Defaults to the primary control plane.")
+	logsCmd.Flags().StringVar(&nodeName, "node", "", "The node to get logs from. Defaults to the primary control plane, or to all nodes with --follow.")
 	logsCmd.Flags().StringVar(&fileOutput, "file", "", "If present, writes to the provided file instead of stdout.")
 	logsCmd.Flags().BoolVar(&auditLogs, "audit", false, "Show only the audit logs")
 	logsCmd.Flags().BoolVar(&lastStartOnly, "last-start-only", false, "Show only the last start logs.")
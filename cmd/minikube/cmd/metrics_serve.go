@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/metrics"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var metricsListenAddress string
+
+// metricsServeCmd represents the metrics serve command
+var metricsServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serves Prometheus metrics of the minikube profiles",
+	Long: `Serves the metrics of the minikube profiles of this host in the Prometheus and OpenMetrics formats at /metrics:
+the CPU and memory usage of the node containers or VMs, node disk usage, pod counts, the component, pause and tunnel state, and the time since the last start.
+All profiles are collected unless a profile is given with --profile. The metrics are collected on every scrape.`,
+	Example: `minikube metrics serve
+minikube metrics serve --listen-address 0.0.0.0:9402 -p minikube`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		api, err := machine.NewAPIClient()
+		if err != nil {
+			exit.Error(reason.NewAPIClient, "Failed to get machine client", err)
+		}
+		defer api.Close()
+
+		var profiles []string
+		// the profile flag always has a value, only limit the profiles when it is given explicitly
+		if cmd.Flags().Changed("profile") {
+			profiles = append(profiles, ClusterFlagValue())
+		}
+
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(metrics.NewCollector(api, profiles...))
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{EnableOpenMetrics: true}))
+
+		out.Styled(style.Launch, "Serving metrics at http://{{.address}}/metrics, press Ctrl-C to stop", out.V{"address": metricsListenAddress})
+		server := &http.Server{
+			Addr:              metricsListenAddress,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+			ReadTimeout:       30 * time.Second,
+			// collecting the metrics of all profiles can take a while
+			WriteTimeout: 2 * time.Minute,
+			IdleTimeout:  2 * time.Minute,
+		}
+		if err := server.ListenAndServe(); err != nil {
+			exit.Error(reason.HostMetricsServe, "Failed to serve metrics", err)
+		}
+	},
+}
+
+func init() {
+	metricsServeCmd.Flags().StringVar(&metricsListenAddress, "listen-address", "127.0.0.1:9402", "The address to serve the metrics at")
+	metricsCmd.AddCommand(metricsServeCmd)
+}
@@ -36,6 +36,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/detect"
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/journal"
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/mustload"
 	"k8s.io/minikube/pkg/minikube/out"
@@ -96,7 +97,7 @@ var mountCmd = &cobra.Command{
 			if err := killMountProcess(); err != nil {
 				exit.Error(reason.HostKillMountProc, "Error killing mount process", err)
 			}
-			os.Exit(0)
+			exit.Code(0)
 		}
 
 		if len(args) != 1 {
@@ -239,6 +240,7 @@ var mountCmd = &cobra.Command{
 					out.FailureT("Failed removing pid from pidfile: {{.error}}", out.V{"error": err})
 				}
 
+				journal.Record(co.Config.Name, journal.MountStopped, fmt.Sprintf("%s was unmounted", vmPath), map[string]string{"source": hostPath, "target": vmPath})
 				exit.Message(reason.Interrupted, "Received {{.name}} signal", out.V{"name": sig})
 			}
 		}()
@@ -250,6 +252,7 @@ var mountCmd = &cobra.Command{
 			}
 			exit.Error(reason.GuestMount, "mount failed", err)
 		}
+		journal.Record(co.Config.Name, journal.MountStarted, fmt.Sprintf("%s was mounted to %s", hostPath, vmPath), map[string]string{"source": hostPath, "target": vmPath})
 		out.Step(style.Success, "Successfully mounted {{.sourcePath}} to {{.destinationPath}}", out.V{"sourcePath": hostPath, "destinationPath": vmPath})
 		out.Ln("")
 		out.Styled(style.Notice, "NOTE: This process must stay alive for the mount to be accessible ...")
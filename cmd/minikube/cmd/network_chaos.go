@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/kapi"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/netchaos"
+	"k8s.io/minikube/pkg/minikube/node"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+// networkChaosCmd represents the set of network chaos subcommands
+var networkChaosCmd = &cobra.Command{
+	Use:   "chaos",
+	Short: "Inject network faults between cluster nodes",
+	Long: `Injects latency, jitter, packet loss, bandwidth limits and partitions between the nodes of a cluster, using tc netem and iptables on the node interfaces.
+The faults are recorded in the profile and shown by 'minikube status', remove them all with 'minikube network chaos clear'.`,
+	Run: func(_ *cobra.Command, _ []string) {
+		exit.Message(reason.Usage, "Usage: minikube network chaos [add|scenario|list|clear]")
+	},
+}
+
+// applyNetworkChaos replaces the network faults of all nodes of the cluster with the given rules and saves them to the profile
+func applyNetworkChaos(co mustload.ClusterController, rules []config.NetworkChaosRule) {
+	cc := co.Config
+	if driver.BareMetal(cc.Driver) {
+		exit.Message(reason.Usage, "Network chaos is not supported with the {{.driver}} driver, it would change the network of the host", out.V{"driver": cc.Driver})
+	}
+
+	podCIDRs := nodePodCIDRs(cc.Name)
+	peers := map[string][]string{}
+	for _, n := range cc.Nodes {
+		name := config.MachineName(*cc, n)
+		peers[name] = netchaos.Destinations(n, podCIDRs[name])
+	}
+
+	for _, n := range cc.Nodes {
+		name := config.MachineName(*cc, n)
+		h, err := machine.LoadHost(co.API, name)
+		if err != nil {
+			exit.Error(reason.GuestLoadHost, "Error getting host", err)
+		}
+		r, err := machine.CommandRunner(h)
+		if err != nil {
+			exit.Error(reason.InternalCommandRunner, "Failed to get command runner", err)
+		}
+		if err := netchaos.Apply(r, name, rules, peers); err != nil {
+			exit.Error(reason.GuestNetworkChaos, "Failed to apply network faults", err)
+		}
+	}
+
+	cc.NetworkChaos = rules
+	if err := config.SaveProfile(cc.Name, cc); err != nil {
+		exit.Error(reason.HostSaveProfile, "failed to save config", err)
+	}
+}
+
+// nodePodCIDRs returns the pod CIDRs of the Kubernetes nodes, pods talk to each other with their own addresses so faults have to match them as well
+func nodePodCIDRs(cname string) map[string][]string {
+	cidrs := map[string][]string{}
+	client, err := kapi.Client(cname)
+	if err != nil {
+		klog.Warningf("unable to get a client for the pod CIDRs: %v", err)
+		return cidrs
+	}
+	nodes, err := client.CoreV1().Nodes().List(context.Background(), meta.ListOptions{})
+	if err != nil {
+		out.WarningT("Unable to list the pod CIDRs of the nodes, faults only apply to node addresses: {{.error}}", out.V{"error": err})
+		return cidrs
+	}
+	for _, n := range nodes.Items {
+		cidrs[n.Name] = n.Spec.PodCIDRs
+		if len(n.Spec.PodCIDRs) == 0 && n.Spec.PodCIDR != "" {
+			cidrs[n.Name] = []string{n.Spec.PodCIDR}
+		}
+	}
+	return cidrs
+}
+
+// networkChaosNode returns the machine name of a node given by its node or machine name
+func networkChaosNode(cc *config.ClusterConfig, name string) string {
+	n, _, err := node.Retrieve(*cc, name)
+	if err != nil {
+		exit.Message(reason.GuestNodeRetrieve, "Node {{.name}} does not exist, see 'minikube node list'", out.V{"name": name})
+	}
+	return config.MachineName(*cc, *n)
+}
+
+// printNetworkChaosSummary prints the number of rules now active
+func printNetworkChaosSummary(rules []config.NetworkChaosRule) {
+	if len(rules) == 0 {
+		out.Styled(style.Deleted, "Removed all network faults")
+		return
+	}
+	out.Styled(style.Success, "{{.count}} network fault rules are active, see 'minikube network chaos list'", out.V{"count": len(rules)})
+}
+
+func init() {
+	networkCmd.AddCommand(networkChaosCmd)
+}
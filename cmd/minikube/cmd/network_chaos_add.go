@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/netchaos"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+)
+
+var (
+	chaosFrom      string
+	chaosTo        string
+	chaosLatency   string
+	chaosJitter    string
+	chaosLoss      string
+	chaosRate      string
+	chaosPartition bool
+	chaosOneWay    bool
+)
+
+var networkChaosAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Adds network faults between two nodes",
+	Long: `Adds network faults between two nodes, in both directions unless --one-way is set.
+Faults added for a pair of nodes that already has faults are combined with them.`,
+	Example: `minikube network chaos add --from minikube --to minikube-m02 --latency 100ms --jitter 10ms
+minikube network chaos add --from minikube-m02 --to minikube-m03 --loss 5% --rate 1mbit
+minikube network chaos add --from minikube --to minikube-m03 --partition --one-way`,
+	Run: func(_ *cobra.Command, args []string) {
+		if len(args) != 0 || chaosFrom == "" || chaosTo == "" {
+			exit.Message(reason.Usage, "Usage: minikube network chaos add --from NODE --to NODE [--latency DURATION] [--jitter DURATION] [--loss PERCENT] [--rate RATE] [--partition]")
+		}
+
+		co := mustload.Running(ClusterFlagValue())
+		rule := config.NetworkChaosRule{
+			From:      networkChaosNode(co.Config, chaosFrom),
+			To:        networkChaosNode(co.Config, chaosTo),
+			Latency:   chaosLatency,
+			Jitter:    chaosJitter,
+			Loss:      chaosLoss,
+			Rate:      chaosRate,
+			Partition: chaosPartition,
+		}
+		if err := netchaos.Validate(rule); err != nil {
+			exit.Message(reason.Usage, "Invalid network fault: {{.error}}", out.V{"error": err})
+		}
+
+		rules := netchaos.Merge(co.Config.NetworkChaos, rule)
+		if !chaosOneWay {
+			rule.From, rule.To = rule.To, rule.From
+			rules = netchaos.Merge(rules, rule)
+		}
+		applyNetworkChaos(co, rules)
+		printNetworkChaosSummary(rules)
+	},
+}
+
+func init() {
+	networkChaosAddCmd.Flags().StringVar(&chaosFrom, "from", "", "The node sending the traffic")
+	networkChaosAddCmd.Flags().StringVar(&chaosTo, "to", "", "The node receiving the traffic")
+	networkChaosAddCmd.Flags().StringVar(&chaosLatency, "latency", "", "The delay added to the packets, like 100ms")
+	networkChaosAddCmd.Flags().StringVar(&chaosJitter, "jitter", "", "The variation of the delay, normally distributed, like 10ms. Requires --latency")
+	networkChaosAddCmd.Flags().StringVar(&chaosLoss, "loss", "", "The percentage of packets dropped, like 5%")
+	networkChaosAddCmd.Flags().StringVar(&chaosRate, "rate", "", "The bandwidth limit, like 1mbit")
+	networkChaosAddCmd.Flags().BoolVar(&chaosPartition, "partition", false, "Drop all the traffic between the nodes")
+	networkChaosAddCmd.Flags().BoolVar(&chaosOneWay, "one-way", false, "Only add the faults to the traffic from --from to --to")
+	networkChaosCmd.AddCommand(networkChaosAddCmd)
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/mustload"
+)
+
+var networkChaosClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Removes all network faults",
+	Long:  "Removes the network faults from all nodes of the cluster, including faults left over on the nodes but no longer recorded in the profile.",
+	Run: func(_ *cobra.Command, _ []string) {
+		co := mustload.Running(ClusterFlagValue())
+		applyNetworkChaos(co, nil)
+		printNetworkChaosSummary(nil)
+	},
+}
+
+func init() {
+	networkChaosCmd.AddCommand(networkChaosClearCmd)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/netchaos"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+)
+
+var networkChaosOutput string
+
+var networkChaosListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the active network faults",
+	Long:  "Lists the network faults recorded in the profile.",
+	Run: func(_ *cobra.Command, _ []string) {
+		_, cc := mustload.Partial(ClusterFlagValue())
+
+		switch strings.ToLower(networkChaosOutput) {
+		case "json":
+			rules := cc.NetworkChaos
+			if rules == nil {
+				rules = []config.NetworkChaosRule{}
+			}
+			b, err := json.Marshal(rules)
+			if err != nil {
+				exit.Error(reason.InternalJSONMarshal, "marshalling network faults", err)
+			}
+			out.String(string(b))
+		case "table":
+			printNetworkChaosTable(cc.NetworkChaos)
+		default:
+			exit.Message(reason.Usage, fmt.Sprintf("invalid output format: %s. Valid values: 'table', 'json'", networkChaosOutput))
+		}
+	},
+}
+
+func printNetworkChaosTable(rules []config.NetworkChaosRule) {
+	if len(rules) == 0 {
+		out.String("No network faults are active, add some with 'minikube network chaos add'\n")
+		return
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"From", "To", "Faults", "Scenario"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+	table.SetCenterSeparator("|")
+	for _, r := range rules {
+		table.Append([]string{r.From, r.To, netchaos.Describe(r), r.Scenario})
+	}
+	table.Render()
+}
+
+func init() {
+	networkChaosListCmd.Flags().StringVarP(&networkChaosOutput, "output", "o", "table", "The output format. One of 'table', 'json'")
+	networkChaosCmd.AddCommand(networkChaosListCmd)
+}
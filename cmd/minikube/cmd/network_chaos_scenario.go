@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/netchaos"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+)
+
+var networkChaosScenarioCmd = &cobra.Command{
+	Use:   "scenario [NAME]",
+	Short: "Applies a named set of network faults",
+	Long: `Applies a named set of network faults to all nodes of the cluster, combined with the faults already active.
+Without a name, lists the available scenarios.`,
+	Example: `minikube network chaos scenario
+minikube network chaos scenario wan`,
+	Run: func(_ *cobra.Command, args []string) {
+		if len(args) == 0 {
+			printNetworkChaosScenarios()
+			return
+		}
+		if len(args) != 1 {
+			exit.Message(reason.Usage, "Usage: minikube network chaos scenario [NAME]")
+		}
+		s := netchaos.FindScenario(args[0])
+		if s == nil {
+			exit.Message(reason.Usage, "Scenario {{.name}} does not exist, run 'minikube network chaos scenario' to list them", out.V{"name": args[0]})
+		}
+
+		co := mustload.Running(ClusterFlagValue())
+		var nodes []string
+		for _, n := range co.Config.Nodes {
+			nodes = append(nodes, config.MachineName(*co.Config, n))
+		}
+		added := s.Rules(nodes)
+		if len(added) == 0 {
+			exit.Message(reason.Usage, "Scenario {{.name}} needs a cluster with more than one node, add one with 'minikube node add'", out.V{"name": s.Name})
+		}
+
+		rules := co.Config.NetworkChaos
+		for _, r := range added {
+			r.Scenario = s.Name
+			rules = netchaos.Merge(rules, r)
+		}
+		applyNetworkChaos(co, rules)
+		printNetworkChaosSummary(rules)
+	},
+}
+
+func printNetworkChaosScenarios() {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Scenario", "Description"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+	table.SetCenterSeparator("|")
+	for _, s := range netchaos.Scenarios {
+		table.Append([]string{s.Name, s.Description})
+	}
+	table.Render()
+}
+
+func init() {
+	networkChaosCmd.AddCommand(networkChaosScenarioCmd)
+}
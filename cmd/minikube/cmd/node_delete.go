@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -25,6 +26,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/delete"
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/journal"
 	"k8s.io/minikube/pkg/minikube/mustload"
 	"k8s.io/minikube/pkg/minikube/node"
 	"k8s.io/minikube/pkg/minikube/out"
@@ -43,8 +45,17 @@ var nodeDeleteCmd = &cobra.Command{
 		name := args[0]
 
 		co := mustload.Healthy(ClusterFlagValue())
+		out.SetJSON(outputFormat == "json")
 		out.Step(style.DeletingHost, "Deleting node {{.name}} from cluster {{.cluster}}", out.V{"name": name, "cluster": co.Config.Name})
 
+		if drainNodes {
+			n, _, err := node.Retrieve(*co.Config, name)
+			if err != nil {
+				exit.Error(reason.GuestNodeRetrieve, "retrieving node", err)
+			}
+			drain(co.API, *co.Config, []config.Node{*n})
+		}
+
 		n, err := node.Delete(*co.Config, name)
 		if err != nil {
 			exit.Error(reason.GuestNodeDelete, "deleting node", err)
@@ -57,10 +68,13 @@ var nodeDeleteCmd = &cobra.Command{
 			delete.PossibleLeftOvers(ctx, machineName, co.Config.Driver)
 		}
 
+		journal.Record(co.Config.Name, journal.NodeDeleted, fmt.Sprintf("node %s was deleted", name), map[string]string{"node": name})
 		out.Step(style.Deleted, "Node {{.name}} was successfully deleted.", out.V{"name": name})
 	},
 }
 
 func init() {
+	nodeDeleteCmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Format to print stdout in. Options include: [text,json]")
+	addDrainFlags(nodeDeleteCmd)
 	nodeCmd.AddCommand(nodeDeleteCmd)
 }
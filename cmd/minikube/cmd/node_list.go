@@ -18,7 +18,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/spf13/cobra"
 	"k8s.io/klog/v2"
@@ -50,7 +49,7 @@ var nodeListCmd = &cobra.Command{
 			machineName := config.MachineName(*cc, n)
 			fmt.Printf("%s\t%s\n", machineName, n.IP)
 		}
-		os.Exit(0)
+		exit.Code(0)
 	},
 }
 
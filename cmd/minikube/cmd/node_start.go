@@ -17,12 +17,14 @@ limitations under the License.
 package cmd
 
 import (
-	"os"
+	"fmt"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/journal"
 	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/mustload"
 	"k8s.io/minikube/pkg/minikube/node"
@@ -52,7 +54,7 @@ var nodeStartCmd = &cobra.Command{
 		machineName := config.MachineName(*cc, *n)
 		if machine.IsRunning(api, machineName) {
 			out.Styled(style.Check, "{{.name}} is already running", out.V{"name": name})
-			os.Exit(0)
+			exit.Code(0)
 		}
 
 		register.Reg.SetStep(register.InitialSetup)
@@ -77,6 +79,10 @@ var nodeStartCmd = &cobra.Command{
 				exit.Error(reason.GuestNodeStart, "failed to start node", err)
 			}
 		}
+		if err := node.UncordonDrained(*cc, []config.Node{*n}); err != nil {
+			klog.Warningf("unable to uncordon node %s: %v", machineName, err)
+		}
+		journal.Record(cc.Name, journal.NodeStarted, fmt.Sprintf("node %s was started", machineName), map[string]string{"node": machineName})
 		out.Step(style.Happy, "Successfully started node {{.name}}!", out.V{"name": machineName})
 	},
 }
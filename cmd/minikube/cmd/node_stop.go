@@ -17,11 +17,12 @@ limitations under the License.
 package cmd
 
 import (
-	"os"
+	"fmt"
 
 	"github.com/spf13/cobra"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/journal"
 	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/mustload"
 	"k8s.io/minikube/pkg/minikube/node"
@@ -41,6 +42,7 @@ var nodeStopCmd = &cobra.Command{
 
 		name := args[0]
 		api, cc := mustload.Partial(ClusterFlagValue())
+		out.SetJSON(outputFormat == "json")
 
 		n, _, err := node.Retrieve(*cc, name)
 		if err != nil {
@@ -49,15 +51,21 @@ var nodeStopCmd = &cobra.Command{
 
 		machineName := config.MachineName(*cc, *n)
 
+		if drain(api, *cc, []config.Node{*n}) {
+			stopKubelet(api, machineName)
+		}
 		err = machine.StopHost(api, machineName)
 		if err != nil {
 			out.ErrT(style.Fatal, "Failed to stop node {{.name}}: {{.error}}", out.V{"name": name, "error": err})
-			os.Exit(reason.ExHostError)
+			exit.Code(reason.ExHostError)
 		}
+		journal.Record(cc.Name, journal.NodeStopped, fmt.Sprintf("node %s was stopped", machineName), map[string]string{"node": machineName})
 		out.Step(style.Stopped, "Successfully stopped node {{.name}}", out.V{"name": machineName})
 	},
 }
 
 func init() {
+	nodeStopCmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Format to print stdout in. Options include: [text,json]")
+	addDrainFlags(nodeStopCmd)
 	nodeCmd.AddCommand(nodeStopCmd)
 }
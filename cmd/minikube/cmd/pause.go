@@ -17,17 +17,22 @@ limitations under the License.
 package cmd
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/cluster"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/cruntime"
 	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/journal"
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/mustload"
@@ -40,27 +45,31 @@ import (
 var (
 	namespaces    []string
 	allNamespaces bool
+	pauseSelector string
+	pausePods     []string
 )
 
 // pauseCmd represents the docker-pause command
 var pauseCmd = &cobra.Command{
 	Use:   "pause",
 	Short: "pause Kubernetes",
-	Run:   runPause,
+	Long: `Pauses the containers of the given namespaces, or only the pods matching --selector or named by --pods.
+
+The kubelet is stopped while kube-system is paused, so that it does not restart the paused pods. Pausing only workloads keeps it running, so failing liveness probes may restart them.`,
+	Example: `minikube pause -A
+minikube pause -l app=kafka
+minikube pause --pods kafka-0 -n streaming`,
+	Run: runPause,
 }
 
-func runPause(_ *cobra.Command, _ []string) {
+func runPause(cmd *cobra.Command, _ []string) {
 	out.SetJSON(outputFormat == "json")
 	co := mustload.Running(ClusterFlagValue())
 	register.SetEventLogPath(localpath.EventLog(ClusterFlagValue()))
 	register.Reg.SetStep(register.Pausing)
 
 	klog.InfoS("namespaces", namespaces, "keys", viper.AllSettings())
-	if allNamespaces {
-		namespaces = nil // all
-	} else if len(namespaces) == 0 {
-		exit.Message(reason.Usage, "Use -A to specify all namespaces")
-	}
+	sel := pauseSelection(cmd)
 
 	ids := []string{}
 
@@ -88,7 +97,7 @@ func runPause(_ *cobra.Command, _ []string) {
 			exit.Error(reason.InternalNewRuntime, "Failed runtime", err)
 		}
 
-		uids, err := cluster.Pause(cr, r, namespaces)
+		uids, err := cluster.PauseSelection(cr, r, sel, nil)
 		if err != nil {
 			exit.Error(reason.GuestPause, "Pause", err)
 		}
@@ -96,15 +105,83 @@ func runPause(_ *cobra.Command, _ []string) {
 	}
 
 	register.Reg.SetStep(register.Done)
-	if namespaces == nil {
+	journal.Record(co.Config.Name, journal.ClusterPaused, "cluster was paused", selectionData(sel, len(ids)))
+	switch {
+	case len(sel.Pods) > 0 || len(sel.Labels) > 0:
+		out.Step(style.Unpause, "Paused {{.count}} containers of pods {{.pods}} in: {{.namespaces}}", out.V{"count": len(ids), "pods": podsValue(sel), "namespaces": namespacesValue(sel.Namespaces)})
+	case sel.Namespaces == nil:
 		out.Step(style.Unpause, "Paused {{.count}} containers", out.V{"count": len(ids)})
-	} else {
-		out.Step(style.Unpause, "Paused {{.count}} containers in: {{.namespaces}}", out.V{"count": len(ids), "namespaces": strings.Join(namespaces, ", ")})
+	default:
+		out.Step(style.Unpause, "Paused {{.count}} containers in: {{.namespaces}}", out.V{"count": len(ids), "namespaces": strings.Join(sel.Namespaces, ", ")})
+	}
+}
+
+// pauseSelection returns the containers selected by the flags of a pause or unpause command
+func pauseSelection(cmd *cobra.Command) cluster.Selection {
+	if allNamespaces {
+		namespaces = nil // all
+	} else if len(namespaces) == 0 {
+		exit.Message(reason.Usage, "Use -A to specify all namespaces")
+	}
+
+	podLabels, err := parseSelector(pauseSelector)
+	if err != nil {
+		exit.Message(reason.Usage, "Invalid selector {{.selector}}: {{.error}}", out.V{"selector": pauseSelector, "error": err})
+	}
+	if (len(podLabels) > 0 || len(pausePods) > 0) && namespaces != nil && !cmd.Flags().Changed("namespaces") {
+		// like kubectl, look up pods in the default namespace
+		namespaces = []string{"default"}
+	}
+	return cluster.Selection{Namespaces: namespaces, Pods: pausePods, Labels: podLabels}
+}
+
+// parseSelector returns the requirements of an equality-based label selector as key=value
+func parseSelector(s string) ([]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	sel, err := labels.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+
+	reqs, _ := sel.Requirements()
+	l := []string{}
+	for _, r := range reqs {
+		if r.Operator() != selection.Equals && r.Operator() != selection.DoubleEquals {
+			return nil, fmt.Errorf("%q is not supported, only key=value requirements are", r.String())
+		}
+		l = append(l, fmt.Sprintf("%s=%s", r.Key(), r.Values().List()[0]))
+	}
+	return l, nil
+}
+
+// selectionData returns the event journal data of a pause or unpause
+func selectionData(sel cluster.Selection, count int) map[string]string {
+	data := map[string]string{"namespaces": namespacesValue(sel.Namespaces), "containers": strconv.Itoa(count)}
+	if len(sel.Pods) > 0 || len(sel.Labels) > 0 {
+		data["pods"] = podsValue(sel)
+	}
+	return data
+}
+
+// podsValue describes the pods selected by a pause or unpause
+func podsValue(sel cluster.Selection) string {
+	return strings.Join(append(append([]string{}, sel.Pods...), sel.Labels...), ",")
+}
+
+// namespacesValue returns the namespaces of a pause or unpause for the event journal
+func namespacesValue(namespaces []string) string {
+	if namespaces == nil {
+		return "all"
 	}
+	return strings.Join(namespaces, ",")
 }
 
 func init() {
 	pauseCmd.Flags().StringSliceVarP(&namespaces, "namespaces", "n", constants.DefaultNamespaces, "namespaces to pause")
 	pauseCmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "If set, pause all namespaces")
+	pauseCmd.Flags().StringVarP(&pauseSelector, "selector", "l", "", "Only pause the pods matching this label selector, like app=kafka, in the default namespace unless --namespaces is set")
+	pauseCmd.Flags().StringSliceVar(&pausePods, "pods", []string{}, "Only pause the pods with these names, in the default namespace unless --namespaces is set")
 	pauseCmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Format to print stdout in. Options include: [text,json]")
 }
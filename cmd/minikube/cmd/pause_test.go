@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     []string
+		err      bool
+	}{
+		{selector: "", want: nil},
+		{selector: "app=kafka", want: []string{"app=kafka"}},
+		{selector: "tier==backend, app=kafka", want: []string{"app=kafka", "tier=backend"}},
+		{selector: "app!=kafka", err: true},
+		{selector: "app in (kafka,zookeeper)", err: true},
+		{selector: "app", err: true},
+		{selector: "=kafka", err: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.selector, func(t *testing.T) {
+			got, err := parseSelector(tc.selector)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("parseSelector(%q) = %q, want error", tc.selector, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSelector(%q): %v", tc.selector, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("parseSelector(%q) mismatch (-want +got):\n%s", tc.selector, diff)
+			}
+		})
+	}
+}
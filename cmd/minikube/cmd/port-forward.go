@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"github.com/juju/fslock"
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/portforward"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+// portForwardCmd represents the port-forward command
+var portForwardCmd = &cobra.Command{
+	Use:   "port-forward [NAME...]",
+	Short: "Run the port forwards defined for the cluster",
+	Long: `port-forward runs the persistent port forwards defined with 'minikube port-forward add', or only the named ones.
+Forwards follow their pods, deployments or services and reconnect automatically when the pod they are connected to goes away.`,
+	Run: func(_ *cobra.Command, args []string) {
+		cname := ClusterFlagValue()
+		co := mustload.Healthy(cname)
+
+		forwards := co.Config.PortForwards
+		if len(args) > 0 {
+			forwards = nil
+			for _, name := range args {
+				pf := findPortForward(co.Config, name)
+				if pf == nil {
+					exit.Message(reason.Usage, "Port forward {{.name}} does not exist, see 'minikube port-forward list'", out.V{"name": name})
+				}
+				forwards = append(forwards, *pf)
+			}
+		}
+		if len(forwards) == 0 {
+			exit.Message(reason.Usage, "No port forwards are defined, add one with 'minikube port-forward add'")
+		}
+
+		lock := fslock.New(filepath.Join(localpath.Profile(cname), ".port_forward_lock"))
+		err := lock.TryLock()
+		if err == fslock.ErrLocked {
+			exit.Message(reason.SvcTunnelAlreadyRunning, "Another port-forward process is already running, terminate the existing instance to start a new one")
+		}
+		if err != nil {
+			exit.Error(reason.SvcTunnelStart, "failed to acquire lock due to unexpected error", err)
+		}
+		defer func() {
+			if err := lock.Unlock(); err != nil {
+				out.Styled(style.Warning, "failed to release lock during cleanup: {{.error}}", out.V{"error": err})
+			}
+		}()
+
+		manager, err := portforward.NewManager(cname)
+		if err != nil {
+			exit.Error(reason.InternalKubernetesClient, "error creating port forward manager", err)
+		}
+
+		ctrlC := make(chan os.Signal, 1)
+		signal.Notify(ctrlC, os.Interrupt)
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-ctrlC
+			cancel()
+		}()
+
+		done := manager.StartForwards(ctx, forwards)
+		out.Styled(style.Notice, "NOTE: Please do not close this terminal as this process must stay alive for the port forwards to be accessible ...")
+		<-done
+	},
+}
+
+func findPortForward(cc *config.ClusterConfig, name string) *config.PortForward {
+	for i := range cc.PortForwards {
+		if cc.PortForwards[i].Name == name {
+			return &cc.PortForwards[i]
+		}
+	}
+	return nil
+}
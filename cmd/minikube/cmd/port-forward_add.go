@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/portforward"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var (
+	portForwardNamespace string
+	portForwardSelector  string
+	portForwardAddress   string
+)
+
+var portForwardAddCmd = &cobra.Command{
+	Use:   "add NAME [TYPE/NAME] [LOCAL:]REMOTE...",
+	Short: "Defines a persistent port forward",
+	Long: `Defines a persistent port forward stored in the profile. The target is a pod, deployment or service in the TYPE/NAME form, or any ready pod matching --selector.
+Ports of a service target are service ports, they are translated to the target ports of the pod.`,
+	Example: `minikube port-forward add web deployment/web 8080:80
+minikube port-forward add db --selector app=postgres -n data 5432`,
+	Run: func(_ *cobra.Command, args []string) {
+		minArgs := 3
+		if portForwardSelector != "" {
+			minArgs = 2
+		}
+		if len(args) < minArgs {
+			exit.Message(reason.Usage, "Usage: minikube port-forward add NAME [TYPE/NAME | --selector SELECTOR] [LOCAL:]REMOTE...")
+		}
+
+		pf := config.PortForward{
+			Name:      args[0],
+			Namespace: portForwardNamespace,
+			Kind:      portforward.KindPod,
+			Selector:  portForwardSelector,
+			Address:   portForwardAddress,
+		}
+		ports := args[1:]
+		if portForwardSelector == "" {
+			kind, target, err := portforward.ParseTarget(args[1])
+			if err != nil {
+				exit.Message(reason.Usage, "Invalid target: {{.error}}", out.V{"error": err})
+			}
+			pf.Kind, pf.Target = kind, target
+			ports = args[2:]
+		}
+		if err := portforward.ParsePorts(ports); err != nil {
+			exit.Message(reason.Usage, "Invalid ports: {{.error}}", out.V{"error": err})
+		}
+		pf.Ports = ports
+
+		cname := ClusterFlagValue()
+		_, cc := mustload.Partial(cname)
+		if findPortForward(cc, pf.Name) != nil {
+			exit.Message(reason.Usage, "Port forward {{.name}} already exists", out.V{"name": pf.Name})
+		}
+		cc.PortForwards = append(cc.PortForwards, pf)
+		if err := config.SaveProfile(cc.Name, cc); err != nil {
+			exit.Error(reason.HostSaveProfile, "failed to save config", err)
+		}
+		out.Styled(style.Success, "Added port forward {{.name}}, run 'minikube port-forward' to start it", out.V{"name": pf.Name})
+	},
+}
+
+func init() {
+	portForwardAddCmd.Flags().StringVarP(&portForwardNamespace, "namespace", "n", "default", "The namespace of the target")
+	portForwardAddCmd.Flags().StringVarP(&portForwardSelector, "selector", "l", "", "Forward to any ready pod matching this label selector instead of a named target")
+	portForwardAddCmd.Flags().StringVar(&portForwardAddress, "address", "127.0.0.1", "The local address to listen on")
+	portForwardCmd.AddCommand(portForwardAddCmd)
+}
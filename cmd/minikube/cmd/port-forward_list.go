@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/portforward"
+	"k8s.io/minikube/pkg/minikube/reason"
+)
+
+var portForwardOutput string
+
+// portForwardListItem is a port forward definition together with its state
+type portForwardListItem struct {
+	config.PortForward
+	Status portforward.Status
+}
+
+var portForwardListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the persistent port forwards and their state",
+	Long:  "Lists the persistent port forwards defined for the cluster, and the state reported by a running 'minikube port-forward' process.",
+	Run: func(_ *cobra.Command, _ []string) {
+		cname := ClusterFlagValue()
+		_, cc := mustload.Partial(cname)
+
+		statuses, err := portforward.ReadStatus(cname)
+		if err != nil {
+			exit.Error(reason.HostConfigLoad, "reading port forward status", err)
+		}
+
+		var items []portForwardListItem
+		for _, pf := range cc.PortForwards {
+			st, ok := statuses[pf.Name]
+			if !ok {
+				st = portforward.Status{State: portforward.Stopped}
+			}
+			items = append(items, portForwardListItem{PortForward: pf, Status: st})
+		}
+
+		switch strings.ToLower(portForwardOutput) {
+		case "json":
+			if items == nil {
+				items = []portForwardListItem{}
+			}
+			b, err := json.Marshal(items)
+			if err != nil {
+				exit.Error(reason.InternalJSONMarshal, "marshalling port forwards", err)
+			}
+			out.String(string(b))
+		case "table":
+			printPortForwardTable(items)
+		default:
+			exit.Message(reason.Usage, fmt.Sprintf("invalid output format: %s. Valid values: 'table', 'json'", portForwardOutput))
+		}
+	},
+}
+
+func printPortForwardTable(items []portForwardListItem) {
+	if len(items) == 0 {
+		out.String("No port forwards are defined, add one with 'minikube port-forward add'\n")
+		return
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Namespace", "Target", "Ports", "State", "Pod", "Reconnects", "Error"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+	table.SetCenterSeparator("|")
+	for _, i := range items {
+		target := fmt.Sprintf("%s/%s", i.Kind, i.Target)
+		if i.Target == "" {
+			target = fmt.Sprintf("%s -l %s", i.Kind, i.Selector)
+		}
+		ports := strings.Join(i.Ports, ", ")
+		if len(i.Status.Ports) > 0 {
+			ports = strings.Join(i.Status.Ports, ", ")
+		}
+		table.Append([]string{i.Name, i.Namespace, target, ports, i.Status.State, i.Status.Pod, fmt.Sprint(i.Status.Reconnects), i.Status.Error})
+	}
+	table.Render()
+}
+
+func init() {
+	portForwardListCmd.Flags().StringVarP(&portForwardOutput, "output", "o", "table", "The output format. One of 'table', 'json'")
+	portForwardCmd.AddCommand(portForwardListCmd)
+}
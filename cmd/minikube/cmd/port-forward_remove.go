@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var portForwardRemoveCmd = &cobra.Command{
+	Use:     "remove NAME",
+	Aliases: []string{"rm", "delete"},
+	Short:   "Removes a persistent port forward",
+	Long:    "Removes a persistent port forward from the profile. A running 'minikube port-forward' process keeps serving it until restarted.",
+	Run: func(_ *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.Message(reason.Usage, "Usage: minikube port-forward remove NAME")
+		}
+
+		cname := ClusterFlagValue()
+		_, cc := mustload.Partial(cname)
+		var forwards []config.PortForward
+		for _, pf := range cc.PortForwards {
+			if pf.Name != args[0] {
+				forwards = append(forwards, pf)
+			}
+		}
+		if len(forwards) == len(cc.PortForwards) {
+			exit.Message(reason.Usage, "Port forward {{.name}} does not exist, see 'minikube port-forward list'", out.V{"name": args[0]})
+		}
+		cc.PortForwards = forwards
+		if err := config.SaveProfile(cc.Name, cc); err != nil {
+			exit.Error(reason.HostSaveProfile, "failed to save config", err)
+		}
+		out.Styled(style.Deleted, "Removed port forward {{.name}}", out.V{"name": args[0]})
+	},
+}
+
+func init() {
+	portForwardCmd.AddCommand(portForwardRemoveCmd)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/journal"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+// resumeCmd represents the resume command
+var resumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Resumes the nodes suspended by minikube suspend",
+	Long: `Resumes the nodes suspended by "minikube suspend", then fixes up their clock, which stood still for VMs.
+For backwards compatibility, when no node is suspended it unpauses Kubernetes like "minikube unpause".`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cname := ClusterFlagValue()
+		api, cc := mustload.Partial(cname)
+		out.SetJSON(outputFormat == "json")
+
+		nodes := suspendOrder(cc.Nodes)
+		var suspended []string
+		for i := len(nodes) - 1; i >= 0; i-- {
+			name := config.MachineName(*cc, nodes[i])
+			st, err := machine.Status(api, name)
+			if err != nil {
+				exit.Error(reason.GuestStatus, "Error getting host status", err)
+			}
+			if machine.IsSuspended(st) {
+				suspended = append(suspended, name)
+			}
+		}
+
+		if len(suspended) == 0 {
+			out.Styled(style.Notice, `No node is suspended, unpausing Kubernetes instead. "minikube resume" used to be an alias of "minikube unpause", which should be used for that now.`)
+			unpauseCmd.Run(cmd, args)
+			return
+		}
+
+		for _, name := range suspended {
+			out.Step(style.Unpause, "Resuming node {{.name}} ...", out.V{"name": name})
+			if err := machine.ResumeHost(api, name); err != nil {
+				exit.Error(reason.GuestResume, "Resume", err)
+			}
+		}
+		journal.Record(cc.Name, journal.ClusterResumed, "cluster was resumed", map[string]string{"nodes": strings.Join(suspended, ",")})
+		out.Step(style.Unpause, "Resumed {{.count}} nodes", out.V{"count": len(suspended)})
+	},
+}
+
+func init() {
+	resumeCmd.Flags().StringSliceVarP(&namespaces, "namespaces", "n", constants.DefaultNamespaces, "namespaces to unpause when no node is suspended")
+	resumeCmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "If set, unpause all namespaces when no node is suspended")
+	resumeCmd.Flags().StringVarP(&pauseSelector, "selector", "l", "", "Only unpause the pods matching this label selector when no node is suspended")
+	resumeCmd.Flags().StringSliceVar(&pausePods, "pods", []string{}, "Only unpause the pods with these names when no node is suspended")
+	resumeCmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Format to print stdout in. Options include: [text,json]")
+}
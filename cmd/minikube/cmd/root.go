@@ -30,6 +30,7 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kubectl/pkg/util/templates"
 	configCmd "k8s.io/minikube/cmd/minikube/cmd/config"
+	"k8s.io/minikube/pkg/addons"
 	"k8s.io/minikube/pkg/drivers/kic/oci"
 	"k8s.io/minikube/pkg/minikube/audit"
 	"k8s.io/minikube/pkg/minikube/config"
@@ -69,6 +70,7 @@ var RootCmd = &cobra.Command{
 				exit.Error(reason.HostHomeMkdir, "Error creating minikube directory", err)
 			}
 		}
+		addons.LoadPlugins()
 		userName := viper.GetString(config.UserFlag)
 		if !validateUsername(userName) {
 			out.WarningT("User name '{{.username}}' is not valid", out.V{"username": userName})
@@ -79,6 +81,11 @@ var RootCmd = &cobra.Command{
 		if err != nil {
 			klog.Warningf("failed to log command start to audit: %v", err)
 		}
+		exit.AddHook(func(code int) {
+			if err := audit.LogCommandExit(auditID, code); err != nil {
+				klog.Warningf("failed to log command exit to audit: %v", err)
+			}
+		})
 		// viper maps $MINIKUBE_ROOTLESS to "rootless" property automatically, but it does not do vice versa,
 		// so we map "rootless" property to $MINIKUBE_ROOTLESS expliclity here.
 		// $MINIKUBE_ROOTLESS is referred by KIC runner, which is decoupled from viper.
@@ -173,7 +180,7 @@ func Execute() {
 
 	if err := RootCmd.Execute(); err != nil {
 		// Cobra already outputs the error, typically because the user provided an unknown command.
-		defer os.Exit(reason.ExProgramUsage)
+		defer exit.Code(reason.ExProgramUsage)
 	}
 }
 
@@ -239,6 +246,8 @@ func init() {
 				dashboardCmd,
 				pauseCmd,
 				unpauseCmd,
+				suspendCmd,
+				resumeCmd,
 			},
 		},
 		{
@@ -256,6 +265,7 @@ func init() {
 				configCmd.AddonsCmd,
 				configCmd.ConfigCmd,
 				configCmd.ProfileCmd,
+				scheduleCmd,
 				updateContextCmd,
 			},
 		},
@@ -264,6 +274,8 @@ func init() {
 			Commands: []*cobra.Command{
 				serviceCmd,
 				tunnelCmd,
+				portForwardCmd,
+				networkCmd,
 			},
 		},
 		{
@@ -283,6 +295,11 @@ func init() {
 				sshHostCmd,
 				ipCmd,
 				logsCmd,
+				eventsCmd,
+				auditCmd,
+				bugreportCmd,
+				metricsCmd,
+				topCmd,
 				updateCheckCmd,
 				versionCmd,
 				optionsCmd,
@@ -0,0 +1,34 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/reason"
+)
+
+// scheduleCmd represents the set of schedule subcommands
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Add, remove, or list recurring scheduled operations",
+	Long: `Operations on the recurring schedules of a profile, which start, stop, pause, unpause or restart the cluster at the times matched by cron expressions.
+The schedules of all profiles are run by a single daemon in the background, which is started when a schedule is added and exits when no schedule is left.`,
+	Run: func(_ *cobra.Command, _ []string) {
+		exit.Message(reason.Usage, "Usage: minikube schedule [add|remove|list]")
+	},
+}
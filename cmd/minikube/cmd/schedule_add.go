@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/schedule"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var (
+	scheduleName string
+	scheduleIdle time.Duration
+)
+
+var scheduleAddCmd = &cobra.Command{
+	Use:   "add OPERATION [CRON]",
+	Short: "Adds a recurring scheduled operation",
+	Long: `Adds a recurring schedule to the profile, which runs the operation at the times matched by the cron expression, in the local time zone.
+OPERATION is one of start, stop, pause, unpause and restart. restart stops and starts the cluster, it does not take a snapshot.
+CRON has five fields (minute, hour, day of month, month and day of week) or is one of @hourly, @daily, @weekly, @monthly and @yearly.
+With --idle instead of CRON, the cluster is stopped once its API server saw no traffic from the host for that long, to reclaim the resources of forgotten clusters.`,
+	Example: `minikube schedule add stop "0 19 * * Mon-Fri"
+minikube schedule add start "30 8 * * Mon-Fri" --name morning
+minikube schedule add stop --idle 4h`,
+	Run: func(_ *cobra.Command, args []string) {
+		if len(args) != 2 && (scheduleIdle == 0 || len(args) != 1) {
+			exit.Message(reason.Usage, "Usage: minikube schedule add OPERATION CRON | --idle DURATION")
+		}
+
+		cname := ClusterFlagValue()
+		_, cc := mustload.Partial(cname)
+		s := config.Schedule{Name: scheduleName, Operation: args[0], Idle: scheduleIdle}
+		if len(args) == 2 {
+			s.Cron = args[1]
+		}
+		if s.Name == "" {
+			s.Name = defaultScheduleName(cc, s)
+		}
+		if s.Idle != 0 && driver.BareMetal(cc.Driver) {
+			exit.Message(reason.DrvUnsupported, "Stopping idle clusters is not supported by the {{.driver}} driver", out.V{"driver": cc.Driver})
+		}
+		if err := schedule.Validate(s); err != nil {
+			exit.Message(reason.Usage, "Invalid schedule: {{.error}}", out.V{"error": err})
+		}
+		if findSchedule(cc, s.Name) != nil {
+			exit.Message(reason.Usage, "Schedule {{.name}} already exists", out.V{"name": s.Name})
+		}
+		cc.Schedules = append(cc.Schedules, s)
+		if err := config.SaveProfile(cc.Name, cc); err != nil {
+			exit.Error(reason.HostSaveProfile, "failed to save config", err)
+		}
+		if err := schedule.EnsureDaemon(); err != nil {
+			exit.Error(reason.DaemonizeError, "failed to start the schedule daemon", err)
+		}
+
+		out.Styled(style.Success, "Added schedule {{.name}}", out.V{"name": s.Name})
+		if s.Idle != 0 {
+			out.Infof("The cluster will be stopped once idle for {{.idle}}", out.V{"idle": s.Idle})
+		} else if r, ok := schedule.NextRun(&config.ClusterConfig{Name: cc.Name, Schedules: []config.Schedule{s}}, time.Now()); ok {
+			out.Infof("Next run: {{.next}}", out.V{"next": r.Describe(time.Now())})
+		}
+	},
+}
+
+// defaultScheduleName returns the first free name like stop-1, stop-2, idle-stop-1 or once-stop-1 for a schedule
+func defaultScheduleName(cc *config.ClusterConfig, s config.Schedule) string {
+	op := s.Operation
+	switch {
+	case s.Idle != 0:
+		op = "idle-" + op
+	case s.At != 0:
+		op = "once-" + op
+	}
+	for i := 1; ; i++ {
+		name := fmt.Sprintf("%s-%d", op, i)
+		if findSchedule(cc, name) == nil {
+			return name
+		}
+	}
+}
+
+func findSchedule(cc *config.ClusterConfig, name string) *config.Schedule {
+	for i := range cc.Schedules {
+		if cc.Schedules[i].Name == name {
+			return &cc.Schedules[i]
+		}
+	}
+	return nil
+}
+
+func init() {
+	scheduleAddCmd.Flags().StringVar(&scheduleName, "name", "", "The name of the schedule, defaults to the operation followed by a number")
+	scheduleAddCmd.Flags().DurationVar(&scheduleIdle, "idle", 0, "Instead of a cron expression, stop the cluster once its API server saw no client activity from the host for this long, like 4h")
+	scheduleCmd.AddCommand(scheduleAddCmd)
+}
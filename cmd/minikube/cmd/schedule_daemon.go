@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/schedule"
+)
+
+var scheduleDaemonCmd = &cobra.Command{
+	Use:    "daemon",
+	Short:  "Runs the recurring schedules of all profiles",
+	Long:   "Runs the recurring schedules of all profiles in the foreground, until none is left. It is started in the background by 'minikube schedule add'.",
+	Hidden: true,
+	Run: func(_ *cobra.Command, _ []string) {
+		if err := schedule.RunDaemon(); err != nil {
+			exit.Error(reason.DaemonizeError, "schedule daemon failed", err)
+		}
+	},
+}
+
+func init() {
+	scheduleCmd.AddCommand(scheduleDaemonCmd)
+}
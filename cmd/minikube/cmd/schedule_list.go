@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/schedule"
+)
+
+var (
+	scheduleOutput  string
+	scheduleListAll bool
+)
+
+// scheduleListItem is a schedule together with its profile and next run
+type scheduleListItem struct {
+	Profile string
+	config.Schedule
+	NextRun *time.Time `json:",omitempty"`
+	// IdleFor is how long the API server of the profile saw no client activity, for idle schedules of running profiles
+	IdleFor string `json:",omitempty"`
+}
+
+var scheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the scheduled operations and their next run",
+	Long:  "Lists the schedules of the profile, or of all profiles with --all, and when they run next. This includes the one-off stops of 'minikube stop --schedule'.",
+	Run: func(_ *cobra.Command, _ []string) {
+		var profiles []*config.ClusterConfig
+		if scheduleListAll {
+			valid, err := config.ListValidProfiles()
+			if err != nil {
+				exit.Error(reason.HostConfigLoad, "listing profiles", err)
+			}
+			for _, p := range valid {
+				profiles = append(profiles, p.Config)
+			}
+		} else {
+			_, cc := mustload.Partial(ClusterFlagValue())
+			profiles = append(profiles, cc)
+		}
+
+		now := time.Now()
+		items := []scheduleListItem{}
+		for _, cc := range profiles {
+			for _, s := range cc.Schedules {
+				item := scheduleListItem{Profile: cc.Name, Schedule: s}
+				if r, ok := schedule.NextRun(&config.ClusterConfig{Name: cc.Name, Schedules: []config.Schedule{s}}, now); ok {
+					item.NextRun = &r.Time
+				}
+				if st, ok := schedule.ReadIdleState(cc.Name); ok && s.Idle != 0 && st.Current(now) {
+					item.IdleFor = st.Idle(now).Round(time.Minute).String()
+				}
+				items = append(items, item)
+			}
+		}
+
+		switch strings.ToLower(scheduleOutput) {
+		case "json":
+			b, err := json.Marshal(items)
+			if err != nil {
+				exit.Error(reason.InternalJSONMarshal, "marshalling schedules", err)
+			}
+			out.String(string(b))
+		case "table":
+			printScheduleTable(items, now)
+		default:
+			exit.Message(reason.Usage, fmt.Sprintf("invalid output format: %s. Valid values: 'table', 'json'", scheduleOutput))
+		}
+	},
+}
+
+func printScheduleTable(items []scheduleListItem, now time.Time) {
+	if len(items) == 0 {
+		out.String("No schedules are defined, add one with 'minikube schedule add'\n")
+		return
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Profile", "Name", "Operation", "When", "Next Run"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+	table.SetCenterSeparator("|")
+	for _, i := range items {
+		when, next := i.Cron, "never"
+		if i.NextRun != nil {
+			next = fmt.Sprintf("%s (in %s)", i.NextRun.Format("Mon Jan 2 15:04"), i.NextRun.Sub(now).Round(time.Minute))
+		}
+		if i.At != 0 {
+			when = "once"
+		}
+		if i.Idle != 0 {
+			when, next = fmt.Sprintf("idle for %s", i.Idle), "when idle"
+			if i.IdleFor != "" {
+				next = fmt.Sprintf("when idle (idle for %s)", i.IdleFor)
+			}
+		}
+		table.Append([]string{i.Profile, i.Name, i.Operation, when, next})
+	}
+	table.Render()
+	if _, ok := schedule.DaemonPID(); !ok {
+		out.WarningT("The schedule daemon is not running, it is started again by 'minikube start' or 'minikube schedule add'")
+	}
+}
+
+func init() {
+	scheduleListCmd.Flags().StringVarP(&scheduleOutput, "output", "o", "table", "The output format. One of 'table', 'json'")
+	scheduleListCmd.Flags().BoolVar(&scheduleListAll, "all", false, "List the schedules of all profiles")
+	scheduleCmd.AddCommand(scheduleListCmd)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var scheduleRemoveAll bool
+
+var scheduleRemoveCmd = &cobra.Command{
+	Use:     "remove NAME...",
+	Aliases: []string{"rm", "delete"},
+	Short:   "Removes recurring scheduled operations",
+	Long:    "Removes the named recurring schedules of the profile, or all of them with --all. The schedule daemon exits once no profile has schedules left.",
+	Run: func(_ *cobra.Command, args []string) {
+		if len(args) == 0 && !scheduleRemoveAll {
+			exit.Message(reason.Usage, "Usage: minikube schedule remove NAME... | --all")
+		}
+
+		cname := ClusterFlagValue()
+		_, cc := mustload.Partial(cname)
+		var schedules []config.Schedule
+		if !scheduleRemoveAll {
+			for _, name := range args {
+				if findSchedule(cc, name) == nil {
+					exit.Message(reason.Usage, "Schedule {{.name}} does not exist, see 'minikube schedule list'", out.V{"name": name})
+				}
+			}
+			for _, s := range cc.Schedules {
+				if !contains(args, s.Name) {
+					schedules = append(schedules, s)
+				}
+			}
+		}
+		removed := len(cc.Schedules) - len(schedules)
+		cc.Schedules = schedules
+		if err := config.SaveProfile(cc.Name, cc); err != nil {
+			exit.Error(reason.HostSaveProfile, "failed to save config", err)
+		}
+		out.Styled(style.Deleted, "Removed {{.count}} schedules", out.V{"count": removed})
+	},
+}
+
+func init() {
+	scheduleRemoveCmd.Flags().BoolVar(&scheduleRemoveAll, "all", false, "Remove all the schedules of the profile")
+	scheduleCmd.AddCommand(scheduleRemoveCmd)
+}
@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
@@ -35,6 +36,7 @@ import (
 	"k8s.io/minikube/pkg/drivers/kic/oci"
 	"k8s.io/minikube/pkg/kapi"
 	"k8s.io/minikube/pkg/minikube/browser"
+	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/exit"
 	"k8s.io/minikube/pkg/minikube/localpath"
@@ -58,6 +60,10 @@ var (
 	serviceURLTemplate *template.Template
 	wait               int
 	interval           int
+	gatewayName        string
+	serviceAllNodes    bool
+	serviceIPFamily    string
+	serviceOutput      string
 )
 
 // serviceCmd represents the service command
@@ -75,6 +81,16 @@ var serviceCmd = &cobra.Command{
 		RootCmd.PersistentPreRun(cmd, args)
 	},
 	Run: func(_ *cobra.Command, args []string) {
+		if gatewayName != "" {
+			if len(args) > 0 || all {
+				exit.Message(reason.Usage, "--gateway cannot be combined with service names or --all")
+			}
+			cname := ClusterFlagValue()
+			mustload.Healthy(cname)
+			printGatewayURLs(cname)
+			return
+		}
+
 		if len(args) == 0 && !all || (len(args) > 0 && all) {
 			exit.Message(reason.Usage, "You must specify service name(s) or --all")
 		}
@@ -99,7 +115,7 @@ var serviceCmd = &cobra.Command{
 		services, err := service.GetServiceURLs(co.API, co.Config.Name, namespace, serviceURLTemplate)
 		if err != nil {
 			out.ErrT(style.Fatal, "Failed to get service URL - check that minikube is running and that you have specified the correct namespace (-n flag) if required: {{.error}}", out.V{"error": err})
-			os.Exit(reason.ExSvcUnavailable)
+			exit.Code(reason.ExSvcUnavailable)
 		}
 
 		if len(args) >= 1 {
@@ -117,6 +133,11 @@ var serviceCmd = &cobra.Command{
 You may select another namespace by using 'minikube service {{.service}} -n <namespace>'. Or list out all the services using 'minikube service list'`, out.V{"service": args[0], "namespace": namespace})
 		}
 
+		if serviceAllNodes || serviceIPFamily != "" || serviceOutput != "" {
+			printServiceNodeEndpoints(co, services)
+			return
+		}
+
 		var data [][]string
 		var noNodePortServices service.URLs
 
@@ -183,10 +204,99 @@ func init() {
 	serviceCmd.Flags().BoolVar(&https, "https", false, "Open the service URL with https instead of http (defaults to \"false\")")
 	serviceCmd.Flags().IntVar(&wait, "wait", service.DefaultWait, "Amount of time to wait for a service in seconds")
 	serviceCmd.Flags().IntVar(&interval, "interval", service.DefaultInterval, "The initial time interval for each check that wait performs in seconds")
+	serviceCmd.Flags().BoolVar(&serviceAllNodes, "all-nodes", false, "List the node port URLs of the service on every node instead of opening it, marking the nodes running its pods")
+	serviceCmd.Flags().StringVar(&serviceIPFamily, "ip-family", "", "List only the node port URLs of the given IP family instead of opening the service. One of 'ipv4', 'ipv6'")
+	serviceCmd.Flags().StringVarP(&serviceOutput, "output", "o", "", "List the node port URLs in the given format instead of opening the service. One of 'table', 'json'")
+	serviceCmd.Flags().StringVar(&gatewayName, "gateway", "", "Print the URLs of the HTTPRoutes attached to the given Gateway API gateway instead of a service")
 
 	serviceCmd.PersistentFlags().StringVar(&serviceURLFormat, "format", defaultServiceFormatTemplate, "Format to output service URL in. This format will be applied to each url individually and they will be printed one at a time.")
 }
 
+// printServiceNodeEndpoints lists the node port URLs of services per node and IP family,
+// on the primary control plane only unless --all-nodes is given
+func printServiceNodeEndpoints(co mustload.ClusterController, services service.URLs) {
+	family, err := service.ParseIPFamily(serviceIPFamily)
+	if err != nil {
+		exit.Message(reason.Usage, "{{.error}}", out.V{"error": err})
+	}
+	output := strings.ToLower(serviceOutput)
+	if output != "" && output != "table" && output != "json" {
+		exit.Message(reason.Usage, fmt.Sprintf("invalid output format: %s. Valid values: 'table', 'json'", serviceOutput))
+	}
+
+	node := ""
+	if !serviceAllNodes {
+		cp, err := config.ControlPlane(*co.Config)
+		if err != nil {
+			exit.Error(reason.GuestCpConfig, "Failed to get control-plane node", err)
+		}
+		node = config.MachineName(*co.Config, cp)
+	}
+
+	services, err = service.AddNodeEndpoints(co.Config.Name, services, serviceURLTemplate, node, family)
+	if err != nil {
+		exit.Error(reason.SvcUnreachable, "Failed to get node endpoints", err)
+	}
+
+	if driver.NeedsPortForward(co.Config.Driver) {
+		out.WarningT("Node IPs are not reachable from the host with the {{.driver}} driver, use 'minikube service' without --all-nodes, --ip-family and --output to access the service.", out.V{"driver": co.Config.Driver})
+	}
+
+	switch {
+	case output == "json":
+		b, err := json.Marshal(services)
+		if err != nil {
+			exit.Error(reason.InternalJSONMarshal, "marshalling services", err)
+		}
+		out.String(string(b))
+	case serviceURLMode:
+		for _, svc := range services {
+			for _, e := range svc.Endpoints {
+				u, _ := service.OptionallyHTTPSFormattedURLString(e.URL, https)
+				out.Stringf("%s\n", u)
+			}
+		}
+	default:
+		service.PrintNodeEndpointList(os.Stdout, services)
+	}
+}
+
+func printGatewayURLs(cname string) {
+	client, err := kapi.DynamicClient(cname)
+	if err != nil {
+		exit.Error(reason.InternalKubernetesClient, "error creating dynamic client", err)
+	}
+
+	urls, err := service.GetGatewayURLs(client, namespace, gatewayName)
+	if err != nil {
+		var notFound *service.GatewayNotFoundError
+		if errors.As(err, &notFound) {
+			exit.Message(reason.SvcNotFound, `Gateway '{{.gateway}}' was not found in '{{.namespace}}' namespace.
+You may select another namespace by using 'minikube service --gateway {{.gateway}} -n <namespace>'.`, out.V{"gateway": gatewayName, "namespace": namespace})
+		}
+		var noAddress *service.GatewayNoAddressError
+		if errors.As(err, &noAddress) {
+			exit.Message(reason.SvcUnreachable, "Gateway '{{.gateway}}' has no address yet, run 'minikube tunnel' in a separate terminal to expose it", out.V{"gateway": gatewayName})
+		}
+		exit.Error(reason.SvcUnreachable, "Failed to get gateway URLs", err)
+	}
+
+	if len(urls) == 0 {
+		out.WarningT("No HTTPRoutes are attached to gateway {{.namespace}}/{{.gateway}}", out.V{"gateway": gatewayName, "namespace": namespace})
+		return
+	}
+
+	if serviceURLMode {
+		for _, u := range urls {
+			for _, gwURL := range u.URLs {
+				out.Stringf("%s\n", gwURL)
+			}
+		}
+		return
+	}
+	service.PrintGatewayList(os.Stdout, urls)
+}
+
 func startKicServiceTunnel(services service.URLs, configName, driverName string) {
 	ctrlC := make(chan os.Signal, 1)
 	signal.Notify(ctrlC, os.Interrupt)
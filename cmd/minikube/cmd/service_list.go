@@ -24,6 +24,7 @@ import (
 
 	"github.com/spf13/cobra"
 	core "k8s.io/api/core/v1"
+	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/exit"
 	"k8s.io/minikube/pkg/minikube/mustload"
@@ -35,6 +36,8 @@ import (
 
 var serviceListNamespace string
 var profileOutput string
+var serviceListAllNodes bool
+var serviceListIPFamily string
 
 // serviceListCmd represents the service list command
 var serviceListCmd = &cobra.Command{
@@ -48,12 +51,20 @@ var serviceListCmd = &cobra.Command{
 		serviceURLs, err := service.GetServiceURLs(co.API, co.Config.Name, serviceListNamespace, serviceURLTemplate)
 		if err != nil {
 			out.ErrT(style.Fatal, "Failed to get service URL - check that minikube is running and that you have specified the correct namespace (-n flag) if required: {{.error}}", out.V{"error": err})
-			os.Exit(reason.ExSvcUnavailable)
+			exit.Code(reason.ExSvcUnavailable)
 		}
 		serviceURLs = updatePortsAndURLs(serviceURLs, co)
 
+		if serviceListAllNodes || serviceListIPFamily != "" {
+			serviceURLs = addServiceListNodeEndpoints(serviceURLs, co)
+		}
+
 		switch output {
 		case "table":
+			if serviceListAllNodes || serviceListIPFamily != "" {
+				service.PrintNodeEndpointList(os.Stdout, serviceURLs)
+				return
+			}
 			printServicesTable(serviceURLs)
 		case "json":
 			printServicesJSON(serviceURLs)
@@ -77,6 +88,30 @@ func updatePortsAndURLs(serviceURLs service.URLs, co mustload.ClusterController)
 	return serviceURLs
 }
 
+// addServiceListNodeEndpoints adds the node port URLs per node and IP family,
+// of the primary control plane only unless --all-nodes is given
+func addServiceListNodeEndpoints(serviceURLs service.URLs, co mustload.ClusterController) service.URLs {
+	family, err := service.ParseIPFamily(serviceListIPFamily)
+	if err != nil {
+		exit.Message(reason.Usage, "{{.error}}", out.V{"error": err})
+	}
+
+	node := ""
+	if !serviceListAllNodes {
+		cp, err := config.ControlPlane(*co.Config)
+		if err != nil {
+			exit.Error(reason.GuestCpConfig, "Failed to get control-plane node", err)
+		}
+		node = config.MachineName(*co.Config, cp)
+	}
+
+	serviceURLs, err = service.AddNodeEndpoints(co.Config.Name, serviceURLs, serviceURLTemplate, node, family)
+	if err != nil {
+		exit.Error(reason.SvcUnreachable, "Failed to get node endpoints", err)
+	}
+	return serviceURLs
+}
+
 func printServicesTable(serviceURLs service.URLs) {
 	var data [][]string
 	for _, serviceURL := range serviceURLs {
@@ -96,5 +131,7 @@ func printServicesJSON(serviceURLs service.URLs) {
 func init() {
 	serviceListCmd.Flags().StringVarP(&profileOutput, "output", "o", "table", "The output format. One of 'json', 'table'")
 	serviceListCmd.Flags().StringVarP(&serviceListNamespace, "namespace", "n", core.NamespaceAll, "The services namespace")
+	serviceListCmd.Flags().BoolVar(&serviceListAllNodes, "all-nodes", false, "List the node port URLs of the services on every node, marking the nodes running their pods")
+	serviceListCmd.Flags().StringVar(&serviceListIPFamily, "ip-family", "", "List only the node port URLs of the given IP family. One of 'ipv4', 'ipv6'")
 	serviceCmd.AddCommand(serviceListCmd)
 }
@@ -74,14 +74,14 @@ func appendKnownHelper(nodeName string, appendKnown bool) {
 		// This is typically due to a non-zero exit code, so no need for flourish.
 		out.ErrLn("ssh-keyscan: %v", err)
 		// It'd be nice if we could pass up the correct error code here :(
-		os.Exit(1)
+		exit.Code(1)
 	}
 
 	if appendKnown {
 		addr, port, err := machine.GetSSHHostAddrPort(co.API, *co.Config, *n)
 		if err != nil {
 			out.ErrLn("GetSSHHostAddrPort: %v", err)
-			os.Exit(1)
+			exit.Code(1)
 		}
 
 		host := addr
@@ -93,7 +93,7 @@ func appendKnownHelper(nodeName string, appendKnown bool) {
 		err = os.MkdirAll(sshDir, os.FileMode(0700)) // drwx------, to match ssh-keygen behavior
 		if err != nil {
 			out.ErrLn("MkdirAll: %v", err)
-			os.Exit(1)
+			exit.Code(1)
 		}
 
 		knownHosts := filepath.Join(sshDir, "known_hosts")
@@ -105,19 +105,19 @@ func appendKnownHelper(nodeName string, appendKnown bool) {
 		f, err := os.OpenFile(knownHosts, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
 		if err != nil {
 			out.ErrLn("OpenFile: %v", err)
-			os.Exit(1)
+			exit.Code(1)
 		}
 
 		_, err = f.WriteString(keys)
 		if err != nil {
 			out.ErrLn("WriteString: %v", err)
 			f.Close()
-			os.Exit(1)
+			exit.Code(1)
 		}
 
 		if err := f.Close(); err != nil {
 			out.ErrLn("Close: %v", err)
-			os.Exit(1)
+			exit.Code(1)
 		}
 
 		fmt.Fprintf(os.Stderr, "Host added: %s (%s)\n", knownHosts, host)
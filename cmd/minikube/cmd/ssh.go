@@ -17,8 +17,6 @@ limitations under the License.
 package cmd
 
 import (
-	"os"
-
 	"github.com/spf13/cobra"
 
 	"k8s.io/minikube/pkg/minikube/config"
@@ -61,7 +59,7 @@ var sshCmd = &cobra.Command{
 			// This is typically due to a non-zero exit code, so no need for flourish.
 			out.ErrLn("ssh: %v", err)
 			// It'd be nice if we could pass up the correct error code here :(
-			os.Exit(1)
+			exit.Code(1)
 		}
 	},
 }
@@ -47,8 +47,10 @@ import (
 	"github.com/spf13/viper"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
+	"k8s.io/minikube/pkg/minikube/autopause"
 	"k8s.io/minikube/pkg/minikube/command"
 	"k8s.io/minikube/pkg/minikube/firewall"
+	"k8s.io/minikube/pkg/minikube/schedule"
 	netutil "k8s.io/minikube/pkg/network"
 
 	"k8s.io/klog/v2"
@@ -64,6 +66,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/driver/auxdriver"
 	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/journal"
 	"k8s.io/minikube/pkg/minikube/kubeconfig"
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/machine"
@@ -159,6 +162,8 @@ func runStart(cmd *cobra.Command, _ []string) {
 		exit.Message(reason.Usage, "error initializing tracing: {{.Error}}", out.V{"Error": err.Error()})
 	}
 	defer pkgtrace.Cleanup()
+	// flush the spans of failed starts too, they are the ones worth looking at
+	exit.AddHook(func(int) { pkgtrace.Cleanup() })
 
 	displayVersion(version.GetVersion())
 	go download.CleanUpOlderPreloads()
@@ -283,9 +288,28 @@ func runStart(cmd *cobra.Command, _ []string) {
 
 	kubeconfig, err := startWithDriver(cmd, starter, existing)
 	if err != nil {
+		journal.Record(starter.Cfg.Name, journal.ClusterStartFailed, fmt.Sprintf("failed to start the cluster: %v", err), nil)
 		node.ExitIfFatal(err, useForce)
 		exit.Error(reason.GuestStart, "failed to start node", err)
 	}
+	journal.Record(starter.Cfg.Name, journal.ClusterStarted, "cluster was started", map[string]string{
+		"driver":            starter.Cfg.Driver,
+		"kubernetesVersion": starter.Node.KubernetesVersion,
+		"containerRuntime":  starter.Node.ContainerRuntime,
+		"nodes":             strconv.Itoa(len(starter.Cfg.Nodes)),
+	})
+
+	// nodes drained by "minikube stop --drain" are cordoned until they start again
+	if err := node.UncordonDrained(*starter.Cfg, starter.Cfg.Nodes); err != nil {
+		klog.Warningf("unable to uncordon drained nodes: %v", err)
+	}
+
+	// the schedule daemon does not survive a reboot of the host, make sure the schedules of the profile keep running
+	if len(starter.Cfg.Schedules) > 0 {
+		if err := schedule.EnsureDaemon(); err != nil {
+			klog.Warningf("unable to start the schedule daemon: %v", err)
+		}
+	}
 
 	if err := showKubectlInfo(kubeconfig, starter.Node.KubernetesVersion, starter.Node.ContainerRuntime, starter.Cfg.Name); err != nil {
 		klog.Errorf("kubectl info: %v", err)
@@ -352,7 +376,7 @@ func provisionWithDriver(cmd *cobra.Command, ds registry.DriverState, existing *
 	// This is about as far as we can go without overwriting config files
 	if viper.GetBool(dryRun) {
 		out.Step(style.DryRun, `dry-run validation complete!`)
-		os.Exit(0)
+		exit.Code(0)
 	}
 
 	if driver.IsVM(driverName) && !driver.IsSSH(driverName) {
@@ -1295,6 +1319,12 @@ func validateFlags(cmd *cobra.Command, drvName string) { //nolint:gocyclo
 		}
 	}
 
+	if cmd.Flags().Changed(ipFamily) || cmd.Flags().Changed(ipv6Subnet) {
+		if err := validateIPFamily(viper.GetString(ipFamily), drvName, viper.GetString(ipv6Subnet), viper.GetString(cniFlag)); err != nil {
+			exit.Message(reason.Usage, "{{.err}}", out.V{"err": err})
+		}
+	}
+
 	if cmd.Flags().Changed(containerRuntime) {
 		err := validateRuntime(viper.GetString(containerRuntime))
 		if err != nil {
@@ -1321,6 +1351,19 @@ func validateFlags(cmd *cobra.Command, drvName string) { //nolint:gocyclo
 		}
 	}
 
+	if cmd.Flags().Changed(autoPauseNeverDuring) {
+		windows, _ := cmd.Flags().GetStringArray(autoPauseNeverDuring)
+		if _, err := autopause.ParseWindows(windows); err != nil {
+			exit.Message(reason.Usage, "Invalid --{{.flag}}: {{.err}}", out.V{"flag": autoPauseNeverDuring, "err": err})
+		}
+	}
+
+	if cmd.Flags().Changed(autoPauseExemptSelector) {
+		if err := validateAutoPauseSelector(viper.GetString(autoPauseExemptSelector)); err != nil {
+			exit.Message(reason.Usage, "Invalid --{{.flag}}: {{.err}}", out.V{"flag": autoPauseExemptSelector, "err": err})
+		}
+	}
+
 	if driver.IsSSH(drvName) {
 		sshIPAddress := viper.GetString(sshIPAddress)
 		if sshIPAddress == "" {
@@ -1486,6 +1529,17 @@ func validateAutoPauseInterval(interval time.Duration) error {
 	return nil
 }
 
+// validateAutoPauseSelector validates the exempt selector, only equality based requirements are supported
+func validateAutoPauseSelector(selector string) error {
+	for _, req := range strings.Split(selector, ",") {
+		k, v, ok := strings.Cut(req, "=")
+		if !ok || strings.TrimSpace(k) == "" || strings.ContainsAny(k+v, "=!()") {
+			return errors.Errorf("%q is not a key=value label requirement", req)
+		}
+	}
+	return nil
+}
+
 func getContainerRuntime(old *config.ClusterConfig) string {
 	paramRuntime := viper.GetString(containerRuntime)
 
@@ -1949,6 +2003,37 @@ func validateSubnet(subnet string) error {
 	return nil
 }
 
+// validateIPFamily checks that IPv6 is only requested with a driver, CNI and subnet supporting it
+func validateIPFamily(family, drvName, ipv6Subnet, cni string) error {
+	if err := config.ValidateIPFamily(family); err != nil {
+		return err
+	}
+	if !config.HasIPv6(config.ClusterConfig{IPFamily: family}) {
+		if ipv6Subnet != "" {
+			out.WarningT("--ipv6-subnet is only used with --ip-family=ipv6 or dual, flag will be ignored")
+		}
+		return nil
+	}
+	if !driver.IsKIC(drvName) {
+		return fmt.Errorf("IPv6 and dual-stack clusters are only supported by the docker and podman drivers")
+	}
+	switch cni {
+	case "", "auto", "bridge", "kindnet", "true", "false":
+	default:
+		return fmt.Errorf("IPv6 and dual-stack clusters are only supported with the bridge and kindnet CNIs, not %q", cni)
+	}
+	if ipv6Subnet != "" {
+		ip, _, err := netutil.ParseAddr(ipv6Subnet)
+		if err != nil {
+			return errors.Errorf("Sorry, unable to parse IPv6 subnet: %v", err)
+		}
+		if ip.To4() != nil || !ip.IsPrivate() {
+			return errors.Errorf("Sorry, the IPv6 subnet %s is not a unique local IPv6 address", ip)
+		}
+	}
+	return nil
+}
+
 func validateStaticIP(staticIP, drvName, subnet string) error {
 	if !driver.IsKIC(drvName) {
 		if staticIP != "" {
@@ -28,8 +28,10 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/addons"
 	"k8s.io/minikube/pkg/drivers/kic"
 	"k8s.io/minikube/pkg/drivers/kic/oci"
+	"k8s.io/minikube/pkg/minikube/autopause"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil/kverify"
 	"k8s.io/minikube/pkg/minikube/cni"
@@ -43,6 +45,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/proxy"
 	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/schedule"
 	"k8s.io/minikube/pkg/minikube/style"
 	pkgutil "k8s.io/minikube/pkg/util"
 	"k8s.io/minikube/pkg/version"
@@ -124,6 +127,9 @@ const (
 	ports                   = "ports"
 	network                 = "network"
 	subnet                  = "subnet"
+	ipFamily                = "ip-family"
+	ipv6Subnet              = "ipv6-subnet"
+	ipv6ServiceCIDR         = "ipv6-service-cluster-ip-range"
 	startNamespace          = "namespace"
 	trace                   = "trace"
 	sshIPAddress            = "ssh-ip-address"
@@ -144,6 +150,11 @@ const (
 	staticIP                = "static-ip"
 	gpus                    = "gpus"
 	autoPauseInterval       = "auto-pause-interval"
+	autoPauseNamespaces     = "auto-pause-namespaces"
+	autoPauseExemptNS       = "auto-pause-exempt-namespaces"
+	autoPauseExemptSelector = "auto-pause-exempt-selector"
+	autoPauseWakeOnServices = "auto-pause-wake-on-services"
+	autoPauseNeverDuring    = "auto-pause-never-during"
 )
 
 var (
@@ -199,7 +210,7 @@ func initMinikubeFlags() {
 	startCmd.Flags().Bool(forceSystemd, false, "If set, force the container runtime to use systemd as cgroup manager. Defaults to false.")
 	startCmd.Flags().String(network, "", "network to run minikube with. Now it is used by docker/podman and KVM drivers. If left empty, minikube will create a new network.")
 	startCmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Format to print stdout in. Options include: [text,json]")
-	startCmd.Flags().String(trace, "", "Send trace events. Options include: [gcp]")
+	startCmd.Flags().String(trace, "", "Send trace events. Options include: [gcp, otlp, json]")
 	startCmd.Flags().Int(extraDisks, 0, "Number of extra disks created and attached to the minikube VM (currently only implemented for hyperkit, kvm2, and qemu2 drivers)")
 	startCmd.Flags().Duration(certExpiration, constants.DefaultCertExpiration, "Duration until minikube certificate expiration, defaults to three years (26280h).")
 	startCmd.Flags().String(binaryMirror, "", "Location to fetch kubectl, kubelet, & kubeadm binaries from.")
@@ -208,6 +219,11 @@ func initMinikubeFlags() {
 	startCmd.Flags().String(staticIP, "", "Set a static IP for the minikube cluster, the IP must be: private, IPv4, and the last octet must be between 2 and 254, for example 192.168.200.200 (Docker and Podman drivers only)")
 	startCmd.Flags().StringP(gpus, "g", "", "Allow pods to use your NVIDIA GPUs. Options include: [all,nvidia] (Docker driver with Docker container-runtime only)")
 	startCmd.Flags().Duration(autoPauseInterval, time.Minute*1, "Duration of inactivity before the minikube VM is paused (default 1m0s)")
+	startCmd.Flags().StringSlice(autoPauseNamespaces, nil, "Namespaces paused by the auto-pause addon (default kube-system)")
+	startCmd.Flags().StringSlice(autoPauseExemptNS, nil, "Namespaces never paused by the auto-pause addon")
+	startCmd.Flags().String(autoPauseExemptSelector, "", "Label selector of the pods never paused by the auto-pause addon, for example: app=db,tier=backend")
+	startCmd.Flags().Bool(autoPauseWakeOnServices, false, "If true, the auto-pause addon also counts connections to NodePort and LoadBalancer services as activity, not just API requests. Requires kube-proxy in iptables mode")
+	startCmd.Flags().StringArray(autoPauseNeverDuring, nil, "Time window during which the auto-pause addon never pauses the cluster, in the local time zone, for example: 'Mon-Fri 09:00-18:00' (can be repeated)")
 }
 
 // initKubernetesFlags inits the commandline flags for Kubernetes related options
@@ -265,6 +281,8 @@ func initDriverFlags() {
 	startCmd.Flags().String(listenAddress, "", "IP Address to use to expose ports (docker and podman driver only)")
 	startCmd.Flags().StringSlice(ports, []string{}, "List of ports that should be exposed (docker and podman driver only)")
 	startCmd.Flags().String(subnet, "", "Subnet to be used on kic cluster. If left empty, minikube will choose subnet address, beginning from 192.168.49.0. (docker and podman driver only)")
+	startCmd.Flags().String(ipFamily, config.IPv4Family, "IP family of the cluster, one of 'ipv4', 'ipv6' or 'dual' for a dual-stack cluster with IPv4 as the primary family. IPv6 requires the bridge or kindnet CNI. (docker and podman driver only for ipv6 and dual)")
+	startCmd.Flags().String(ipv6Subnet, "", "IPv6 subnet to be used on kic cluster with --ip-family=ipv6 or dual. If left empty, minikube will choose a unique local subnet, beginning from fd00:192:168:49::/64. (docker and podman driver only)")
 
 	// qemu
 	startCmd.Flags().String(qemuFirmwarePath, "", "Path to the qemu firmware file. Defaults: For Linux, the default firmware location. For macOS, the brew installation location. For Windows, C:\\Program Files\\qemu\\share")
@@ -277,6 +295,7 @@ func initNetworkingFlags() {
 	startCmd.Flags().String(imageRepository, "", "Alternative image repository to pull docker images from. This can be used when you have limited access to gcr.io. Set it to \"auto\" to let minikube decide one for you. For Chinese mainland users, you may use local gcr.io mirrors such as registry.cn-hangzhou.aliyuncs.com/google_containers")
 	startCmd.Flags().String(imageMirrorCountry, "", "Country code of the image mirror to be used. Leave empty to use the global one. For Chinese mainland users, set it to cn.")
 	startCmd.Flags().String(serviceCIDR, constants.DefaultServiceCIDR, "The CIDR to be used for service cluster IPs.")
+	startCmd.Flags().String(ipv6ServiceCIDR, constants.DefaultIPv6ServiceCIDR, "The CIDR to be used for IPv6 service cluster IPs, with --ip-family=ipv6 or dual.")
 	startCmd.Flags().StringArrayVar(&config.DockerEnv, "docker-env", nil, "Environment variables to pass to the Docker daemon. (format: key=value)")
 	startCmd.Flags().StringArrayVar(&config.DockerOpt, "docker-opt", nil, "Specify arbitrary flags to pass to the Docker daemon. (format: key=value)")
 
@@ -531,6 +550,8 @@ func generateNewConfigFromFlags(cmd *cobra.Command, k8sVersion string, rtime str
 		KicBaseImage:            viper.GetString(kicBaseImage),
 		Network:                 getNetwork(drvName),
 		Subnet:                  viper.GetString(subnet),
+		IPFamily:                strings.ToLower(viper.GetString(ipFamily)),
+		IPv6Subnet:              viper.GetString(ipv6Subnet),
 		Memory:                  getMemorySize(cmd, drvName),
 		CPUs:                    getCPUCount(drvName),
 		DiskSize:                getDiskSize(),
@@ -599,6 +620,7 @@ func generateNewConfigFromFlags(cmd *cobra.Command, k8sVersion string, rtime str
 			CRISocket:              viper.GetString(criSocket),
 			NetworkPlugin:          chosenNetworkPlugin,
 			ServiceCIDR:            viper.GetString(serviceCIDR),
+			IPv6ServiceCIDR:        viper.GetString(ipv6ServiceCIDR),
 			ImageRepository:        getRepository(cmd, k8sVersion),
 			ExtraOptions:           getExtraOptions(),
 			ShouldLoadCachedImages: viper.GetBool(cacheImages),
@@ -608,6 +630,7 @@ func generateNewConfigFromFlags(cmd *cobra.Command, k8sVersion string, rtime str
 		GPUs:               viper.GetString(gpus),
 		AutoPauseInterval:  viper.GetDuration(autoPauseInterval),
 	}
+	updateAutoPausePolicyFromFlags(cmd, &cc.AutoPausePolicy)
 	cc.VerifyComponents = interpretWaitFlag(*cmd)
 	if viper.GetBool(createMount) && driver.IsKIC(drvName) {
 		cc.ContainerVolumeMounts = []string{viper.GetString(mountString)}
@@ -780,6 +803,11 @@ func updateExistingConfigFromFlags(cmd *cobra.Command, existing *config.ClusterC
 		out.WarningT("You cannot change the static IP of an existing minikube cluster. Please first delete the cluster.")
 	}
 
+	requested := config.ClusterConfig{IPFamily: viper.GetString(ipFamily)}
+	if cmd.Flags().Changed(ipFamily) && (config.HasIPv4(requested) != config.HasIPv4(*existing) || config.HasIPv6(requested) != config.HasIPv6(*existing)) {
+		out.WarningT("You cannot change the IP family of an existing minikube cluster. Please first delete the cluster.")
+	}
+
 	updateBoolFromFlag(cmd, &cc.KeepContext, keepContext)
 	updateBoolFromFlag(cmd, &cc.EmbedCerts, embedCerts)
 	updateStringFromFlag(cmd, &cc.MinikubeISO, isoURL)
@@ -819,6 +847,7 @@ func updateExistingConfigFromFlags(cmd *cobra.Command, existing *config.ClusterC
 	updateStringFromFlag(cmd, &cc.KubernetesConfig.CRISocket, criSocket)
 	updateStringFromFlag(cmd, &cc.KubernetesConfig.NetworkPlugin, networkPlugin)
 	updateStringFromFlag(cmd, &cc.KubernetesConfig.ServiceCIDR, serviceCIDR)
+	updateStringFromFlag(cmd, &cc.KubernetesConfig.IPv6ServiceCIDR, ipv6ServiceCIDR)
 	updateBoolFromFlag(cmd, &cc.KubernetesConfig.ShouldLoadCachedImages, cacheImages)
 	updateDurationFromFlag(cmd, &cc.CertExpiration, certExpiration)
 	updateBoolFromFlag(cmd, &cc.Mount, createMount)
@@ -837,6 +866,14 @@ func updateExistingConfigFromFlags(cmd *cobra.Command, existing *config.ClusterC
 	updateStringFromFlag(cmd, &cc.SocketVMnetClientPath, socketVMnetClientPath)
 	updateStringFromFlag(cmd, &cc.SocketVMnetPath, socketVMnetPath)
 	updateDurationFromFlag(cmd, &cc.AutoPauseInterval, autoPauseInterval)
+	if cmd.Flags().Changed(autoPauseInterval) {
+		// the flag overrides the interval set with minikube addons configure auto-pause
+		delete(cc.AddonValues["auto-pause"], "interval")
+	}
+	if err := addons.MigrateSecretValues(&cc); err != nil {
+		klog.Warningf("unable to move the secret addon values out of the config of %s: %v", cc.Name, err)
+	}
+	updateAutoPausePolicyFromFlags(cmd, &cc.AutoPausePolicy)
 
 	if cmd.Flags().Changed(kubernetesVersion) {
 		kubeVer, err := getKubernetesVersion(existing)
@@ -872,10 +909,8 @@ func updateExistingConfigFromFlags(cmd *cobra.Command, existing *config.ClusterC
 		cc.KicBaseImage = viper.GetString(kicBaseImage)
 	}
 
-	// If this cluster was stopped by a scheduled stop, clear the config
-	if cc.ScheduledStop != nil && time.Until(time.Unix(cc.ScheduledStop.InitiationTime, 0).Add(cc.ScheduledStop.Duration)) <= 0 {
-		cc.ScheduledStop = nil
-	}
+	// Starting the cluster again supersedes a scheduled stop which was missed
+	schedule.ClearOverdue(&cc, time.Now())
 
 	return cc
 }
@@ -922,6 +957,20 @@ func updateUint16FromFlag(cmd *cobra.Command, v *uint16, key string) {
 	}
 }
 
+// updateAutoPausePolicyFromFlags will update the auto-pause policy from the flags.
+func updateAutoPausePolicyFromFlags(cmd *cobra.Command, p *config.AutoPausePolicy) {
+	updateStringSliceFromFlag(cmd, &p.Namespaces, autoPauseNamespaces)
+	updateStringSliceFromFlag(cmd, &p.ExemptNamespaces, autoPauseExemptNS)
+	updateStringFromFlag(cmd, &p.ExemptSelector, autoPauseExemptSelector)
+	updateBoolFromFlag(cmd, &p.WakeOnServices, autoPauseWakeOnServices)
+	if cmd.Flags().Changed(autoPauseNeverDuring) {
+		// windows may contain commas, so they are not read as a comma separated slice
+		p.NeverPause, _ = cmd.Flags().GetStringArray(autoPauseNeverDuring)
+		// the windows are in the local time zone, while the daemon runs in the node
+		p.TimeZone = autopause.LocalTimeZone()
+	}
+}
+
 // interpretWaitFlag interprets the wait flag and respects the legacy minikube users
 // returns map of components to wait for
 func interpretWaitFlag(cmd cobra.Command) map[string]bool {
@@ -17,10 +17,13 @@ limitations under the License.
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
+	"runtime"
 	"strings"
 	"text/template"
 	"time"
@@ -30,6 +33,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/kapi"
 	"k8s.io/minikube/pkg/minikube/cluster"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/exit"
@@ -38,6 +42,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/notify"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/schedule"
 )
 
 var (
@@ -45,6 +50,7 @@ var (
 	output       string
 	layout       string
 	watch        time.Duration
+	watchExec    string
 )
 
 const (
@@ -60,18 +66,30 @@ kubeconfig: {{.Kubeconfig}}
 {{- if .TimeToStop }}
 timeToStop: {{.TimeToStop}}
 {{- end }}
+{{- if .NextSchedule }}
+nextSchedule: {{.NextSchedule}}
+{{- end }}
+{{- if .AutoPause }}
+autoPause: {{.AutoPause}}
+{{- end }}
 {{- if .DockerEnv }}
 docker-env: {{.DockerEnv}}
 {{- end }}
 {{- if .PodManEnv }}
 podman-env: {{.PodManEnv}}
 {{- end }}
+{{- range .NetworkChaos }}
+networkChaos: {{.}}
+{{- end }}
 
 `
 	workerStatusFormat = `{{.Name}}
 type: Worker
 host: {{.Host}}
 kubelet: {{.Kubelet}}
+{{- range .NetworkChaos }}
+networkChaos: {{.}}
+{{- end }}
 
 `
 )
@@ -82,7 +100,8 @@ var statusCmd = &cobra.Command{
 	Short: "Gets the status of a local Kubernetes cluster",
 	Long: `Gets the status of a local Kubernetes cluster.
 	Exit status contains the status of minikube's VM, cluster and Kubernetes encoded on it's bits in this order from right to left.
-	Eg: 7 meaning: 1 (for minikube NOK) + 2 (for cluster NOK) + 4 (for Kubernetes NOK)`,
+	Eg: 7 meaning: 1 (for minikube NOK) + 2 (for cluster NOK) + 4 (for Kubernetes NOK)
+	With --watch, only the transitions of the cluster, its nodes and their components are written, as they happen.`,
 	Run: func(cmd *cobra.Command, _ []string) {
 		output = strings.ToLower(output)
 		if output != "text" && statusFormat != defaultStatusFormat {
@@ -95,65 +114,154 @@ var statusCmd = &cobra.Command{
 		cname := ClusterFlagValue()
 		api, cc := mustload.Partial(cname)
 
-		duration := watch
-		if !cmd.Flags().Changed("watch") || watch < 0 {
-			duration = 0
+		if cmd.Flags().Changed("watch") && watch > 0 {
+			if statusFormat != defaultStatusFormat {
+				exit.Message(reason.Usage, "Cannot use both --watch and --format options")
+			}
+			watchStatusTransitions(watch, api, cc)
+			return
+		}
+		if watchExec != "" {
+			exit.Message(reason.Usage, "The --exec flag requires --watch")
 		}
-		writeStatusesAtInterval(duration, api, cc)
+		writeStatuses(api, cc)
 	},
 }
 
-// writeStatusesAtInterval writes statuses in a given output format - at intervals defined by duration
-func writeStatusesAtInterval(duration time.Duration, api libmachine.API, cc *config.ClusterConfig) {
-	for {
-		var statuses []*cluster.Status
+// writeStatuses writes statuses in a given output format, and exits with a code reflecting them
+func writeStatuses(api libmachine.API, cc *config.ClusterConfig) {
+	var statuses []*cluster.Status
 
-		if nodeName != "" || statusFormat != defaultStatusFormat && len(cc.Nodes) > 1 {
-			n, _, err := node.Retrieve(*cc, nodeName)
-			if err != nil {
-				exit.Error(reason.GuestNodeRetrieve, "retrieving node", err)
-			}
+	if nodeName != "" || statusFormat != defaultStatusFormat && len(cc.Nodes) > 1 {
+		n, _, err := node.Retrieve(*cc, nodeName)
+		if err != nil {
+			exit.Error(reason.GuestNodeRetrieve, "retrieving node", err)
+		}
+
+		st, err := cluster.NodeStatus(api, *cc, *n)
+		if err != nil {
+			klog.Errorf("status error: %v", err)
+		}
+		statuses = append(statuses, st)
+	} else {
+		var err error
+		statuses, err = cluster.GetStatus(api, cc)
+		if err != nil {
+			klog.Errorf("status error: %v", err)
+		}
+	}
+
+	setNextSchedule(statuses, cc)
 
-			st, err := cluster.NodeStatus(api, *cc, *n)
-			if err != nil {
-				klog.Errorf("status error: %v", err)
+	switch output {
+	case "text":
+		for _, st := range statuses {
+			if err := statusText(st, os.Stdout); err != nil {
+				exit.Error(reason.InternalStatusText, "status text failure", err)
+			}
+		}
+	case "json":
+		// Layout is currently only supported for JSON mode
+		if layout == "cluster" {
+			if err := clusterStatusJSON(statuses, os.Stdout, cc); err != nil {
+				exit.Error(reason.InternalStatusJSON, "status json failure", err)
 			}
-			statuses = append(statuses, st)
 		} else {
-			var err error
-			statuses, err = cluster.GetStatus(api, cc)
-			if err != nil {
-				klog.Errorf("status error: %v", err)
+			if err := statusJSON(statuses, os.Stdout); err != nil {
+				exit.Error(reason.InternalStatusJSON, "status json failure", err)
 			}
 		}
+	default:
+		exit.Message(reason.Usage, fmt.Sprintf("invalid output format: %s. Valid values: 'text', 'json'", output))
+	}
 
-		switch output {
-		case "text":
-			for _, st := range statuses {
-				if err := statusText(st, os.Stdout); err != nil {
-					exit.Error(reason.InternalStatusText, "status text failure", err)
-				}
+	exit.Code(exitCode(statuses))
+}
+
+// watchStatusTransitions writes the transitions of the status of the cluster as they are observed, polling every interval
+func watchStatusTransitions(interval time.Duration, api libmachine.API, cc *config.ClusterConfig) {
+	if output != "text" && output != "json" {
+		exit.Message(reason.Usage, fmt.Sprintf("invalid output format: %s. Valid values: 'text', 'json'", output))
+	}
+	machineName := ""
+	if nodeName != "" {
+		n, _, err := node.Retrieve(*cc, nodeName)
+		if err != nil {
+			exit.Error(reason.GuestNodeRetrieve, "retrieving node", err)
+		}
+		machineName = config.MachineName(*cc, *n)
+	}
+
+	w := cluster.NewWatcher(api, cc, ClusterFlagValue())
+	for {
+		for _, t := range w.Poll(time.Now()) {
+			if machineName != "" && t.Node != machineName {
+				continue
 			}
-		case "json":
-			// Layout is currently only supported for JSON mode
-			if layout == "cluster" {
-				if err := clusterStatusJSON(statuses, os.Stdout, cc); err != nil {
-					exit.Error(reason.InternalStatusJSON, "status json failure", err)
-				}
-			} else {
-				if err := statusJSON(statuses, os.Stdout); err != nil {
-					exit.Error(reason.InternalStatusJSON, "status json failure", err)
+			if err := writeTransition(t, os.Stdout); err != nil {
+				exit.Error(reason.InternalStatusText, "status transition failure", err)
+			}
+			if watchExec != "" {
+				if err := execTransitionHook(watchExec, t); err != nil {
+					out.WarningT("Status hook failed: {{.error}}", out.V{"error": err})
 				}
 			}
-		default:
-			exit.Message(reason.Usage, fmt.Sprintf("invalid output format: %s. Valid values: 'text', 'json'", output))
 		}
+		time.Sleep(interval)
+	}
+}
 
-		if duration == 0 {
-			os.Exit(exitCode(statuses))
+// writeTransition writes a transition as a JSON line or a text line, depending on the output format
+func writeTransition(t cluster.Transition, w io.Writer) error {
+	if output == "json" {
+		js, err := json.Marshal(t)
+		if err != nil {
+			return err
 		}
-		time.Sleep(duration)
+		_, err = fmt.Fprintf(w, "%s\n", js)
+		return err
+	}
+
+	change := fmt.Sprintf("%s (%d)", t.StatusName, t.StatusCode)
+	if t.PreviousStatusName != "" {
+		change = fmt.Sprintf("%s -> %s", t.PreviousStatusName, change)
 	}
+	line := fmt.Sprintf("%s %s: %s", t.Time.Format(time.RFC3339), t.Subject(), change)
+	if t.StatusDetail != "" {
+		line += ": " + t.StatusDetail
+	}
+	_, err := fmt.Fprintln(w, line)
+	return err
+}
+
+// execTransitionHook runs the user command for a transition, passing it via env variables and as JSON on stdin
+func execTransitionHook(command string, t cluster.Transition) error {
+	js, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	c := exec.Command("/bin/sh", "-c", command)
+	if runtime.GOOS == "windows" {
+		c = exec.Command("cmd", "/C", command)
+	}
+	c.Env = append(os.Environ(),
+		"MINIKUBE_TRANSITION_PROFILE="+t.Profile,
+		"MINIKUBE_TRANSITION_NODE="+t.Node,
+		"MINIKUBE_TRANSITION_COMPONENT="+t.Component,
+		"MINIKUBE_TRANSITION_SUBJECT="+t.Subject(),
+		fmt.Sprintf("MINIKUBE_TRANSITION_STATUS_CODE=%d", t.StatusCode),
+		"MINIKUBE_TRANSITION_STATUS_NAME="+t.StatusName,
+		"MINIKUBE_TRANSITION_STATUS_DETAIL="+t.StatusDetail,
+		fmt.Sprintf("MINIKUBE_TRANSITION_PREVIOUS_STATUS_CODE=%d", t.PreviousStatusCode),
+		"MINIKUBE_TRANSITION_PREVIOUS_STATUS_NAME="+t.PreviousStatusName,
+	)
+	c.Stdin = bytes.NewReader(js)
+	c.Stdout = os.Stderr
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return errors.Wrapf(err, "running %q", command)
+	}
+	return nil
 }
 
 // exitCode calculates the appropriate exit code given a set of status messages
@@ -182,8 +290,9 @@ For the list accessible variables for the template, see the struct values here:
 	statusCmd.Flags().StringVarP(&layout, "layout", "l", "nodes",
 		`output layout (EXPERIMENTAL, JSON only): 'nodes' or 'cluster'`)
 	statusCmd.Flags().StringVarP(&nodeName, "node", "n", "", "The node to check status for. Defaults to control plane. Leave blank with default format for status on all nodes.")
-	statusCmd.Flags().DurationVarP(&watch, "watch", "w", 1*time.Second, "Continuously listing/getting the status with optional interval duration.")
+	statusCmd.Flags().DurationVarP(&watch, "watch", "w", 1*time.Second, "Continuously watch the status with optional polling interval, writing only its transitions.")
 	statusCmd.Flags().Lookup("watch").NoOptDefVal = "1s"
+	statusCmd.Flags().StringVar(&watchExec, "exec", "", "Command to run on each status transition when watching. The transition is passed as JSON on stdin and via MINIKUBE_TRANSITION_* env variables.")
 }
 
 func statusText(st *cluster.Status, w io.Writer) error {
@@ -204,6 +313,20 @@ func statusText(st *cluster.Status, w io.Writer) error {
 	return nil
 }
 
+// setNextSchedule describes the next run of the recurring schedules of the cluster on its control planes.
+// It is shown whatever the state of the hosts, as schedules like a start run on stopped clusters.
+func setNextSchedule(statuses []*cluster.Status, cc *config.ClusterConfig) {
+	r, ok := schedule.NextRun(cc, time.Now())
+	if !ok {
+		return
+	}
+	for _, st := range statuses {
+		if st != nil && !st.Worker {
+			st.NextSchedule = r.Describe(time.Now())
+		}
+	}
+}
+
 func statusJSON(st []*cluster.Status, w io.Writer) error {
 	var js []byte
 	var err error
@@ -222,6 +345,14 @@ func statusJSON(st []*cluster.Status, w io.Writer) error {
 
 func clusterStatusJSON(statuses []*cluster.Status, w io.Writer, cc *config.ClusterConfig) error {
 	cs := cluster.GetState(statuses, ClusterFlagValue(), cc)
+	if cs.StatusCode == cluster.OK || cs.StatusCode == cluster.OKHAppy {
+		client, err := kapi.Client(cc.Name)
+		if err != nil {
+			klog.Warningf("unable to get a client for the component health: %v", err)
+		} else if err := cluster.AddComponentHealth(&cs, client, cc); err != nil {
+			klog.Warningf("unable to get the component health: %v", err)
+		}
+	}
 
 	bs, err := json.Marshal(cs)
 	if err != nil {
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"k8s.io/minikube/pkg/minikube/cluster"
 )
@@ -107,3 +108,38 @@ func TestStatusJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteTransition(t *testing.T) {
+	tr := cluster.Transition{
+		Time:               time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC),
+		Profile:            "minikube",
+		Node:               "minikube-m02",
+		Component:          "kubelet",
+		StatusCode:         cluster.Stopped,
+		StatusName:         "Stopped",
+		StatusDetail:       "stopped",
+		PreviousStatusCode: cluster.OK,
+		PreviousStatusName: "OK",
+	}
+	defer func(o string) { output = o }(output)
+
+	var tests = []struct {
+		output string
+		want   string
+	}{
+		{"text", "2024-10-01T12:00:00Z node/minikube-m02/kubelet: OK -> Stopped (405): stopped\n"},
+		{"json", `{"Time":"2024-10-01T12:00:00Z","Profile":"minikube","Node":"minikube-m02","Component":"kubelet","StatusCode":405,"StatusName":"Stopped","StatusDetail":"stopped","PreviousStatusCode":200,"PreviousStatusName":"OK"}` + "\n"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.output, func(t *testing.T) {
+			output = tc.output
+			var b bytes.Buffer
+			if err := writeTransition(tr, &b); err != nil {
+				t.Fatalf("writeTransition: %v", err)
+			}
+			if got := b.String(); got != tc.want {
+				t.Errorf("writeTransition() = %q, want: %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -18,7 +18,7 @@ package cmd
 
 import (
 	"os"
-	"runtime"
+	"strconv"
 	"time"
 
 	"github.com/docker/machine/libmachine"
@@ -28,11 +28,14 @@ import (
 	"github.com/spf13/viper"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/journal"
 	"k8s.io/minikube/pkg/minikube/kubeconfig"
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/node"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/out/register"
 	"k8s.io/minikube/pkg/minikube/reason"
@@ -46,6 +49,8 @@ var (
 	keepActive            bool
 	scheduledStopDuration time.Duration
 	cancelScheduledStop   bool
+	drainNodes            bool
+	drainTimeout          time.Duration
 )
 
 // stopCmd represents the stop command
@@ -62,6 +67,7 @@ func init() {
 	stopCmd.Flags().DurationVar(&scheduledStopDuration, "schedule", 0*time.Second, "Set flag to stop cluster after a set amount of time (e.g. --schedule=5m)")
 	stopCmd.Flags().BoolVar(&cancelScheduledStop, "cancel-scheduled", false, "cancel any existing scheduled stop requests")
 	stopCmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Format to print stdout in. Options include: [text,json]")
+	addDrainFlags(stopCmd)
 
 	if err := viper.GetViper().BindPFlags(stopCmd.Flags()); err != nil {
 		exit.Error(reason.InternalBindFlags, "unable to bind flags", err)
@@ -93,8 +99,8 @@ func runStop(_ *cobra.Command, _ []string) {
 		profilesToStop = append(profilesToStop, cname)
 	}
 
-	// Kill any existing scheduled stops
-	schedule.KillExisting(profilesToStop)
+	// Cancel any existing scheduled stops
+	cancelScheduledStops(profilesToStop)
 	if cancelScheduledStop {
 		register.Reg.SetStep(register.Done)
 		out.Step(style.Stopped, `All existing scheduled stops cancelled`)
@@ -102,15 +108,8 @@ func runStop(_ *cobra.Command, _ []string) {
 	}
 
 	if scheduledStopDuration != 0 {
-		if err := schedule.Daemonize(profilesToStop, scheduledStopDuration); err != nil {
-			exit.Message(reason.DaemonizeError, "unable to daemonize: {{.err}}", out.V{"err": err.Error()})
-		}
-		// if OS is windows, scheduled stop is now being handled within minikube, so return
-		if runtime.GOOS == "windows" {
-			return
-		}
-		klog.Infof("sleeping %s before completing stop...", scheduledStopDuration.String())
-		time.Sleep(scheduledStopDuration)
+		scheduleStops(profilesToStop, scheduledStopDuration)
+		return
 	}
 
 	stoppedNodes := 0
@@ -122,6 +121,46 @@ func runStop(_ *cobra.Command, _ []string) {
 	out.Step(style.Stopped, `{{.count}} node{{if gt .count 1}}s{{end}} stopped.`, out.V{"count": stoppedNodes})
 }
 
+// cancelScheduledStops removes the one-off stops of the profiles from their schedules
+func cancelScheduledStops(profiles []string) {
+	for _, profile := range profiles {
+		cc, err := config.Load(profile)
+		if err != nil {
+			klog.Warningf("unable to load profile %s: %v", profile, err)
+			continue
+		}
+		if !schedule.CancelStops(cc) {
+			continue
+		}
+		if err := config.SaveProfile(profile, cc); err != nil {
+			klog.Errorf("error saving profile for profile %s: %v", profile, err)
+		}
+	}
+}
+
+// scheduleStops adds a one-off stop after d to the schedules of the profiles, which the schedule daemon runs
+func scheduleStops(profiles []string, d time.Duration) {
+	at := time.Now().Add(d)
+	for _, profile := range profiles {
+		_, cc := mustload.Partial(profile)
+		if driver.BareMetal(cc.Driver) {
+			out.WarningT("scheduled stop is not supported on the none driver, skipping scheduling")
+			continue
+		}
+		s := config.Schedule{Operation: "stop", At: at.Unix()}
+		s.Name = defaultScheduleName(cc, s)
+		cc.Schedules = append(cc.Schedules, s)
+		if err := config.SaveProfile(profile, cc); err != nil {
+			exit.Error(reason.HostSaveProfile, "failed to save config", err)
+		}
+	}
+	if err := schedule.EnsureDaemon(); err != nil {
+		exit.Message(reason.DaemonizeError, "unable to daemonize: {{.err}}", out.V{"err": err.Error()})
+	}
+	register.Reg.SetStep(register.Done)
+	out.Step(style.Stopped, "Scheduled a stop at {{.time}}", out.V{"time": at.Format("Mon 15:04:05")})
+}
+
 func stopProfile(profile string) int {
 	stoppedNodes := 0
 	register.Reg.SetStep(register.Stopping)
@@ -134,17 +173,34 @@ func stopProfile(profile string) int {
 		out.WarningT("Unable to kill mount process: {{.error}}", out.V{"error": err})
 	}
 
+	drained := drain(api, *cc, cc.Nodes)
+
 	// stop nodes in reverse order, so last one being primary control-plane node, that will start first next time
 	for i := len(cc.Nodes) - 1; i >= 0; i-- {
 		n := cc.Nodes[i]
 		machineName := config.MachineName(*cc, n)
 
+		if drained {
+			stopKubelet(api, machineName)
+		}
 		nonexistent := stop(api, machineName)
 		if !nonexistent {
 			stoppedNodes++
 		}
 	}
 
+	// network faults do not survive a restart of the nodes, so stop recording them
+	if len(cc.NetworkChaos) > 0 {
+		cc.NetworkChaos = nil
+		if err := config.SaveProfile(profile, cc); err != nil {
+			out.WarningT("Unable to clear the network faults of the profile: {{.error}}", out.V{"error": err})
+		}
+	}
+
+	if stoppedNodes > 0 {
+		journal.Record(profile, journal.ClusterStopped, "cluster was stopped", map[string]string{"nodes": strconv.Itoa(stoppedNodes)})
+	}
+
 	if !keepActive {
 		if err := kubeconfig.DeleteContext(profile, kubeconfig.PathFromEnv()); err != nil {
 			exit.Error(reason.HostKubeconfigDeleteCtx, "delete ctx", err)
@@ -154,6 +210,73 @@ func stopProfile(profile string) int {
 	return stoppedNodes
 }
 
+// addDrainFlags adds the flags of the drain which runs before nodes stop
+func addDrainFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&drainNodes, "drain", false, "If set, drain the nodes before stopping them: cordon them and evict their pods, respecting PodDisruptionBudgets, so that preStop hooks run and pods shut down cleanly")
+	cmd.Flags().DurationVar(&drainTimeout, "drain-timeout", 2*time.Minute, "How long to wait for the pods to be evicted with --drain, the pods left are killed when the nodes stop")
+}
+
+// drain drains the running nodes when --drain is set, and returns whether they were drained.
+// Failures are only reported: the nodes are stopped anyway.
+func drain(api libmachine.API, cc config.ClusterConfig, nodes []config.Node) bool {
+	if !drainNodes {
+		return false
+	}
+	var running []config.Node
+	for _, n := range nodes {
+		if machine.IsRunning(api, config.MachineName(cc, n)) {
+			running = append(running, n)
+		}
+	}
+	if len(running) == 0 {
+		return false
+	}
+
+	register.Reg.SetStep(register.Draining)
+	out.Step(style.Waiting, "Draining {{.count}} node{{if gt .count 1}}s{{end}}, waiting up to {{.timeout}} for the pods to be evicted ...", out.V{"count": len(running), "timeout": drainTimeout})
+	evictions, err := node.DrainNodes(cc, running, drainTimeout)
+	if err != nil {
+		out.WarningT("Unable to drain the nodes, stopping them anyway: {{.error}}", out.V{"error": err})
+		return false
+	}
+	failed, kept := 0, 0
+	for _, e := range evictions {
+		errMsg := ""
+		noController := errors.Is(e.Err, node.ErrNoController)
+		switch {
+		case noController:
+			kept++
+		case e.Err != nil:
+			failed++
+		}
+		if e.Err != nil {
+			errMsg = e.Err.Error()
+		}
+		if out.JSON {
+			register.PrintEviction(e.Node, e.Namespace, e.Pod, e.Duration.Round(time.Millisecond).String(), errMsg)
+			continue
+		}
+		if noController {
+			out.Styled(style.SubStep, "Kept pod {{.namespace}}/{{.pod}} on {{.node}}: it has no controller to recreate it elsewhere, it runs again when the node starts", out.V{"namespace": e.Namespace, "pod": e.Pod, "node": e.Node})
+			continue
+		}
+		if e.Err != nil {
+			out.WarningT("Pod {{.namespace}}/{{.pod}} on {{.node}} was not evicted: {{.error}}", out.V{"namespace": e.Namespace, "pod": e.Pod, "node": e.Node, "error": errMsg})
+			continue
+		}
+		out.Styled(style.SubStep, "Evicted pod {{.namespace}}/{{.pod}} from {{.node}} in {{.duration}}", out.V{"namespace": e.Namespace, "pod": e.Pod, "node": e.Node, "duration": e.Duration.Round(time.Millisecond)})
+	}
+	out.Step(style.Check, "Drained {{.count}} node{{if gt .count 1}}s{{end}}: {{.evicted}} pods evicted, {{.failed}} left, {{.kept}} kept", out.V{"count": len(running), "evicted": len(evictions) - failed - kept, "failed": failed, "kept": kept})
+	return true
+}
+
+// stopKubelet stops the kubelet of a drained node, so that it does not restart the evicted pods while the node stops
+func stopKubelet(api libmachine.API, machineName string) {
+	if err := node.StopKubelet(api, machineName); err != nil {
+		klog.Warningf("unable to stop the kubelet of %s (will continue): %v", machineName, err)
+	}
+}
+
 func stop(api libmachine.API, machineName string) bool {
 	nonexistent := false
 
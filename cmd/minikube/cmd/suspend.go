@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/journal"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var suspendToDisk bool
+
+// suspendCmd represents the suspend command
+var suspendCmd = &cobra.Command{
+	Use:   "suspend",
+	Short: "Suspends the nodes of the cluster, freeing their CPU and optionally their memory",
+	Long: `Suspends the nodes of the cluster as a whole: unlike pause, which freezes the containers of Kubernetes but keeps the nodes running, suspend freezes the node containers of the docker and podman drivers and the VMs of the kvm2 driver.
+With --to-disk, the kvm2 driver saves the memory of the VMs to disk and stops them, freeing their memory too.
+Run "minikube resume" to resume the nodes.`,
+	Example: `minikube suspend
+minikube suspend --to-disk`,
+	Run: func(_ *cobra.Command, _ []string) {
+		cname := ClusterFlagValue()
+		api, cc := mustload.Partial(cname)
+		out.SetJSON(outputFormat == "json")
+
+		if !machine.SupportsSuspend(cc.Driver, suspendToDisk) {
+			if suspendToDisk {
+				exit.Message(reason.DrvUnsupported, "Suspending to disk is not supported by the {{.driver}} driver, try without --to-disk", out.V{"driver": cc.Driver})
+			}
+			exit.Message(reason.DrvUnsupported, "Suspending is not supported by the {{.driver}} driver, try \"minikube pause\" or \"minikube stop\"", out.V{"driver": cc.Driver})
+		}
+
+		var suspended []string
+		for _, n := range suspendOrder(cc.Nodes) {
+			name := config.MachineName(*cc, n)
+			st, err := machine.Status(api, name)
+			if err != nil {
+				exit.Error(reason.GuestStatus, "Error getting host status", err)
+			}
+			if machine.IsSuspended(st) {
+				out.Step(style.Pause, "Node {{.name}} is already suspended", out.V{"name": name})
+				continue
+			}
+
+			out.Step(style.Pause, "Suspending node {{.name}} ...", out.V{"name": name})
+			if err := machine.SuspendHost(api, name, suspendToDisk); err != nil {
+				exit.Error(reason.GuestSuspend, "Suspend", err)
+			}
+			suspended = append(suspended, name)
+		}
+
+		if len(suspended) > 0 {
+			journal.Record(cc.Name, journal.ClusterSuspended, "cluster was suspended", map[string]string{"nodes": strings.Join(suspended, ","), "toDisk": strconv.FormatBool(suspendToDisk)})
+		}
+		out.Step(style.Pause, "Suspended {{.count}} nodes", out.V{"count": len(suspended)})
+	},
+}
+
+// suspendOrder returns the nodes in the order in which they are suspended: workers, then control planes.
+// They are resumed in the reverse order, so that the workers find the API server running.
+func suspendOrder(nodes []config.Node) []config.Node {
+	var ordered []config.Node
+	for _, n := range nodes {
+		if !n.ControlPlane {
+			ordered = append(ordered, n)
+		}
+	}
+	for _, n := range nodes {
+		if n.ControlPlane {
+			ordered = append(ordered, n)
+		}
+	}
+	return ordered
+}
+
+func init() {
+	suspendCmd.Flags().BoolVar(&suspendToDisk, "to-disk", false, "If set, save the memory of the nodes to disk and stop them, freeing their memory (kvm2 driver only)")
+	suspendCmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Format to print stdout in. Options include: [text,json]")
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestSuspendOrder(t *testing.T) {
+	nodes := []config.Node{
+		{Name: "", ControlPlane: true},
+		{Name: "m02", ControlPlane: false},
+		{Name: "m03", ControlPlane: true},
+		{Name: "m04", ControlPlane: false},
+	}
+	var got []string
+	for _, n := range suspendOrder(nodes) {
+		got = append(got, n.Name)
+	}
+	want := []string{"m02", "m04", "", "m03"}
+	if len(got) != len(want) {
+		t.Fatalf("suspendOrder() = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("suspendOrder() = %q, want %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/docker/machine/libmachine/state"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/metrics"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/reason"
+)
+
+var (
+	topInterval time.Duration
+	topPods     int
+	topOnce     bool
+)
+
+// clearScreen moves the cursor home and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
+// topCmd represents the top command
+var topCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Display the resource usage of the cluster nodes and pods",
+	Long: `Displays, per node, the state and the CPU, memory and disk usage of the node container or VM as seen by the driver,
+the usage reported by the kubelet, and the pods using the most resources.
+The kubelet usage is read from its /stats/summary endpoint, so the metrics-server addon is not needed.
+The display is refreshed every --interval until interrupted with Ctrl-C.`,
+	Example: `minikube top
+minikube top --interval 5s --pods 20
+minikube top --once`,
+	Run: func(_ *cobra.Command, _ []string) {
+		cname := ClusterFlagValue()
+		api, cc := mustload.Partial(cname)
+
+		tty := term.IsTerminal(int(os.Stdout.Fd()))
+		t := &topView{profile: cname}
+		for {
+			u, err := metrics.CollectUsage(api, cc, topPods)
+			if err != nil {
+				exit.Error(reason.GuestStatus, "Failed to get the resource usage", err)
+			}
+			if tty && !topOnce {
+				fmt.Print(clearScreen)
+			}
+			t.render(os.Stdout, u)
+			if topOnce {
+				return
+			}
+			time.Sleep(topInterval)
+		}
+	},
+}
+
+// topView renders usage snapshots, keeping the previous one to turn CPU time counters into rates
+type topView struct {
+	profile string
+	// cpuSeconds is the CPU time of the VMs at the previous snapshot, by node
+	cpuSeconds map[string]float64
+	last       time.Time
+}
+
+func (t *topView) render(w io.Writer, u *metrics.Usage) {
+	clusterState := "Running"
+	if u.Paused {
+		clusterState = "Paused"
+	}
+	fmt.Fprintf(w, "minikube top - %s - %s - %s\n\n", t.profile, clusterState, u.Time.Format(time.TimeOnly))
+
+	nodes := tablewriter.NewWriter(w)
+	nodes.SetHeader([]string{"Node", "State", "Host CPU", "Host Memory", "Disk (/var)", "Kubelet CPU", "Kubelet Memory"})
+	nodes.SetAutoFormatHeaders(false)
+	nodes.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+	nodes.SetCenterSeparator("|")
+	cpuSeconds := map[string]float64{}
+	for _, n := range u.Nodes {
+		kcpu, kmem := "-", "-"
+		if n.Kubelet != nil {
+			kcpu = millicores(n.Kubelet.CPU.Cores())
+			kmem = usageOf(n.Kubelet.Memory.WorkingSet(), availableMemory(n.Kubelet))
+		}
+		if n.Machine.CPUSeconds != nil {
+			cpuSeconds[n.Status.Name] = *n.Machine.CPUSeconds
+		}
+		nodes.Append([]string{
+			n.Status.Name,
+			nodeState(n.Status.Host, n.Status.Kubelet, n.Status.APIServer),
+			t.hostCPU(n, u.Time),
+			usageOfPtr(n.Machine.MemoryUsage, n.Machine.MemoryLimit),
+			usageOfPtr(n.DiskUsed, n.DiskSize),
+			kcpu,
+			kmem,
+		})
+	}
+	nodes.Render()
+	t.cpuSeconds = cpuSeconds
+	t.last = u.Time
+
+	if len(u.Pods) == 0 {
+		return
+	}
+	fmt.Fprintln(w)
+	pods := tablewriter.NewWriter(w)
+	pods.SetHeader([]string{"Namespace", "Pod", "Node", "CPU", "Memory"})
+	pods.SetAutoFormatHeaders(false)
+	pods.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+	pods.SetCenterSeparator("|")
+	for _, p := range u.Pods {
+		pods.Append([]string{p.Namespace, p.Name, p.Node, millicores(p.CPUCores), units.BytesSize(p.MemoryBytes)})
+	}
+	pods.Render()
+}
+
+// hostCPU returns the CPU usage of the node container, or of the VM since the previous snapshot
+func (t *topView) hostCPU(n metrics.NodeUsage, now time.Time) string {
+	if n.Machine.CPURatio != nil {
+		return millicores(*n.Machine.CPURatio)
+	}
+	if n.Machine.CPUSeconds == nil {
+		return "-"
+	}
+	prev, ok := t.cpuSeconds[n.Status.Name]
+	elapsed := now.Sub(t.last).Seconds()
+	if !ok || elapsed <= 0 {
+		return "-"
+	}
+	return millicores((*n.Machine.CPUSeconds - prev) / elapsed)
+}
+
+// nodeState summarizes the state of a node
+func nodeState(host, kubelet, apiserver string) string {
+	switch {
+	case host != state.Running.String():
+		return host
+	case apiserver == state.Paused.String():
+		return "Paused"
+	case kubelet != state.Running.String():
+		return "Kubelet " + kubelet
+	}
+	return "Running"
+}
+
+// availableMemory returns the memory of a node as seen by its kubelet, 0 if it is unknown
+func availableMemory(n *metrics.NodeSummary) float64 {
+	if n.Memory == nil || n.Memory.AvailableBytes == nil {
+		return 0
+	}
+	return n.Memory.WorkingSet() + float64(*n.Memory.AvailableBytes)
+}
+
+// millicores formats a CPU usage in cores like kubectl top does
+func millicores(cores float64) string {
+	return fmt.Sprintf("%.0fm", cores*1000)
+}
+
+// usageOf formats a usage in bytes, with its share of the total if it is known
+func usageOf(used, total float64) string {
+	if total <= 0 {
+		return units.BytesSize(used)
+	}
+	return fmt.Sprintf("%s / %s (%.0f%%)", units.BytesSize(used), units.BytesSize(total), used/total*100)
+}
+
+func usageOfPtr(used, total *float64) string {
+	switch {
+	case used == nil:
+		return "-"
+	case total == nil:
+		return usageOf(*used, 0)
+	}
+	return usageOf(*used, *total)
+}
+
+func init() {
+	topCmd.Flags().DurationVar(&topInterval, "interval", 2*time.Second, "How often to refresh the display")
+	topCmd.Flags().IntVar(&topPods, "pods", 10, "The number of pods using the most resources to display")
+	topCmd.Flags().BoolVar(&topOnce, "once", false, "Display the resource usage once and exit")
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/minikube/pkg/minikube/cluster"
+	"k8s.io/minikube/pkg/minikube/metrics"
+)
+
+func TestNodeState(t *testing.T) {
+	var tests = []struct {
+		host, kubelet, apiserver string
+		want                     string
+	}{
+		{"Running", "Running", "Running", "Running"},
+		{"Running", "Stopped", "Paused", "Paused"},
+		{"Running", "Stopped", "Irrelevant", "Kubelet Stopped"},
+		{"Stopped", "Stopped", "Stopped", "Stopped"},
+	}
+	for _, tc := range tests {
+		if got := nodeState(tc.host, tc.kubelet, tc.apiserver); got != tc.want {
+			t.Errorf("nodeState(%q, %q, %q) = %q, want: %q", tc.host, tc.kubelet, tc.apiserver, got, tc.want)
+		}
+	}
+}
+
+func TestTopRender(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	usage := func(cpuSeconds float64, at time.Time) *metrics.Usage {
+		return &metrics.Usage{
+			Time: at,
+			Nodes: []metrics.NodeUsage{
+				{
+					Status:   &cluster.Status{Name: "minikube", Host: "Running", Kubelet: "Running", APIServer: "Running"},
+					Machine:  metrics.MachineStats{CPUSeconds: f(cpuSeconds), MemoryUsage: f(1 << 30), MemoryLimit: f(4 << 30)},
+					DiskSize: f(20 << 30),
+					DiskUsed: f(5 << 30),
+				},
+				{
+					Status: &cluster.Status{Name: "minikube-m02", Host: "Stopped", Kubelet: "Stopped", APIServer: "Irrelevant"},
+				},
+			},
+			Pods: []metrics.PodUsage{{Namespace: "kube-system", Name: "etcd-minikube", Node: "minikube", CPUCores: 0.03, MemoryBytes: 50 << 20}},
+		}
+	}
+
+	v := &topView{profile: "minikube"}
+	var b bytes.Buffer
+	v.render(&b, usage(100, now))
+	for _, want := range []string{"minikube top - minikube - Running - 12:00:00", "1GiB / 4GiB (25%)", "5GiB / 20GiB (25%)", "minikube-m02", "Stopped", "etcd-minikube", "30m", "50MiB"} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("render() output does not contain %q:\n%s", want, b.String())
+		}
+	}
+
+	// the CPU usage of VMs is the rate of their CPU time since the previous snapshot
+	b.Reset()
+	v.render(&b, usage(101, now.Add(2*time.Second)))
+	if !strings.Contains(b.String(), "500m") {
+		t.Errorf("render() output does not contain the VM CPU rate 500m:\n%s", b.String())
+	}
+}
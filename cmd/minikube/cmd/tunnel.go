@@ -34,6 +34,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/journal"
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/mustload"
 	"k8s.io/minikube/pkg/minikube/out"
@@ -52,7 +53,7 @@ var lockHandle *fslock.Lock
 var tunnelCmd = &cobra.Command{
 	Use:   "tunnel",
 	Short: "Connect to LoadBalancer services",
-	Long:  `tunnel creates a route to services deployed with type LoadBalancer and sets their Ingress to their ClusterIP. for a detailed example see https://minikube.sigs.k8s.io/docs/tasks/loadbalancer`,
+	Long:  `tunnel creates a route to services deployed with type LoadBalancer and sets their Ingress to their ClusterIP. Gateway API gateways are exposed on their listener ports, and the ones without addresses from their controller get their addresses set as well. for a detailed example see https://minikube.sigs.k8s.io/docs/tasks/loadbalancer`,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		RootCmd.PersistentPreRun(cmd, args)
 	},
@@ -78,6 +79,13 @@ var tunnelCmd = &cobra.Command{
 
 		mustLockOrExit(cname)
 		defer cleanupLock()
+		if err := tunnel.RecordPid(cname); err != nil {
+			klog.Warningf("unable to record the tunnel pid: %v", err)
+		}
+		defer tunnel.RemovePid(cname)
+
+		journal.Record(cname, journal.TunnelStarted, "tunnel was started", nil)
+		defer journal.Record(cname, journal.TunnelStopped, "tunnel was stopped", nil)
 
 		// Tunnel uses the k8s clientset to query the API server for services in the LoadBalancerEmulator.
 		// We define the tunnel and minikube error free if the API server responds within a second.
@@ -88,6 +96,12 @@ var tunnelCmd = &cobra.Command{
 			exit.Error(reason.InternalKubernetesClient, "error creating clientset", err)
 		}
 
+		// The dynamic client is used to expose Gateway API gateways, which are not part of the clientset.
+		dynamicClient, err := kapi.DynamicClient(cname)
+		if err != nil {
+			exit.Error(reason.InternalKubernetesClient, "error creating dynamic client", err)
+		}
+
 		ctrlC := make(chan os.Signal, 1)
 		signal.Notify(ctrlC, os.Interrupt)
 		ctx, cancel := context.WithCancel(context.Background())
@@ -105,7 +119,7 @@ var tunnelCmd = &cobra.Command{
 			sshKey := filepath.Join(localpath.MiniPath(), "machines", cname, "id_rsa")
 
 			outputTunnelStarted()
-			kicSSHTunnel := kic.NewSSHTunnel(ctx, sshPort, sshKey, bindAddress, clientset.CoreV1(), clientset.NetworkingV1())
+			kicSSHTunnel := kic.NewSSHTunnel(ctx, sshPort, sshKey, bindAddress, clientset.CoreV1(), clientset.NetworkingV1(), dynamicClient)
 			err = kicSSHTunnel.Start()
 			if err != nil {
 				exit.Error(reason.SvcTunnelStart, "error starting tunnel", err)
@@ -114,7 +128,7 @@ var tunnelCmd = &cobra.Command{
 			return
 		}
 
-		done, err := manager.StartTunnel(ctx, cname, co.API, config.DefaultLoader, clientset.CoreV1())
+		done, err := manager.StartTunnel(ctx, cname, co.API, config.DefaultLoader, clientset.CoreV1(), dynamicClient)
 		if err != nil {
 			exit.Error(reason.SvcTunnelStart, "error starting tunnel", err)
 		}
@@ -132,9 +146,7 @@ func cleanupLock() {
 }
 
 func mustLockOrExit(profile string) {
-	tunnelLockPath := filepath.Join(localpath.Profile(profile), ".tunnel_lock")
-
-	lockHandle = fslock.New(tunnelLockPath)
+	lockHandle = fslock.New(localpath.TunnelLock(profile))
 	err := lockHandle.TryLock()
 	if err == fslock.ErrLocked {
 		exit.Message(reason.SvcTunnelAlreadyRunning, "Another tunnel process is already running, terminate the existing instance to start a new one")
@@ -28,6 +28,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/cruntime"
 	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/journal"
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/mustload"
@@ -39,10 +40,12 @@ import (
 
 // unpauseCmd represents the docker-pause command
 var unpauseCmd = &cobra.Command{
-	Use:     "unpause",
-	Aliases: []string{"resume"},
-	Short:   "unpause Kubernetes",
-	Run: func(_ *cobra.Command, _ []string) {
+	Use:   "unpause",
+	Short: "unpause Kubernetes",
+	Long:  `Unpauses the containers of the given namespaces, or only the pods matching --selector or named by --pods. The kubelet is started again once kube-system is no longer paused.`,
+	Example: `minikube unpause -A
+minikube unpause -l app=kafka`,
+	Run: func(cmd *cobra.Command, _ []string) {
 		cname := ClusterFlagValue()
 		register.SetEventLogPath(localpath.EventLog(cname))
 
@@ -51,13 +54,7 @@ var unpauseCmd = &cobra.Command{
 		register.Reg.SetStep(register.Unpausing)
 
 		klog.Infof("namespaces: %v keys: %v", namespaces, viper.AllSettings())
-		if allNamespaces {
-			namespaces = nil // all
-		} else {
-			if len(namespaces) == 0 {
-				exit.Message(reason.Usage, "Use -A to specify all namespaces")
-			}
-		}
+		sel := pauseSelection(cmd)
 
 		ids := []string{}
 
@@ -88,7 +85,7 @@ var unpauseCmd = &cobra.Command{
 				exit.Error(reason.InternalNewRuntime, "Failed runtime", err)
 			}
 
-			uids, err := cluster.Unpause(cr, r, namespaces)
+			uids, err := cluster.UnpauseSelection(cr, r, sel)
 			if err != nil {
 				exit.Error(reason.GuestUnpause, "Pause", err)
 			}
@@ -96,11 +93,15 @@ var unpauseCmd = &cobra.Command{
 		}
 
 		register.Reg.SetStep(register.Done)
+		journal.Record(cname, journal.ClusterUnpaused, "cluster was unpaused", selectionData(sel, len(ids)))
 
-		if namespaces == nil {
+		switch {
+		case len(sel.Pods) > 0 || len(sel.Labels) > 0:
+			out.Step(style.Pause, "Unpaused {{.count}} containers of pods {{.pods}} in: {{.namespaces}}", out.V{"count": len(ids), "pods": podsValue(sel), "namespaces": namespacesValue(sel.Namespaces)})
+		case sel.Namespaces == nil:
 			out.Step(style.Pause, "Unpaused {{.count}} containers", out.V{"count": len(ids)})
-		} else {
-			out.Step(style.Pause, "Unpaused {{.count}} containers in: {{.namespaces}}", out.V{"count": len(ids), "namespaces": strings.Join(namespaces, ", ")})
+		default:
+			out.Step(style.Pause, "Unpaused {{.count}} containers in: {{.namespaces}}", out.V{"count": len(ids), "namespaces": strings.Join(sel.Namespaces, ", ")})
 		}
 	},
 }
@@ -108,5 +109,7 @@ var unpauseCmd = &cobra.Command{
 func init() {
 	unpauseCmd.Flags().StringSliceVarP(&namespaces, "namespaces", "n", constants.DefaultNamespaces, "namespaces to unpause")
 	unpauseCmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "If set, unpause all namespaces")
+	unpauseCmd.Flags().StringVarP(&pauseSelector, "selector", "l", "", "Only unpause the pods matching this label selector, like app=kafka, in the default namespace unless --namespaces is set")
+	unpauseCmd.Flags().StringSliceVar(&pausePods, "pods", []string{}, "Only unpause the pods with these names, in the default namespace unless --namespaces is set")
 	unpauseCmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Format to print stdout in. Options include: [text,json]")
 }
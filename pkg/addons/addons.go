@@ -22,7 +22,6 @@ import (
 	"os/exec"
 	"path"
 	"runtime"
-	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -32,6 +31,7 @@ import (
 	"github.com/docker/machine/libmachine/state"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
 
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/drivers/kic/oci"
@@ -44,12 +44,14 @@ import (
 	"k8s.io/minikube/pkg/minikube/cruntime"
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/journal"
 	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/out/register"
 	"k8s.io/minikube/pkg/minikube/reason"
 	"k8s.io/minikube/pkg/minikube/style"
 	"k8s.io/minikube/pkg/minikube/sysinit"
+	"k8s.io/minikube/pkg/trace"
 	"k8s.io/minikube/pkg/util"
 	"k8s.io/minikube/pkg/util/retry"
 )
@@ -176,13 +178,44 @@ func Set(cc *config.ClusterConfig, name string, value string) error {
 	return a.set(cc, name, value)
 }
 
-// SetAndSave sets a value and saves the config
+// SetAndSave sets a value and saves the config, enabling the missing dependencies of an addon first
 func SetAndSave(profile string, name string, value string) error {
 	cc, err := config.Load(profile)
 	if err != nil {
 		return errors.Wrap(err, "loading profile")
 	}
 
+	if enable, err := strconv.ParseBool(value); err == nil {
+		if err := checkDependencies(cc, name, enable); err != nil {
+			return err
+		}
+		if missing := missingDependencies(cc, name); enable && len(missing) > 0 {
+			// the dependencies are transitive, so each of them is enabled after its own dependencies without recursing
+			for _, batch := range enableOrder(missing) {
+				for _, dep := range batch {
+					out.Step(style.AddonEnable, "Enabling the '{{.dep}}' addon, which '{{.name}}' depends on", out.V{"dep": dep, "name": name})
+					if err := setAndSave(profile, dep, "true"); err != nil && !errors.Is(err, ErrSkipThisAddon) {
+						return errors.Wrapf(err, "enable dependency %s", dep)
+					}
+				}
+			}
+		}
+	}
+	return setAndSave(profile, name, value)
+}
+
+// setAndSave sets a value and saves the config, leaving the dependencies of an addon alone
+func setAndSave(profile string, name string, value string) error {
+	cc, err := config.Load(profile)
+	if err != nil {
+		return errors.Wrap(err, "loading profile")
+	}
+	if enable, err := strconv.ParseBool(value); err == nil {
+		if err := checkDependencies(cc, name, enable); err != nil {
+			return err
+		}
+	}
+
 	if err := RunCallbacks(cc, name, value); err != nil {
 		if errors.Is(err, ErrSkipThisAddon) {
 			return err
@@ -195,7 +228,17 @@ func SetAndSave(profile string, name string, value string) error {
 	}
 
 	klog.Infof("Writing out %q config to set %s=%v...", profile, name, value)
-	return config.Write(profile, cc)
+	if err := config.Write(profile, cc); err != nil {
+		return err
+	}
+	if enable, err := strconv.ParseBool(value); err == nil {
+		kind, verb := journal.AddonDisabled, "disabled"
+		if enable {
+			kind, verb = journal.AddonEnabled, "enabled"
+		}
+		journal.Record(profile, kind, fmt.Sprintf("addon %s was %s", name, verb), map[string]string{"addon": name})
+	}
+	return nil
 }
 
 // Runs all the validation or callback functions and collects errors
@@ -303,6 +346,9 @@ func EnableOrDisableAddon(cc *config.ClusterConfig, name string, val string) err
 	}
 
 	data := assets.GenerateTemplateData(addon, cc, networkInfo, images, customRegistries, enable)
+	if err, ok := data.(error); ok {
+		return err
+	}
 	return enableOrDisableAddonInternal(cc, addon, runner, data, enable)
 }
 
@@ -503,6 +549,10 @@ func verifyAddonStatusInternal(cc *config.ClusterConfig, name string, val string
 func Enable(wg *sync.WaitGroup, cc *config.ClusterConfig, toEnable map[string]bool, enabled chan<- []string) {
 	defer wg.Done()
 
+	// Enable runs concurrently with the rest of the start, and so do the addons it enables
+	span := trace.StartAsync("addons.Enable")
+	defer span.End()
+
 	start := time.Now()
 	klog.Infof("enable addons start: toEnable=%v", toEnable)
 	var enabledAddons []string
@@ -510,42 +560,53 @@ func Enable(wg *sync.WaitGroup, cc *config.ClusterConfig, toEnable map[string]bo
 		klog.Infof("duration metric: took %s for enable addons: enabled=%v", time.Since(start), enabledAddons)
 	}()
 
-	toEnableList := []string{}
-	for k, v := range toEnable {
-		if v {
-			toEnableList = append(toEnableList, k)
-		}
-	}
-	sort.Strings(toEnableList)
-
 	var awg sync.WaitGroup
+	var mu sync.Mutex
+	failed := map[string]bool{}
 
 	defer func() { // making it show after verifications (see #7613)
 		register.Reg.SetStep(register.EnablingAddons)
 		out.Step(style.AddonEnable, "Enabled addons: {{.addons}}", out.V{"addons": strings.Join(enabledAddons, ", ")})
 	}()
-	for _, a := range toEnableList {
-		awg.Add(1)
-		go func(name string) {
-			err := RunCallbacks(cc, name, "true")
-			if err != nil && !errors.Is(err, ErrSkipThisAddon) {
-				out.WarningT("Enabling '{{.name}}' returned an error: {{.error}}", out.V{"name": name, "error": err})
-			} else {
-				enabledAddons = append(enabledAddons, name)
+	// addons are enabled concurrently, once the addons they depend on are enabled
+	for _, batch := range enableOrder(sortedEnabled(toEnable)) {
+		for _, a := range batch {
+			mu.Lock()
+			dep := failedDependency(a, failed)
+			if dep != "" {
+				failed[a] = true
 			}
-			awg.Done()
-		}(a)
-	}
+			mu.Unlock()
+			if dep != "" {
+				out.WarningT("Skipping the '{{.name}}' addon, the '{{.dep}}' addon it depends on failed", out.V{"name": a, "dep": dep})
+				continue
+			}
+			awg.Add(1)
+			go func(name string) {
+				defer span.Child("addons.RunCallbacks", attribute.String("addon", name)).End()
+				err := RunCallbacks(cc, name, "true")
+				mu.Lock()
+				if err != nil && !errors.Is(err, ErrSkipThisAddon) {
+					out.WarningT("Enabling '{{.name}}' returned an error: {{.error}}", out.V{"name": name, "error": err})
+					failed[name] = true
+				} else {
+					enabledAddons = append(enabledAddons, name)
+				}
+				mu.Unlock()
+				awg.Done()
+			}(a)
+		}
 
-	// Wait until all of the addons are enabled
-	awg.Wait()
+		// Wait until all of the addons of the batch are enabled
+		awg.Wait()
+	}
 
 	// send the slice of all successfully enabled addons to channel and close
 	enabled <- enabledAddons
 	close(enabled)
 }
 
-// ToEnable returns the final list of addons to enable (not thread-safe).
+// ToEnable returns the final list of addons to enable along with their dependencies, without conflicting addons (not thread-safe).
 func ToEnable(cc *config.ClusterConfig, existing map[string]bool, additional []string) map[string]bool {
 	// start from existing
 	enable := map[string]bool{}
@@ -576,6 +637,7 @@ func ToEnable(cc *config.ClusterConfig, existing map[string]bool, additional []s
 		}
 	}
 
+	resolveDependencies(enable, existing)
 	return enable
 }
 
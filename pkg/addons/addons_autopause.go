@@ -42,10 +42,16 @@ func enableOrDisableAutoPause(cc *config.ClusterConfig, name, val string) error
 
 	co := mustload.Running(cc.Name)
 	if enable {
-		if err := sysinit.New(co.CP.Runner).EnableNow("auto-pause"); err != nil {
+		sm := sysinit.New(co.CP.Runner)
+		if err := sm.Enable("auto-pause"); err != nil {
 			klog.ErrorS(err, "failed to enable", "service", "auto-pause")
 			return err
 		}
+		// restart rather than start, so that a running daemon picks up a changed policy
+		if err := sm.Restart("auto-pause"); err != nil {
+			klog.ErrorS(err, "failed to restart", "service", "auto-pause")
+			return err
+		}
 	}
 
 	port := co.CP.Port // API server port
@@ -28,6 +28,10 @@ type Addon struct {
 	set         func(*config.ClusterConfig, string, string) error
 	validations []setFn
 	callbacks   []setFn
+	// dependencies are the addons enabled before this one, which cannot be disabled while it is enabled
+	dependencies []string
+	// conflicts are the addons that cannot be enabled along with this one
+	conflicts []string
 }
 
 // addonPodLabels holds the pod label that will be used to verify if the addon is enabled
@@ -80,9 +84,10 @@ var Addons = []*Addon{
 		callbacks: []setFn{EnableOrDisableAddon, verifyAddonStatus},
 	},
 	{
-		name:      "ingress-dns",
-		set:       SetBool,
-		callbacks: []setFn{EnableOrDisableAddon},
+		name:         "ingress-dns",
+		set:          SetBool,
+		callbacks:    []setFn{EnableOrDisableAddon},
+		dependencies: []string{"ingress"},
 	},
 	{
 		name:      "istio-provisioner",
@@ -147,11 +152,11 @@ var Addons = []*Addon{
 		callbacks: []setFn{EnableOrDisableAddon},
 	},
 	{
-		name:      "registry-aliases",
-		set:       SetBool,
-		callbacks: []setFn{EnableOrDisableAddon},
+		name:         "registry-aliases",
+		set:          SetBool,
+		callbacks:    []setFn{EnableOrDisableAddon},
+		dependencies: []string{"registry"},
 		// TODO - add other settings
-		// TODO check if registry addon is enabled
 	},
 	{
 		name:      "storage-provisioner",
@@ -162,6 +167,8 @@ var Addons = []*Addon{
 		name:      "storage-provisioner-gluster",
 		set:       SetBool,
 		callbacks: []setFn{enableOrDisableStorageClasses},
+		// both make their storage class the default one
+		conflicts: []string{"storage-provisioner-rancher"},
 	},
 	{
 		name:      "storage-provisioner-rancher",
@@ -199,10 +206,10 @@ var Addons = []*Addon{
 		callbacks: []setFn{EnableOrDisableAddon},
 	},
 	{
-		name:        "csi-hostpath-driver",
-		set:         SetBool,
-		validations: []setFn{isVolumesnapshotsEnabled},
-		callbacks:   []setFn{EnableOrDisableAddon, verifyAddonStatus},
+		name:         "csi-hostpath-driver",
+		set:          SetBool,
+		callbacks:    []setFn{EnableOrDisableAddon, verifyAddonStatus},
+		dependencies: []string{"volumesnapshots"},
 	},
 	{
 		name:      "portainer",
@@ -233,6 +240,8 @@ var Addons = []*Addon{
 		name:      "nvidia-device-plugin",
 		set:       SetBool,
 		callbacks: []setFn{EnableOrDisableAddon},
+		// both advertise the nvidia.com/gpu resource
+		conflicts: []string{"nvidia-gpu-device-plugin"},
 	},
 	{
 		name:      "yakd",
@@ -0,0 +1,220 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/out"
+)
+
+// dependencies returns the addons an addon depends on, transitively, in the order to enable them
+func dependencies(name string) []string {
+	var deps []string
+	seen := map[string]bool{name: true}
+	var visit func(string)
+	visit = func(n string) {
+		a, ok := isAddonValid(n)
+		if !ok {
+			return
+		}
+		for _, d := range a.dependencies {
+			if seen[d] {
+				continue
+			}
+			seen[d] = true
+			visit(d)
+			deps = append(deps, d)
+		}
+	}
+	visit(name)
+	return deps
+}
+
+// missingDependencies returns the dependencies of an addon that are not enabled in a cluster, in the order to enable them
+func missingDependencies(cc *config.ClusterConfig, name string) []string {
+	var missing []string
+	for _, dep := range dependencies(name) {
+		if !cc.Addons[dep] {
+			missing = append(missing, dep)
+		}
+	}
+	return missing
+}
+
+// failedDependency returns the first dependency of an addon that failed, or ""
+func failedDependency(name string, failed map[string]bool) string {
+	for _, dep := range dependencies(name) {
+		if failed[dep] {
+			return dep
+		}
+	}
+	return ""
+}
+
+// conflicting returns whether two addons conflict, whichever of them declares it
+func conflicting(a, b string) bool {
+	for _, pair := range [][2]string{{a, b}, {b, a}} {
+		if addon, ok := isAddonValid(pair[0]); ok && contains(addon.conflicts, pair[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// dependents returns the enabled addons of a cluster that directly depend on an addon, sorted
+func dependents(cc *config.ClusterConfig, name string) []string {
+	var deps []string
+	for _, a := range Addons {
+		if cc.Addons[a.name] && contains(a.dependencies, name) {
+			deps = append(deps, a.name)
+		}
+	}
+	sort.Strings(deps)
+	return deps
+}
+
+// checkDependencies returns an error if enabling an addon conflicts with an enabled addon,
+// or if disabling it breaks enabled addons depending on it, unless forced
+func checkDependencies(cc *config.ClusterConfig, name string, enable bool) error {
+	if enable {
+		for other, enabled := range cc.Addons {
+			if enabled && conflicting(name, other) {
+				return fmt.Errorf("the %s addon conflicts with the enabled %s addon, disable it first", name, other)
+			}
+		}
+		return nil
+	}
+	if deps := dependents(cc, name); len(deps) > 0 && !Force {
+		return fmt.Errorf("the enabled %s addons depend on the %s addon, disable them first or use --force", strings.Join(deps, ", "), name)
+	}
+	return nil
+}
+
+// resolveDependencies adds the dependencies of the addons to enable, then drops the addons conflicting with
+// an addon kept before them, the existing ones first, along with the addons depending on the dropped ones
+func resolveDependencies(enable map[string]bool, existing map[string]bool) {
+	for _, name := range sortedEnabled(enable) {
+		for _, dep := range dependencies(name) {
+			if !enable[dep] {
+				klog.Infof("enabling %s, which %s depends on", dep, name)
+				enable[dep] = true
+			}
+		}
+	}
+
+	order := sortedEnabled(enable)
+	sort.SliceStable(order, func(i, j int) bool { return existing[order[i]] && !existing[order[j]] })
+	var kept []string
+	for _, name := range order {
+		if other := conflictingWith(name, kept); other != "" {
+			out.WarningT("Not enabling the '{{.name}}' addon, it conflicts with the '{{.other}}' addon", out.V{"name": name, "other": other})
+			enable[name] = false
+			continue
+		}
+		kept = append(kept, name)
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for _, name := range sortedEnabled(enable) {
+			for _, dep := range dependencies(name) {
+				if !enable[dep] {
+					out.WarningT("Not enabling the '{{.name}}' addon, it depends on the '{{.dep}}' addon", out.V{"name": name, "dep": dep})
+					enable[name] = false
+					changed = true
+					break
+				}
+			}
+		}
+	}
+}
+
+// conflictingWith returns the first addon of others conflicting with an addon, or ""
+func conflictingWith(name string, others []string) string {
+	for _, o := range others {
+		if conflicting(name, o) {
+			return o
+		}
+	}
+	return ""
+}
+
+// sortedEnabled returns the enabled addons of a map, sorted
+func sortedEnabled(m map[string]bool) []string {
+	var names []string
+	for name, enabled := range m {
+		if enabled {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EnabledAddons returns the enabled addons of the cluster, each after the addons it depends on
+func EnabledAddons(cc *config.ClusterConfig) []string {
+	var names []string
+	for _, batch := range enableOrder(sortedEnabled(cc.Addons)) {
+		names = append(names, batch...)
+	}
+	return names
+}
+
+// enableOrder splits addons into batches enabled one after the other, each batch only depending on the addons
+// of the batches before it, so that the addons of a batch can be enabled concurrently.
+// The addons of a dependency cycle end up together in the last batch.
+func enableOrder(names []string) [][]string {
+	pending := map[string]bool{}
+	for _, name := range names {
+		pending[name] = true
+	}
+
+	var batches [][]string
+	for len(pending) > 0 {
+		var batch []string
+		for _, name := range sortedEnabled(pending) {
+			if a, ok := isAddonValid(name); ok && pendingDependency(a, pending) {
+				continue
+			}
+			batch = append(batch, name)
+		}
+		if len(batch) == 0 {
+			batch = sortedEnabled(pending)
+			klog.Warningf("addon dependency cycle between %v", batch)
+		}
+		for _, name := range batch {
+			delete(pending, name)
+		}
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// pendingDependency returns whether an addon depends on a pending addon
+func pendingDependency(a *Addon, pending map[string]bool) bool {
+	for _, d := range a.dependencies {
+		if pending[d] {
+			return true
+		}
+	}
+	return false
+}
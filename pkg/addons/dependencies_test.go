@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestAddonDependencies(t *testing.T) {
+	var names []string
+	for _, a := range Addons {
+		names = append(names, a.name)
+		for _, d := range append(append([]string{}, a.dependencies...), a.conflicts...) {
+			if _, ok := isAddonValid(d); !ok {
+				t.Errorf("addon %s refers to the unknown addon %s", a.name, d)
+			}
+		}
+	}
+
+	// every addon is enabled after its dependencies, so there are no cycles
+	batch := map[string]int{}
+	for i, b := range enableOrder(names) {
+		for _, name := range b {
+			batch[name] = i
+		}
+	}
+	for _, name := range names {
+		for _, d := range dependencies(name) {
+			if batch[d] >= batch[name] {
+				t.Errorf("addon %s is enabled in batch %d, not after its dependency %s in batch %d", name, batch[name], d, batch[d])
+			}
+		}
+	}
+}
+
+func TestEnableOrder(t *testing.T) {
+	got := enableOrder([]string{"ingress-dns", "csi-hostpath-driver", "dashboard", "ingress", "volumesnapshots"})
+	want := [][]string{{"dashboard", "ingress", "volumesnapshots"}, {"csi-hostpath-driver", "ingress-dns"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("enableOrder() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestResolveDependencies(t *testing.T) {
+	tests := []struct {
+		description string
+		enable      map[string]bool
+		existing    map[string]bool
+		want        []string
+	}{
+		{
+			description: "dependencies are added",
+			enable:      map[string]bool{"registry-aliases": true, "csi-hostpath-driver": true, "volumesnapshots": false},
+			want:        []string{"csi-hostpath-driver", "registry", "registry-aliases", "volumesnapshots"},
+		},
+		{
+			description: "existing addons win conflicts",
+			enable:      map[string]bool{"storage-provisioner-gluster": true, "storage-provisioner-rancher": true},
+			existing:    map[string]bool{"storage-provisioner-rancher": true},
+			want:        []string{"storage-provisioner-rancher"},
+		},
+		{
+			description: "new conflicting addons are dropped in order",
+			enable:      map[string]bool{"nvidia-device-plugin": true, "nvidia-gpu-device-plugin": true, "dashboard": true},
+			want:        []string{"dashboard", "nvidia-device-plugin"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			resolveDependencies(tc.enable, tc.existing)
+			if diff := cmp.Diff(tc.want, sortedEnabled(tc.enable)); diff != "" {
+				t.Errorf("resolveDependencies() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckDependencies(t *testing.T) {
+	defer func(force bool) { Force = force }(Force)
+	cc := &config.ClusterConfig{Addons: map[string]bool{"ingress": true, "ingress-dns": true, "storage-provisioner-rancher": true}}
+
+	if err := checkDependencies(cc, "storage-provisioner-gluster", true); err == nil {
+		t.Errorf("enabling a conflicting addon should fail")
+	}
+	if err := checkDependencies(cc, "registry", true); err != nil {
+		t.Errorf("enabling registry: %v", err)
+	}
+	if err := checkDependencies(cc, "ingress", false); err == nil {
+		t.Errorf("disabling an addon enabled addons depend on should fail")
+	}
+	if err := checkDependencies(cc, "ingress-dns", false); err != nil {
+		t.Errorf("disabling ingress-dns: %v", err)
+	}
+	Force = true
+	if err := checkDependencies(cc, "ingress", false); err != nil {
+		t.Errorf("disabling ingress with force: %v", err)
+	}
+
+	if got, want := missingDependencies(&config.ClusterConfig{}, "registry-aliases"), []string{"registry"}; !cmp.Equal(got, want) {
+		t.Errorf("missingDependencies(registry-aliases) = %v, want %v", got, want)
+	}
+}
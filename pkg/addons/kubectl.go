@@ -29,19 +29,12 @@ import (
 )
 
 func kubectlCommand(ctx context.Context, cc *config.ClusterConfig, files []string, enable, force bool) *exec.Cmd {
-	v := constants.DefaultKubernetesVersion
-	if cc != nil {
-		v = cc.KubernetesConfig.KubernetesVersion
-	}
-
-	kubectlBinary := kapi.KubectlBinaryPath(v)
-
 	kubectlAction := "apply"
 	if !enable {
 		kubectlAction = "delete"
 	}
 
-	args := []string{fmt.Sprintf("KUBECONFIG=%s", path.Join(vmpath.GuestPersistentDir, "kubeconfig")), kubectlBinary, kubectlAction}
+	args := []string{kubectlAction}
 	if force {
 		args = append(args, "--force")
 	}
@@ -54,5 +47,18 @@ func kubectlCommand(ctx context.Context, cc *config.ClusterConfig, files []strin
 		args = append(args, []string{"-f", f}...)
 	}
 
+	return kubectl(ctx, cc, args...)
+}
+
+// kubectl returns a command running kubectl on the control plane with the given arguments
+func kubectl(ctx context.Context, cc *config.ClusterConfig, args ...string) *exec.Cmd {
+	v := constants.DefaultKubernetesVersion
+	if cc != nil {
+		v = cc.KubernetesConfig.KubernetesVersion
+	}
+
+	kubectlBinary := kapi.KubectlBinaryPath(v)
+
+	args = append([]string{fmt.Sprintf("KUBECONFIG=%s", path.Join(vmpath.GuestPersistentDir, "kubeconfig")), kubectlBinary}, args...)
 	return exec.CommandContext(ctx, "sudo", args...)
 }
@@ -0,0 +1,326 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+)
+
+// renderAddon returns the assets of the addon rendered for the cluster as enabling it would, without persisting anything
+func renderAddon(cc *config.ClusterConfig, addon *assets.Addon) ([]assets.CopyableFile, error) {
+	images, customRegistries, err := assets.SelectAndPersistImages(addon, cc)
+	if err != nil {
+		return nil, errors.Wrap(err, "selecting images")
+	}
+	if cc.KubernetesConfig.ImageRepository == constants.AliyunMirror {
+		images, customRegistries = assets.FixAddonImagesAndRegistries(addon, images, customRegistries)
+	}
+
+	var networkInfo assets.NetworkInfo
+	if len(cc.Nodes) >= 1 {
+		networkInfo.ControlPlaneNodeIP = cc.Nodes[0].IP
+		networkInfo.ControlPlaneNodePort = cc.Nodes[0].Port
+	}
+	data := assets.GenerateTemplateData(addon, cc, networkInfo, images, customRegistries, false)
+	if err, ok := data.(error); ok {
+		return nil, err
+	}
+
+	var files []assets.CopyableFile
+	for _, a := range addon.Assets {
+		if !a.IsTemplate() {
+			files = append(files, a)
+			continue
+		}
+		f, err := a.Evaluate(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "evaluate bundled addon %s asset", a.GetSourcePath())
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// isManifest returns whether an addon asset is a Kubernetes manifest applied when the addon is enabled
+func isManifest(f assets.CopyableFile) bool {
+	return strings.HasSuffix(f.GetTargetName(), ".yaml")
+}
+
+// manifestPath returns the path of an addon manifest on the control plane
+func manifestPath(f assets.CopyableFile) string {
+	return path.Join(f.GetTargetDir(), f.GetTargetName())
+}
+
+// readAsset returns the contents of an asset, leaving it ready to be copied
+func readAsset(f assets.CopyableFile) ([]byte, error) {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	_, err = f.Seek(0, io.SeekStart)
+	return b, err
+}
+
+// manifestObjects returns the objects of the manifests among the rendered assets of an addon, and the manifests joined
+// into a single multi-document YAML
+func manifestObjects(files []assets.CopyableFile) ([]*unstructured.Unstructured, []byte, error) {
+	var objs []*unstructured.Unstructured
+	var all bytes.Buffer
+	for _, f := range files {
+		if !isManifest(f) {
+			continue
+		}
+		b, err := readAsset(f)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "reading %s", f.GetTargetName())
+		}
+		o, err := decodeManifest(b)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "decoding %s", f.GetTargetName())
+		}
+		objs = append(objs, o...)
+		fmt.Fprintf(&all, "---\n%s\n", b)
+	}
+	return objs, all.Bytes(), nil
+}
+
+// decodeManifest returns the objects of a YAML or JSON manifest of one or more documents, flattening lists
+func decodeManifest(b []byte) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	d := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(b), 4096)
+	for {
+		var raw runtime.RawExtension
+		if err := d.Decode(&raw); err != nil {
+			if err == io.EOF {
+				return objs, nil
+			}
+			return nil, err
+		}
+		raw.Raw = bytes.TrimSpace(raw.Raw)
+		if len(raw.Raw) == 0 || bytes.Equal(raw.Raw, []byte("null")) {
+			continue
+		}
+		obj, _, err := unstructured.UnstructuredJSONScheme.Decode(raw.Raw, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		switch o := obj.(type) {
+		case *unstructured.Unstructured:
+			objs = append(objs, o)
+		case *unstructured.UnstructuredList:
+			for i := range o.Items {
+				objs = append(objs, &o.Items[i])
+			}
+		}
+	}
+}
+
+// objectRef returns the reference of an object for kubectl, like Deployment.v1.apps/name
+func objectRef(o *unstructured.Unstructured) string {
+	gvk := o.GroupVersionKind()
+	kind := gvk.Kind
+	if gvk.Group != "" {
+		kind = fmt.Sprintf("%s.%s.%s", gvk.Kind, gvk.Version, gvk.Group)
+	}
+	return kind + "/" + o.GetName()
+}
+
+// objectName returns the name of an object to show, like Deployment/ingress-nginx/ingress-nginx-controller
+func objectName(o *unstructured.Unstructured) string {
+	if o.GetNamespace() == "" {
+		return o.GetKind() + "/" + o.GetName()
+	}
+	return o.GetKind() + "/" + o.GetNamespace() + "/" + o.GetName()
+}
+
+// sameObject returns whether two objects are the same, objects without a namespace, like the ones of manifests
+// applied to the default namespace, matching any namespace
+func sameObject(a, b *unstructured.Unstructured) bool {
+	agvk, bgvk := a.GroupVersionKind(), b.GroupVersionKind()
+	if agvk.Group != bgvk.Group || agvk.Kind != bgvk.Kind || a.GetName() != b.GetName() {
+		return false
+	}
+	return a.GetNamespace() == "" || b.GetNamespace() == "" || a.GetNamespace() == b.GetNamespace()
+}
+
+// findObject returns the object of objs which is the same as o, or nil
+func findObject(o *unstructured.Unstructured, objs []*unstructured.Unstructured) *unstructured.Unstructured {
+	for _, obj := range objs {
+		if sameObject(o, obj) {
+			return obj
+		}
+	}
+	return nil
+}
+
+// drift returns the paths of the fields set by a manifest whose value differs in the cluster. Fields added by the
+// cluster are ignored, and unset fields match the zero values of the manifest.
+func drift(want, got interface{}, field string) []string {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			if got == nil && len(w) == 0 {
+				return nil
+			}
+			return []string{field}
+		}
+		var fields []string
+		keys := make([]string, 0, len(w))
+		for k := range w {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if field == "" && k == "status" {
+				continue
+			}
+			f := k
+			if field != "" {
+				f = field + "." + k
+			}
+			fields = append(fields, drift(w[k], g[k], f)...)
+		}
+		return fields
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok {
+			if got == nil && len(w) == 0 {
+				return nil
+			}
+			return []string{field}
+		}
+		if len(g) != len(w) {
+			return []string{field}
+		}
+		var fields []string
+		for i := range w {
+			fields = append(fields, drift(w[i], g[i], fmt.Sprintf("%s[%d]", field, i))...)
+		}
+		return fields
+	}
+	if got == nil {
+		if isZero(want) {
+			return nil
+		}
+		return []string{field}
+	}
+	if fmt.Sprint(want) == fmt.Sprint(got) || sameQuantity(want, got) {
+		return nil
+	}
+	return []string{field}
+}
+
+// isZero returns whether a scalar of a manifest is its zero value, which the API server may drop
+func isZero(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case int64:
+		return v == 0
+	case float64:
+		return v == 0
+	}
+	return false
+}
+
+// sameQuantity returns whether both values are the same resource quantity, like 0.5 and 500m
+func sameQuantity(a, b interface{}) bool {
+	qa, err := resource.ParseQuantity(fmt.Sprint(a))
+	if err != nil {
+		return false
+	}
+	qb, err := resource.ParseQuantity(fmt.Sprint(b))
+	if err != nil {
+		return false
+	}
+	return qa.Cmp(qb) == 0
+}
+
+// podSpecFields returns the path of the pod spec of a workload, or nil if the object has no pods
+func podSpecFields(o *unstructured.Unstructured) []string {
+	switch o.GetKind() {
+	case "Deployment", "DaemonSet", "StatefulSet", "ReplicaSet", "Job":
+		return []string{"spec", "template", "spec"}
+	case "CronJob":
+		return []string{"spec", "jobTemplate", "spec", "template", "spec"}
+	case "Pod":
+		return []string{"spec"}
+	}
+	return nil
+}
+
+// containerImages returns the images of the containers of a workload by container name
+func containerImages(o *unstructured.Unstructured) map[string]string {
+	fields := podSpecFields(o)
+	if fields == nil {
+		return nil
+	}
+	images := map[string]string{}
+	for _, kind := range []string{"initContainers", "containers"} {
+		cs, _, _ := unstructured.NestedSlice(o.Object, append(fields, kind)...)
+		for _, c := range cs {
+			m, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _, _ := unstructured.NestedString(m, "name")
+			image, _, _ := unstructured.NestedString(m, "image")
+			images[name] = image
+		}
+	}
+	return images
+}
+
+// workloadReady returns whether a workload of the cluster runs all its replicas, and false for other objects
+func workloadReady(o *unstructured.Unstructured) (ready bool, workload bool) {
+	switch o.GetKind() {
+	case "Deployment", "StatefulSet", "ReplicaSet":
+		replicas, found, _ := unstructured.NestedInt64(o.Object, "spec", "replicas")
+		if !found {
+			replicas = 1
+		}
+		readyReplicas, _, _ := unstructured.NestedInt64(o.Object, "status", "readyReplicas")
+		return readyReplicas >= replicas, true
+	case "DaemonSet":
+		desired, _, _ := unstructured.NestedInt64(o.Object, "status", "desiredNumberScheduled")
+		numberReady, _, _ := unstructured.NestedInt64(o.Object, "status", "numberReady")
+		return numberReady >= desired, true
+	}
+	return false, false
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/version"
+)
+
+const testManifest = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: mesh-system
+---
+# empty documents are skipped
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: operator
+  namespace: mesh-system
+  labels:
+    app: operator
+spec:
+  replicas: 2
+  template:
+    spec:
+      hostNetwork: false
+      containers:
+      - name: operator
+        image: example/operator:v2@sha256:abc
+        resources:
+          requests:
+            cpu: 0.5
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: settings
+    namespace: mesh-system
+- apiVersion: batch/v1
+  kind: Job
+  metadata:
+    name: migrate
+    namespace: mesh-system
+`
+
+// mustDecode decodes a manifest or fails the test
+func mustDecode(t *testing.T, manifest string) []*unstructured.Unstructured {
+	t.Helper()
+	objs, err := decodeManifest([]byte(manifest))
+	if err != nil {
+		t.Fatalf("decodeManifest: %v", err)
+	}
+	return objs
+}
+
+func TestDecodeManifest(t *testing.T) {
+	var got []string
+	for _, o := range mustDecode(t, testManifest) {
+		got = append(got, objectRef(o))
+	}
+	want := []string{"Namespace/mesh-system", "Deployment.v1.apps/operator", "ConfigMap/settings", "Job.v1.batch/migrate"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("decodeManifest mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDrift(t *testing.T) {
+	manifest := mustDecode(t, testManifest)[1]
+	tests := []struct {
+		description string
+		live        string
+		want        []string
+	}{
+		{
+			description: "fields added by the cluster",
+			live: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: operator
+  namespace: mesh-system
+  uid: 1234
+  labels:
+    app: operator
+    extra: label
+spec:
+  replicas: 2
+  strategy:
+    type: RollingUpdate
+  template:
+    spec:
+      containers:
+      - name: operator
+        image: example/operator:v2@sha256:abc
+        imagePullPolicy: IfNotPresent
+        resources:
+          requests:
+            cpu: 500m
+status:
+  readyReplicas: 2
+`,
+		},
+		{
+			description: "changed fields",
+			live: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: operator
+  namespace: mesh-system
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: operator
+        image: example/operator:v1
+      - name: sidecar
+        image: example/sidecar:v1
+`,
+			want: []string{"metadata.labels", "spec.replicas", "spec.template.spec.containers"},
+		},
+		{
+			description: "changed container",
+			live: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: operator
+  namespace: mesh-system
+  labels:
+    app: other
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: operator
+        image: example/operator:v1
+`,
+			want: []string{"metadata.labels.app", "spec.template.spec.containers[0].image", "spec.template.spec.containers[0].resources"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			live := mustDecode(t, tc.live)[0]
+			if diff := cmp.Diff(tc.want, drift(manifest.Object, live.Object, "")); diff != "" {
+				t.Errorf("drift mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCompareObjects(t *testing.T) {
+	manifests := mustDecode(t, testManifest)
+	live := mustDecode(t, `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Namespace
+  metadata:
+    name: mesh-system
+- apiVersion: apps/v1
+  kind: Deployment
+  metadata:
+    name: operator
+    namespace: mesh-system
+    labels:
+      app: operator
+  spec:
+    replicas: 2
+    template:
+      spec:
+        containers:
+        - name: operator
+          image: example/operator:v1
+          resources:
+            requests:
+              cpu: 500m
+  status:
+    readyReplicas: 1
+`)
+	got := compareObjects("mesh", manifests, live)
+	want := &Status{
+		Name:           "mesh",
+		Workloads:      1,
+		ReadyWorkloads: 0,
+		// the completed job may have been deleted
+		Missing: []string{"ConfigMap/mesh-system/settings"},
+		Images: []ImageStatus{
+			{Object: "Deployment/mesh-system/operator", Container: "operator", Expected: "example/operator:v2@sha256:abc", Running: "example/operator:v1", Outdated: true},
+		},
+		Drift: []string{"Deployment/mesh-system/operator: spec.template.spec.containers[0].image"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("compareObjects mismatch (-want +got):\n%s", diff)
+	}
+	if len(got.OutdatedImages()) != 1 {
+		t.Errorf("OutdatedImages() = %v, want the operator", got.OutdatedImages())
+	}
+}
+
+func TestWorkloadReady(t *testing.T) {
+	tests := []struct {
+		manifest string
+		ready    bool
+		workload bool
+	}{
+		{"kind: Deployment\nspec:\n  replicas: 2\nstatus:\n  readyReplicas: 2\n", true, true},
+		{"kind: Deployment\nstatus:\n  readyReplicas: 0\n", false, true},
+		{"kind: DaemonSet\nstatus:\n  desiredNumberScheduled: 1\n  numberReady: 1\n", true, true},
+		{"kind: StatefulSet\nspec:\n  replicas: 3\nstatus:\n  readyReplicas: 2\n", false, true},
+		{"kind: ConfigMap\n", false, false},
+	}
+	for _, tc := range tests {
+		o := mustDecode(t, "apiVersion: v1\nmetadata:\n  name: w\n"+tc.manifest)[0]
+		ready, workload := workloadReady(o)
+		if ready != tc.ready || workload != tc.workload {
+			t.Errorf("workloadReady(%q) = %v, %v, want %v, %v", tc.manifest, ready, workload, tc.ready, tc.workload)
+		}
+	}
+}
+
+func TestRemovedObjects(t *testing.T) {
+	previous := mustDecode(t, testManifest)
+	current := mustDecode(t, `apiVersion: v1
+kind: Namespace
+metadata:
+  name: mesh-system
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: operator
+`)
+	var got []string
+	for _, o := range removedObjects(previous, current) {
+		got = append(got, objectName(o))
+	}
+	want := []string{"ConfigMap/mesh-system/settings", "Job/mesh-system/migrate"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("removedObjects mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRenderBuiltinAddons(t *testing.T) {
+	cc := &config.ClusterConfig{
+		KubernetesConfig: config.KubernetesConfig{KubernetesVersion: constants.DefaultKubernetesVersion, ContainerRuntime: constants.Docker},
+		Nodes:            []config.Node{{IP: "192.168.49.2", Port: 8443, ControlPlane: true}},
+	}
+	for name, addon := range assets.Addons {
+		if IsPlugin(name) {
+			continue
+		}
+		if name == "storage-provisioner" && version.GetStorageProvisionerVersion() == "" {
+			// the tag of its image is set when linking minikube
+			continue
+		}
+		files, err := renderAddon(cc, addon)
+		if err != nil {
+			t.Errorf("rendering %s: %v", name, err)
+			continue
+		}
+		if _, _, err := manifestObjects(files); err != nil {
+			t.Errorf("decoding the manifests of %s: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+const (
+	// ociPrefix marks an OCI artifact source, like oci://ghcr.io/org/addon:v1
+	ociPrefix = "oci://"
+	// gitPrefix marks a Git repository source that does not end in .git, like git::https://example.com/addon
+	gitPrefix = "git::"
+)
+
+// Install installs the plugin of a source, replacing any installed version of it, and returns the installed plugin.
+// The source is a local directory, an OCI artifact as oci://REFERENCE whose layers hold the plugin directory,
+// or a Git repository URL ending in .git or prefixed with git::, optionally followed by #BRANCH_OR_TAG.
+func Install(src string) (*Plugin, error) {
+	dir, cleanup, err := fetch(src)
+	defer cleanup()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := Load(dir)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.Assets(); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(localpath.AddonPlugins(), 0755); err != nil {
+		return nil, err
+	}
+	dst := filepath.Join(localpath.AddonPlugins(), p.Name)
+	tmp := filepath.Join(localpath.AddonPlugins(), "."+p.Name+".new")
+	if err := os.RemoveAll(tmp); err != nil {
+		return nil, err
+	}
+	if err := copyDir(dir, tmp); err != nil {
+		os.RemoveAll(tmp)
+		return nil, errors.Wrap(err, "copy plugin")
+	}
+	if err := os.RemoveAll(dst); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		return nil, err
+	}
+	klog.Infof("installed addon plugin %s from %s to %s", p.Name, src, dst)
+	return Load(dst)
+}
+
+// Uninstall removes an installed plugin
+func Uninstall(name string) error {
+	dir := filepath.Join(localpath.AddonPlugins(), name)
+	if !nameRe.MatchString(name) {
+		return fmt.Errorf("invalid addon name %q", name)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ManifestFile)); err != nil {
+		return errors.Wrapf(err, "addon plugin %s is not installed", name)
+	}
+	return os.RemoveAll(dir)
+}
+
+// fetch returns the local directory of a source, and a function removing anything downloaded
+func fetch(src string) (string, func(), error) {
+	noop := func() {}
+	if !strings.HasPrefix(src, ociPrefix) && !isGit(src) {
+		fi, err := os.Stat(src)
+		if err != nil {
+			return "", noop, err
+		}
+		if !fi.IsDir() {
+			return "", noop, fmt.Errorf("%s is not a directory", src)
+		}
+		return src, noop, nil
+	}
+
+	tmp, err := os.MkdirTemp("", "addon-plugin")
+	if err != nil {
+		return "", noop, err
+	}
+	cleanup := func() { os.RemoveAll(tmp) }
+	if strings.HasPrefix(src, ociPrefix) {
+		return tmp, cleanup, pullOCI(strings.TrimPrefix(src, ociPrefix), tmp)
+	}
+	return tmp, cleanup, cloneGit(src, tmp)
+}
+
+// isGit returns whether a source is a Git repository
+func isGit(src string) bool {
+	url, _, _ := strings.Cut(src, "#")
+	return strings.HasPrefix(src, gitPrefix) || strings.HasSuffix(url, ".git")
+}
+
+// cloneGit clones the branch or tag of a Git source into dir
+func cloneGit(src, dir string) error {
+	url, ref, _ := strings.Cut(strings.TrimPrefix(src, gitPrefix), "#")
+	if _, err := exec.LookPath("git"); err != nil {
+		return errors.Wrap(err, "git is needed to install addons from Git repositories")
+	}
+	args := []string{"clone", "--depth", "1"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	args = append(args, url, dir)
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		return errors.Wrapf(err, "git clone %s: %s", url, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// pullOCI extracts the layers of an OCI artifact into dir
+func pullOCI(ref, dir string) error {
+	r, err := name.ParseReference(ref)
+	if err != nil {
+		return errors.Wrapf(err, "parsing reference %s", ref)
+	}
+	img, err := remote.Image(r, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return errors.Wrapf(err, "pulling %s", ref)
+	}
+	rc := mutate.Extract(img)
+	defer rc.Close()
+	return untar(rc, dir)
+}
+
+// untar extracts the directories and regular files of a tar stream into dir, rejecting paths outside of it
+func untar(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := strings.TrimPrefix(path.Clean("/"+hdr.Name), "/")
+		if name == "" {
+			continue
+		}
+		if !fs.ValidPath(name) {
+			return fmt.Errorf("invalid path %s in archive", hdr.Name)
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := writeFile(target, tr, hdr.FileInfo().Mode().Perm()); err != nil {
+				return err
+			}
+		default:
+			klog.Infof("skipping %s of type %c in archive", hdr.Name, hdr.Typeflag)
+		}
+	}
+}
+
+// copyDir copies the directories and regular files of src to dst, but the .git directory
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		switch {
+		case d.IsDir() && d.Name() == ".git":
+			return filepath.SkipDir
+		case d.IsDir():
+			return os.MkdirAll(target, 0755)
+		case !d.Type().IsRegular():
+			klog.Infof("skipping %s, not a regular file", p)
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return writeFile(target, f, fi.Mode().Perm())
+	})
+}
+
+// writeFile writes the contents of r to a new file
+func writeFile(name string, r io.Reader, perm os.FileMode) error {
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
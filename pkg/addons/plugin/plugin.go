@@ -0,0 +1,230 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugin loads and installs addon plugins: external addons defined by an addon.yaml manifest
+// next to their Kubernetes manifests, which behave like the built-in addons once installed.
+package plugin
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+)
+
+// ManifestFile is the name of the manifest of a plugin, at the root of its directory
+const ManifestFile = "addon.yaml"
+
+// nameRe matches the valid addon names, DNS labels
+var nameRe = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// Manifest is the addon.yaml of a plugin
+type Manifest struct {
+	// Name is the name of the addon, as used by minikube addons enable
+	Name string `yaml:"name"`
+	// Maintainer and VerifiedMaintainer are shown by minikube addons list and enable
+	Maintainer         string `yaml:"maintainer,omitempty"`
+	VerifiedMaintainer string `yaml:"verifiedMaintainer,omitempty"`
+	// Docs is a link to the documentation of the addon
+	Docs string `yaml:"docs,omitempty"`
+	// Images and Registries are the default images of the addon and their registries by image name, like for the built-in addons
+	Images     map[string]string `yaml:"images,omitempty"`
+	Registries map[string]string `yaml:"registries,omitempty"`
+	// Templates are the Kubernetes manifests of the addon relative to the plugin directory, applied in order.
+	// Files ending in .tmpl are rendered with the data of the built-in addon templates.
+	Templates []string `yaml:"templates"`
+	// Dependencies are the addons enabled before this one, Conflicts the addons that cannot be enabled along with it
+	Dependencies []string `yaml:"dependencies,omitempty"`
+	Conflicts    []string `yaml:"conflicts,omitempty"`
+	// Values configure the addon, they are rendered into the templates as .Values.<name>
+	Values []assets.Value `yaml:"values,omitempty"`
+	// Verify selects the pods to wait for when the addon is enabled
+	Verify *Verify `yaml:"verify,omitempty"`
+	// Hooks are run on the host when the addon is enabled or disabled
+	Hooks Hooks `yaml:"hooks,omitempty"`
+}
+
+// Verify selects the pods that must be running for the addon to be enabled
+type Verify struct {
+	// Namespace is the namespace of the pods, kube-system by default
+	Namespace string `yaml:"namespace,omitempty"`
+	// Label is the label selector of the pods, like app.kubernetes.io/name=operator
+	Label string `yaml:"label"`
+}
+
+// Hooks are executables of the plugin directory, run from it with the profile in $MINIKUBE_PROFILE
+type Hooks struct {
+	// Enable runs after the manifests are applied
+	Enable string `yaml:"enable,omitempty"`
+	// Disable runs before the manifests are deleted
+	Disable string `yaml:"disable,omitempty"`
+}
+
+// Plugin is an addon plugin found in a directory
+type Plugin struct {
+	Manifest
+	// Dir is the directory of the plugin
+	Dir string
+}
+
+// Load returns the plugin of a directory
+func Load(dir string) (*Plugin, error) {
+	b, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := yaml.UnmarshalStrict(b, &m); err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", filepath.Join(dir, ManifestFile))
+	}
+	p := &Plugin{Manifest: m, Dir: dir}
+	if err := p.validate(); err != nil {
+		return nil, errors.Wrapf(err, "%s", dir)
+	}
+	return p, nil
+}
+
+// LoadDir returns the plugins of the subdirectories of a directory, sorted by name.
+// A missing directory has no plugins, invalid plugins are skipped and reported as error.
+func LoadDir(dir string) ([]*Plugin, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var plugins []*Plugin
+	var errs []string
+	for _, de := range entries {
+		if !de.IsDir() || strings.HasPrefix(de.Name(), ".") {
+			continue
+		}
+		p, err := Load(filepath.Join(dir, de.Name()))
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		plugins = append(plugins, p)
+	}
+	if len(errs) > 0 {
+		return plugins, fmt.Errorf("invalid addon plugins: %s", strings.Join(errs, "; "))
+	}
+	return plugins, nil
+}
+
+// validate checks the manifest of the plugin against the files of its directory
+func (p *Plugin) validate() error {
+	if !nameRe.MatchString(p.Name) {
+		return fmt.Errorf("invalid addon name %q, it must be lowercase alphanumeric characters or '-'", p.Name)
+	}
+	if len(p.Templates) == 0 {
+		return fmt.Errorf("addon %s has no templates", p.Name)
+	}
+	for _, t := range p.Templates {
+		if !strings.HasSuffix(strings.TrimSuffix(t, ".tmpl"), ".yaml") {
+			return fmt.Errorf("template %s is not a .yaml or .yaml.tmpl file", t)
+		}
+		if err := p.checkFile(t); err != nil {
+			return err
+		}
+	}
+	for _, a := range append(append([]string{}, p.Dependencies...), p.Conflicts...) {
+		if !nameRe.MatchString(a) || a == p.Name {
+			return fmt.Errorf("invalid dependency or conflict %q", a)
+		}
+	}
+	if err := assets.ValidateSchema(p.Values); err != nil {
+		return err
+	}
+	if p.Verify != nil {
+		if _, err := labels.Parse(p.Verify.Label); err != nil || p.Verify.Label == "" {
+			return fmt.Errorf("invalid verify label %q: %v", p.Verify.Label, err)
+		}
+	}
+	for _, h := range []string{p.Hooks.Enable, p.Hooks.Disable} {
+		if h == "" {
+			continue
+		}
+		if err := p.checkFile(h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkFile checks that a file of the manifest is a regular file of the plugin directory
+func (p *Plugin) checkFile(name string) error {
+	if !fs.ValidPath(name) {
+		return fmt.Errorf("%s is not a relative path inside the plugin", name)
+	}
+	fi, err := os.Stat(filepath.Join(p.Dir, filepath.FromSlash(name)))
+	if err != nil {
+		return err
+	}
+	if !fi.Mode().IsRegular() {
+		return fmt.Errorf("%s is not a file", name)
+	}
+	return nil
+}
+
+// Assets returns the templates of the plugin as addon assets, copied to the addons directory of the node with the addon name as prefix
+func (p *Plugin) Assets() ([]*assets.BinAsset, error) {
+	fsys, ok := os.DirFS(p.Dir).(fs.ReadFileFS)
+	if !ok {
+		return nil, fmt.Errorf("cannot read files of %s", p.Dir)
+	}
+	var as []*assets.BinAsset
+	for _, t := range p.Templates {
+		target := p.Name + "-" + strings.ReplaceAll(strings.TrimSuffix(t, ".tmpl"), "/", "-")
+		a, err := assets.NewBinAsset(fsys, t, vmpath.GuestAddonsDir, target, "0640")
+		if err != nil {
+			return nil, errors.Wrapf(err, "addon %s template %s", p.Name, t)
+		}
+		as = append(as, a)
+	}
+	return as, nil
+}
+
+// RunHook runs a hook of the plugin on the host, from the plugin directory, for a profile
+func (p *Plugin) RunHook(hook, profile string) error {
+	if hook == "" {
+		return nil
+	}
+	klog.Infof("running %s hook %s for profile %s", p.Name, hook, profile)
+	c := exec.Command(filepath.Join(p.Dir, filepath.FromSlash(hook)))
+	c.Dir = p.Dir
+	c.Env = append(os.Environ(), "MINIKUBE_PROFILE="+profile, "MINIKUBE_ADDON="+p.Name)
+	out, err := c.CombinedOutput()
+	klog.Infof("%s hook %s output: %s", p.Name, hook, out)
+	if err != nil {
+		return errors.Wrapf(err, "%s hook %s: %s", p.Name, hook, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
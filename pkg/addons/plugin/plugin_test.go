@@ -0,0 +1,227 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+const testManifest = `name: mesh-config
+maintainer: Platform Team
+images:
+  Operator: example/mesh-operator:v1.2.0
+registries:
+  Operator: registry.example.com
+templates:
+- crds.yaml
+- operator/deployment.yaml.tmpl
+values:
+- name: replicas
+  type: int
+  default: 1
+verify:
+  namespace: mesh-system
+  label: app=mesh-operator
+hooks:
+  enable: hooks/enable.sh
+`
+
+// writePlugin writes a plugin with the files of the test manifest and returns its directory
+func writePlugin(t *testing.T, manifest string) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		ManifestFile:                    manifest,
+		"crds.yaml":                     "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: mesh-system\n",
+		"operator/deployment.yaml.tmpl": "image: {{.Registries.Operator}}/{{.Images.Operator}}\n",
+		"hooks/enable.sh":               "#!/bin/sh\necho $MINIKUBE_PROFILE $MINIKUBE_ADDON\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoad(t *testing.T) {
+	dir := writePlugin(t, testManifest)
+	p, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if p.Name != "mesh-config" || p.Maintainer != "Platform Team" || p.Verify.Label != "app=mesh-operator" || p.Hooks.Enable != "hooks/enable.sh" {
+		t.Errorf("Load() = %+v", p)
+	}
+
+	as, err := p.Assets()
+	if err != nil {
+		t.Fatalf("Assets: %v", err)
+	}
+	var targets []string
+	for _, a := range as {
+		targets = append(targets, a.GetTargetName())
+	}
+	if got, want := strings.Join(targets, ","), "mesh-config-crds.yaml,mesh-config-operator-deployment.yaml"; got != want {
+		t.Errorf("asset targets = %s, want %s", got, want)
+	}
+	if as[0].IsTemplate() || !as[1].IsTemplate() {
+		t.Errorf("only the .tmpl asset should be a template")
+	}
+	f, err := as[1].Evaluate(map[string]interface{}{"Images": p.Images, "Registries": p.Registries})
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	b, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), "image: registry.example.com/example/mesh-operator:v1.2.0\n"; got != want {
+		t.Errorf("rendered template = %q, want %q", got, want)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		replace string
+		with    string
+		err     string
+	}{
+		{"name", "name: mesh-config", "name: Mesh_Config", "invalid addon name"},
+		{"unknown field", "maintainer:", "maintainers:", "parsing"},
+		{"missing template", "- crds.yaml", "- missing.yaml", "missing.yaml"},
+		{"template outside", "- crds.yaml", "- ../crds.yaml", "not a relative path"},
+		{"not yaml", "- crds.yaml", "- hooks/enable.sh", "not a .yaml"},
+		{"value default", "default: 1", "default: one", "invalid default"},
+		{"verify label", "label: app=mesh-operator", "label: app in (", "invalid verify label"},
+		{"missing hook", "enable: hooks/enable.sh", "enable: hooks/missing.sh", "missing.sh"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := writePlugin(t, strings.Replace(testManifest, tc.replace, tc.with, 1))
+			_, err := Load(dir)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("Load() error = %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestInstall(t *testing.T) {
+	t.Setenv(localpath.MinikubeHome, t.TempDir())
+	src := writePlugin(t, testManifest)
+
+	p, err := Install(src)
+	if err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+	if want := filepath.Join(localpath.AddonPlugins(), "mesh-config"); p.Dir != want {
+		t.Errorf("installed plugin dir = %s, want %s", p.Dir, want)
+	}
+	// installing again replaces the plugin
+	if err := os.WriteFile(filepath.Join(src, "crds.yaml"), []byte("kind: List\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Install(src); err != nil {
+		t.Fatalf("Install again: %v", err)
+	}
+	b, err := os.ReadFile(filepath.Join(p.Dir, "crds.yaml"))
+	if err != nil || string(b) != "kind: List\n" {
+		t.Errorf("reinstalled crds.yaml = %q, %v", b, err)
+	}
+
+	plugins, err := LoadDir(localpath.AddonPlugins())
+	if err != nil || len(plugins) != 1 || plugins[0].Name != "mesh-config" {
+		t.Errorf("LoadDir() = %v, %v, want the installed plugin", plugins, err)
+	}
+
+	if err := Uninstall("mesh-config"); err != nil {
+		t.Fatalf("Uninstall: %v", err)
+	}
+	if err := Uninstall("mesh-config"); err == nil {
+		t.Errorf("Uninstall of a missing plugin should fail")
+	}
+	if plugins, _ := LoadDir(localpath.AddonPlugins()); len(plugins) != 0 {
+		t.Errorf("LoadDir() after Uninstall = %v, want none", plugins)
+	}
+}
+
+func TestUntar(t *testing.T) {
+	archive := func(names ...string) io.Reader {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for _, name := range names {
+			if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: 4, Typeflag: tar.TypeReg}); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := tw.Write([]byte("data")); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := tw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return &buf
+	}
+
+	dir := t.TempDir()
+	if err := untar(archive("./addon.yaml", "/templates/a.yaml"), dir); err != nil {
+		t.Fatalf("untar: %v", err)
+	}
+	for _, name := range []string{"addon.yaml", "templates/a.yaml"} {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+			t.Errorf("%s was not extracted: %v", name, err)
+		}
+	}
+
+	// paths are rooted in the directory, so they cannot escape it
+	escape := t.TempDir()
+	if err := untar(archive("../../escaped.yaml"), filepath.Join(escape, "plugin")); err != nil {
+		t.Fatalf("untar: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(escape, "plugin", "escaped.yaml")); err != nil {
+		t.Errorf("escaping path was not kept inside the directory: %v", err)
+	}
+}
+
+func TestIsGit(t *testing.T) {
+	tests := map[string]bool{
+		"https://github.com/example/addon.git":      true,
+		"https://github.com/example/addon.git#v1.0": true,
+		"git::https://example.com/addon":            true,
+		"./addon":                                   false,
+		"oci://ghcr.io/example/addon:v1":            false,
+	}
+	for src, want := range tests {
+		if got := isGit(src); got != want {
+			t.Errorf("isGit(%q) = %v, want %v", src, got, want)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/addons/plugin"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/out"
+)
+
+// plugins are the registered addon plugins by name
+var plugins = map[string]*plugin.Plugin{}
+
+// LoadPlugins registers the installed addon plugins, invalid plugins are skipped with a warning
+func LoadPlugins() {
+	ps, err := plugin.LoadDir(localpath.AddonPlugins())
+	if err != nil {
+		out.WarningT("Skipping addon plugins: {{.error}}", out.V{"error": err})
+	}
+	for _, p := range ps {
+		if err := RegisterPlugin(p); err != nil {
+			out.WarningT("Skipping addon plugin {{.name}}: {{.error}}", out.V{"name": p.Name, "error": err})
+		}
+	}
+}
+
+// RegisterPlugin registers an addon plugin like the built-in addons, replacing an earlier version of it
+func RegisterPlugin(p *plugin.Plugin) error {
+	if _, ok := assets.Addons[p.Name]; ok && plugins[p.Name] == nil {
+		return fmt.Errorf("%s is a built-in addon", p.Name)
+	}
+	for _, d := range p.Dependencies {
+		if d == p.Name || contains(dependencies(d), p.Name) {
+			return fmt.Errorf("%s depends on itself through %s", p.Name, d)
+		}
+	}
+	as, err := p.Assets()
+	if err != nil {
+		return err
+	}
+
+	callbacks := []setFn{pluginHook(p, false), EnableOrDisableAddon}
+	if p.Verify != nil {
+		ns := p.Verify.Namespace
+		if ns == "" {
+			ns = "kube-system"
+		}
+		addonPodLabels[p.Name] = p.Verify.Label
+		callbacks = append(callbacks, func(cc *config.ClusterConfig, name, val string) error {
+			return verifyAddonStatusInternal(cc, name, val, ns)
+		})
+	}
+	callbacks = append(callbacks, pluginHook(p, true))
+
+	a := &Addon{name: p.Name, set: SetBool, callbacks: callbacks, dependencies: p.Dependencies, conflicts: p.Conflicts}
+	if old, ok := isAddonValid(p.Name); ok {
+		*old = *a
+	} else {
+		Addons = append(Addons, a)
+	}
+	assets.Addons[p.Name] = assets.NewAddon(as, false, p.Name, p.Maintainer, p.VerifiedMaintainer, p.Docs, p.Images, p.Registries)
+	assets.Addons[p.Name].Values = p.Values
+	plugins[p.Name] = p
+	klog.Infof("registered addon plugin %s from %s", p.Name, p.Dir)
+	return nil
+}
+
+// IsPlugin returns whether an addon is a registered addon plugin
+func IsPlugin(name string) bool {
+	return plugins[name] != nil
+}
+
+// pluginHook returns the callback running the enable hook of a plugin when it is enabled, or its disable hook when it is disabled
+func pluginHook(p *plugin.Plugin, enable bool) setFn {
+	return func(cc *config.ClusterConfig, _ string, val string) error {
+		b, err := strconv.ParseBool(val)
+		if err != nil || b != enable {
+			return err
+		}
+		hook := p.Hooks.Disable
+		if enable {
+			hook = p.Hooks.Enable
+		}
+		if hook == "" {
+			return nil
+		}
+		// like the manifests, hooks wait for the next start of a stopped cluster
+		if !controlPlaneRunning(cc) {
+			klog.Infof("%s is not running, skipping the %s hook of %s", cc.Name, hook, p.Name)
+			return nil
+		}
+		return p.RunHook(hook, cc.Name)
+	}
+}
+
+// controlPlaneRunning returns whether the primary control-plane of a cluster is running
+func controlPlaneRunning(cc *config.ClusterConfig) bool {
+	api, err := machine.NewAPIClient()
+	if err != nil {
+		klog.Warningf("machine client: %v", err)
+		return false
+	}
+	defer api.Close()
+
+	cp, err := config.ControlPlane(*cc)
+	if err != nil {
+		klog.Warningf("control-plane node: %v", err)
+		return false
+	}
+	return machine.IsRunning(api, config.MachineName(*cc, cp))
+}
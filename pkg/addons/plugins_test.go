@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/minikube/pkg/addons/plugin"
+	"k8s.io/minikube/pkg/minikube/assets"
+)
+
+// loadTestPlugin loads a plugin named name with a single manifest
+func loadTestPlugin(t *testing.T, name string) *plugin.Plugin {
+	t.Helper()
+	dir := t.TempDir()
+	manifest := "name: " + name + "\nmaintainer: Platform Team\ntemplates:\n- app.yaml\nverify:\n  label: app=" + name + "\n"
+	if err := os.WriteFile(filepath.Join(dir, plugin.ManifestFile), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "app.yaml"), []byte("kind: List\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := plugin.Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return p
+}
+
+func TestRegisterPlugin(t *testing.T) {
+	builtin := len(Addons)
+	t.Cleanup(func() {
+		Addons = Addons[:builtin]
+		delete(assets.Addons, "mesh-config")
+		delete(addonPodLabels, "mesh-config")
+		delete(plugins, "mesh-config")
+	})
+
+	p := loadTestPlugin(t, "mesh-config")
+	if err := RegisterPlugin(p); err != nil {
+		t.Fatalf("RegisterPlugin: %v", err)
+	}
+	if _, ok := isAddonValid("mesh-config"); !ok {
+		t.Errorf("plugin is not a valid addon")
+	}
+	a, ok := assets.Addons["mesh-config"]
+	if !ok || a.Maintainer != "Platform Team" || len(a.Assets) != 1 {
+		t.Errorf("assets.Addons[mesh-config] = %+v", a)
+	}
+	if addonPodLabels["mesh-config"] != "app=mesh-config" {
+		t.Errorf("verify label = %q", addonPodLabels["mesh-config"])
+	}
+	if !IsPlugin("mesh-config") || IsPlugin("dashboard") {
+		t.Errorf("IsPlugin should only be true for plugins")
+	}
+
+	// registering again replaces the plugin
+	if err := RegisterPlugin(loadTestPlugin(t, "mesh-config")); err != nil {
+		t.Fatalf("RegisterPlugin again: %v", err)
+	}
+	if len(Addons) != builtin+1 {
+		t.Errorf("len(Addons) = %d, want %d", len(Addons), builtin+1)
+	}
+
+	if err := RegisterPlugin(loadTestPlugin(t, "dashboard")); err == nil {
+		t.Errorf("RegisterPlugin should not replace built-in addons")
+	}
+
+	// a plugin depending on itself, directly or through another addon, would never be enabled
+	cyclic := loadTestPlugin(t, "mesh-config")
+	cyclic.Dependencies = []string{"mesh-config"}
+	if err := RegisterPlugin(cyclic); err == nil {
+		t.Errorf("RegisterPlugin should reject a plugin depending on itself")
+	}
+	other := loadTestPlugin(t, "mesh-policy")
+	other.Dependencies = []string{"mesh-config"}
+	t.Cleanup(func() {
+		delete(assets.Addons, "mesh-policy")
+		delete(addonPodLabels, "mesh-policy")
+		delete(plugins, "mesh-policy")
+	})
+	if err := RegisterPlugin(other); err != nil {
+		t.Fatalf("RegisterPlugin mesh-policy: %v", err)
+	}
+	cyclic.Dependencies = []string{"mesh-policy"}
+	if err := RegisterPlugin(cyclic); err == nil {
+		t.Errorf("RegisterPlugin should reject a dependency cycle")
+	}
+	if a, _ := isAddonValid("mesh-config"); len(a.dependencies) != 0 {
+		t.Errorf("rejected plugin replaced mesh-config: %v", a.dependencies)
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+// ImageStatus is the image of a container of an addon
+type ImageStatus struct {
+	Object    string `json:"object"`
+	Container string `json:"container"`
+	// Expected is the image the current minikube deploys, Running the one of the cluster
+	Expected string `json:"expected"`
+	Running  string `json:"running"`
+	Outdated bool   `json:"outdated"`
+}
+
+// Status is the health of an enabled addon
+type Status struct {
+	Name string `json:"name"`
+	// Ready is true when all the objects of the addon exist and all its workloads are ready
+	Ready bool `json:"ready"`
+	// Workloads and ReadyWorkloads count the deployments, daemon sets, stateful sets and replica sets of the addon
+	Workloads      int `json:"workloads"`
+	ReadyWorkloads int `json:"readyWorkloads"`
+	// Missing are the objects of the manifests not found in the cluster
+	Missing []string      `json:"missing,omitempty"`
+	Images  []ImageStatus `json:"images,omitempty"`
+	// Drift are the fields of the objects differing from the manifests, like Deployment/kube-system/registry: spec.replicas
+	Drift []string `json:"drift,omitempty"`
+}
+
+// OutdatedImages returns the containers running another image than the current minikube deploys
+func (s *Status) OutdatedImages() []ImageStatus {
+	var images []ImageStatus
+	for _, i := range s.Images {
+		if i.Outdated {
+			images = append(images, i)
+		}
+	}
+	return images
+}
+
+// AddonStatus compares the objects of an enabled addon in the cluster with its manifests rendered by the current minikube
+func AddonStatus(cc *config.ClusterConfig, runner command.Runner, name string) (*Status, error) {
+	addon, ok := assets.Addons[name]
+	if !ok {
+		return nil, fmt.Errorf("%s is not a valid addon", name)
+	}
+	files, err := renderAddon(cc, addon)
+	if err != nil {
+		return nil, errors.Wrapf(err, "rendering %s", name)
+	}
+	manifests, all, err := manifestObjects(files)
+	if err != nil {
+		return nil, err
+	}
+	if len(manifests) == 0 {
+		// the addon has no Kubernetes objects, like auto-pause
+		return &Status{Name: name, Ready: true}, nil
+	}
+	live, err := liveObjects(cc, runner, all)
+	if err != nil {
+		return nil, err
+	}
+	return compareObjects(name, manifests, live), nil
+}
+
+// liveObjects returns the objects of the manifests found in the cluster
+func liveObjects(cc *config.ClusterConfig, runner command.Runner, manifests []byte) ([]*unstructured.Unstructured, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	c := kubectl(ctx, cc, "get", "--ignore-not-found", "-o", "json", "-f", "-")
+	c.Stdin = bytes.NewReader(manifests)
+	rr, err := runner.RunCmd(c)
+	if err != nil {
+		// kubectl fails on the kinds the cluster does not know, after printing the objects it found
+		if rr == nil || rr.Stdout.Len() == 0 {
+			return nil, errors.Wrap(err, "getting addon objects")
+		}
+		klog.Warningf("getting addon objects: %v", err)
+	}
+	return decodeManifest(rr.Stdout.Bytes())
+}
+
+// compareObjects returns the status of an addon from the objects of its manifests and the ones found in the cluster
+func compareObjects(name string, manifests, live []*unstructured.Unstructured) *Status {
+	s := &Status{Name: name}
+	for _, m := range manifests {
+		o := findObject(m, live)
+		if o == nil {
+			// completed jobs, like the admission jobs of ingress, may have been deleted
+			if m.GetKind() == "Job" {
+				continue
+			}
+			if _, workload := workloadReady(m); workload {
+				s.Workloads++
+			}
+			s.Missing = append(s.Missing, objectName(m))
+			continue
+		}
+		if ready, workload := workloadReady(o); workload {
+			s.Workloads++
+			if ready {
+				s.ReadyWorkloads++
+			}
+		}
+
+		expected, running := containerImages(m), containerImages(o)
+		containers := make([]string, 0, len(expected))
+		for c := range expected {
+			containers = append(containers, c)
+		}
+		sort.Strings(containers)
+		for _, c := range containers {
+			s.Images = append(s.Images, ImageStatus{Object: objectName(o), Container: c, Expected: expected[c], Running: running[c], Outdated: expected[c] != running[c]})
+		}
+
+		for _, f := range drift(m.Object, o.Object, "") {
+			s.Drift = append(s.Drift, objectName(o)+": "+f)
+		}
+	}
+	s.Ready = len(s.Missing) == 0 && s.ReadyWorkloads == s.Workloads
+	return s
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/util/retry"
+)
+
+// Upgrade applies the manifests of an enabled addon rendered by the current minikube in place with server-side apply,
+// and deletes the objects of the previously applied manifests the addon no longer has. It returns the deleted objects.
+func Upgrade(cc *config.ClusterConfig, runner command.Runner, name string) ([]string, error) {
+	addon, ok := assets.Addons[name]
+	if !ok {
+		return nil, fmt.Errorf("%s is not a valid addon", name)
+	}
+	files, err := renderAddon(cc, addon)
+	if err != nil {
+		return nil, errors.Wrapf(err, "rendering %s", name)
+	}
+	manifests, _, err := manifestObjects(files)
+	if err != nil {
+		return nil, err
+	}
+	previous := appliedObjects(runner, files)
+
+	var deployFiles []string
+	for _, f := range files {
+		klog.Infof("installing %s", manifestPath(f))
+		if err := runner.Copy(f); err != nil {
+			return nil, err
+		}
+		if isManifest(f) {
+			deployFiles = append(deployFiles, manifestPath(f))
+		}
+	}
+	if len(deployFiles) == 0 {
+		return nil, nil
+	}
+
+	args := []string{"apply", "--server-side", "--force-conflicts"}
+	for _, f := range deployFiles {
+		args = append(args, "-f", f)
+	}
+	// Retry, because sometimes we race against an apiserver restart
+	apply := func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+		_, err := runner.RunCmd(kubectl(ctx, cc, args...))
+		if err != nil {
+			klog.Warningf("apply failed, will retry: %v", err)
+		}
+		return err
+	}
+	if err := retry.Expo(apply, 250*time.Millisecond, 2*time.Minute); err != nil {
+		return nil, err
+	}
+
+	var pruned []string
+	for _, o := range removedObjects(previous, manifests) {
+		args := []string{"delete", "--ignore-not-found", objectRef(o)}
+		if ns := o.GetNamespace(); ns != "" {
+			args = append(args, "-n", ns)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		_, err := runner.RunCmd(kubectl(ctx, cc, args...))
+		cancel()
+		if err != nil {
+			return pruned, errors.Wrapf(err, "pruning %s", objectName(o))
+		}
+		pruned = append(pruned, objectName(o))
+	}
+	return pruned, nil
+}
+
+// appliedObjects returns the objects of the manifests of the addon last applied to the control plane. Manifests the
+// addon no longer has are not known, so their objects are left in place.
+func appliedObjects(runner command.Runner, files []assets.CopyableFile) []*unstructured.Unstructured {
+	var objs []*unstructured.Unstructured
+	for _, f := range files {
+		if !isManifest(f) {
+			continue
+		}
+		rr, err := runner.RunCmd(exec.Command("sudo", "cat", manifestPath(f)))
+		if err != nil {
+			klog.Infof("%s was not applied before: %v", manifestPath(f), err)
+			continue
+		}
+		o, err := decodeManifest(rr.Stdout.Bytes())
+		if err != nil {
+			klog.Warningf("not pruning the objects of %s: %v", manifestPath(f), err)
+			continue
+		}
+		objs = append(objs, o...)
+	}
+	return objs
+}
+
+// removedObjects returns the objects of the previous manifests missing from the current ones
+func removedObjects(previous, current []*unstructured.Unstructured) []*unstructured.Unstructured {
+	var removed []*unstructured.Unstructured
+	for _, o := range previous {
+		if findObject(o, current) == nil {
+			removed = append(removed, o)
+		}
+	}
+	return removed
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+// ParseValues reads addon values from YAML files of value names to values, then from name=value pairs
+// overriding them. Lists are joined with commas, like in --set.
+func ParseValues(files, sets []string) (map[string]string, error) {
+	values := map[string]string{}
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		m := map[string]interface{}{}
+		if err := yaml.Unmarshal(b, &m); err != nil {
+			return nil, fmt.Errorf("parsing %s: %v", f, err)
+		}
+		for name, v := range m {
+			s, err := valueString(v)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s %v", f, name, err)
+			}
+			values[name] = s
+		}
+	}
+	for _, s := range sets {
+		name, v, ok := strings.Cut(s, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid value %q, expected name=value", s)
+		}
+		values[name] = v
+	}
+	return values, nil
+}
+
+// valueString returns the string form of a value read from a values file
+func valueString(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case []interface{}:
+		var items []string
+		for _, item := range v {
+			s, err := valueString(item)
+			if err != nil {
+				return "", err
+			}
+			if strings.Contains(s, ",") {
+				return "", fmt.Errorf("list item %q contains a comma", s)
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, ","), nil
+	case map[interface{}]interface{}:
+		return "", fmt.Errorf("is a map, addon values are scalars or lists")
+	}
+	return fmt.Sprint(v), nil
+}
+
+// SetValues validates the values of an addon against its schema and sets them in the cluster config,
+// which the caller saves. Secret values are saved right away to the secret values of the profile instead.
+func SetValues(cc *config.ClusterConfig, name string, values map[string]string) error {
+	addon, ok := assets.Addons[name]
+	if !ok {
+		return fmt.Errorf("%s is not a valid addon", name)
+	}
+	if err := addon.ValidateValues(values); err != nil {
+		return err
+	}
+	if cc.AddonValues == nil {
+		cc.AddonValues = map[string]map[string]string{}
+	}
+	if cc.AddonValues[name] == nil {
+		cc.AddonValues[name] = map[string]string{}
+	}
+	for k, v := range values {
+		cc.AddonValues[name][k] = v
+	}
+	return MigrateSecretValues(cc)
+}
+
+// MigrateSecretValues moves the secret addon values of the cluster config, as set by previous versions of minikube,
+// to the secret values of the profile. The caller saves the cluster config.
+func MigrateSecretValues(cc *config.ClusterConfig) error {
+	var secrets map[string]map[string]string
+	for name, values := range cc.AddonValues {
+		addon, ok := assets.Addons[name]
+		if !ok {
+			continue
+		}
+		for k, s := range values {
+			if v, ok := addon.Value(k); !ok || !v.Secret {
+				continue
+			}
+			if secrets == nil {
+				var err error
+				if secrets, err = assets.SecretValues(cc.Name); err != nil {
+					return err
+				}
+			}
+			if secrets[name] == nil {
+				secrets[name] = map[string]string{}
+			}
+			secrets[name][k] = s
+			delete(values, k)
+		}
+	}
+	if secrets == nil {
+		return nil
+	}
+	return assets.SaveSecretValues(cc.Name, secrets)
+}
+
+// RedactSecretValues hides the secret addon values left in a cluster config, for the output of commands
+func RedactSecretValues(cc *config.ClusterConfig) {
+	for name, values := range cc.AddonValues {
+		addon, ok := assets.Addons[name]
+		if !ok {
+			continue
+		}
+		for k := range values {
+			if v, ok := addon.Value(k); ok && v.Secret {
+				values[k] = "********"
+			}
+		}
+	}
+}
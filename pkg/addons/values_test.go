@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+func TestParseValues(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "values.yaml")
+	if err := os.WriteFile(f, []byte("startIP: 192.168.49.100\nendIP: 192.168.49.120\naliases:\n- example.com\n- example.org\nreplicas: 2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ParseValues([]string{f}, []string{"endIP=192.168.49.110", "customCert=ns/a=b"})
+	if err != nil {
+		t.Fatalf("ParseValues: %v", err)
+	}
+	want := map[string]string{
+		"startIP":    "192.168.49.100",
+		"endIP":      "192.168.49.110",
+		"aliases":    "example.com,example.org",
+		"replicas":   "2",
+		"customCert": "ns/a=b",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ParseValues mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := ParseValues(nil, []string{"startIP"}); err == nil {
+		t.Errorf("--set without = should fail")
+	}
+	if err := os.WriteFile(f, []byte("pool:\n  start: 192.168.49.100\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseValues([]string{f}, nil); err == nil {
+		t.Errorf("nested values should fail")
+	}
+}
+
+func TestSetValues(t *testing.T) {
+	cc := &config.ClusterConfig{}
+	if err := SetValues(cc, "metallb", map[string]string{"startIP": "192.168.49.100"}); err != nil {
+		t.Fatalf("SetValues: %v", err)
+	}
+	if err := SetValues(cc, "metallb", map[string]string{"endIP": "192.168.49.120"}); err != nil {
+		t.Fatalf("SetValues: %v", err)
+	}
+	want := map[string]map[string]string{"metallb": {"startIP": "192.168.49.100", "endIP": "192.168.49.120"}}
+	if diff := cmp.Diff(want, cc.AddonValues); diff != "" {
+		t.Errorf("AddonValues mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := SetValues(cc, "metallb", map[string]string{"endIP": "last"}); err == nil {
+		t.Errorf("invalid value should fail")
+	}
+	if err := SetValues(cc, "no-such-addon", nil); err == nil {
+		t.Errorf("unknown addon should fail")
+	}
+}
+
+func TestSecretValues(t *testing.T) {
+	t.Setenv(localpath.MinikubeHome, t.TempDir())
+	if err := os.MkdirAll(localpath.Profile("p1"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	// awsSessionToken was stored in the config by previous versions of minikube
+	cc := &config.ClusterConfig{Name: "p1", AddonValues: map[string]map[string]string{"registry-creds": {"awsSessionToken": "token"}}}
+	if err := SetValues(cc, "registry-creds", map[string]string{"awsRegion": "eu-west-1", "awsSecretAccessKey": "key"}); err != nil {
+		t.Fatalf("SetValues: %v", err)
+	}
+	want := map[string]map[string]string{"registry-creds": {"awsRegion": "eu-west-1"}}
+	if diff := cmp.Diff(want, cc.AddonValues); diff != "" {
+		t.Errorf("AddonValues mismatch (-want +got):\n%s", diff)
+	}
+
+	secrets, err := assets.SecretValues("p1")
+	if err != nil {
+		t.Fatalf("SecretValues: %v", err)
+	}
+	want = map[string]map[string]string{"registry-creds": {"awsSecretAccessKey": "key", "awsSessionToken": "token"}}
+	if diff := cmp.Diff(want, secrets); diff != "" {
+		t.Errorf("SecretValues mismatch (-want +got):\n%s", diff)
+	}
+	if fi, err := os.Stat(localpath.AddonSecrets("p1")); err != nil || fi.Mode().Perm() != 0o600 {
+		t.Errorf("secret values file = %v, %v, want mode 0600", fi, err)
+	}
+	addon := assets.Addons["registry-creds"]
+	v, _ := addon.Value("awsSecretAccessKey")
+	if got := addon.RawValue(cc, v); got != "key" {
+		t.Errorf("RawValue(awsSecretAccessKey) = %q, want key", got)
+	}
+
+	legacy := &config.ClusterConfig{Name: "p2", AddonValues: map[string]map[string]string{"registry-creds": {"awsRegion": "eu-west-1", "dockerPassword": "hunter2"}}}
+	RedactSecretValues(legacy)
+	want = map[string]map[string]string{"registry-creds": {"awsRegion": "eu-west-1", "dockerPassword": "********"}}
+	if diff := cmp.Diff(want, legacy.AddonValues); diff != "" {
+		t.Errorf("redacted AddonValues mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -46,6 +46,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/reason"
 	"k8s.io/minikube/pkg/minikube/style"
 	"k8s.io/minikube/pkg/minikube/sysinit"
+	"k8s.io/minikube/pkg/network"
 	"k8s.io/minikube/pkg/util/retry"
 )
 
@@ -99,7 +100,8 @@ func (d *Driver) Create() error {
 		networkName = d.NodeConfig.ClusterName
 	}
 	staticIP := d.NodeConfig.StaticIP
-	if gateway, err := oci.CreateNetwork(d.OCIBinary, networkName, d.NodeConfig.Subnet, staticIP); err != nil {
+	gateway, ipv6Gateway, err := d.createNetwork(networkName)
+	if err != nil {
 		msg := "Unable to create dedicated network, this might result in cluster IP change after restart: {{.error}}"
 		args := out.V{"error": err}
 		if staticIP != "" {
@@ -121,6 +123,15 @@ func (d *Driver) Create() error {
 		klog.Infof("calculated static IP %q for the %q container", ip.String(), d.NodeConfig.MachineName)
 		params.IP = ip.String()
 	}
+	if d.NodeConfig.IPv6 {
+		if ipv6Gateway == nil || params.Network == "" {
+			return fmt.Errorf("IPv6 requires a dedicated network with an IPv6 subnet, network %q has none", networkName)
+		}
+		// like the IPv4 address, the IPv6 address is calculated from the gateway and the machine index
+		ipv6 := network.IPv6Offset(ipv6Gateway, driver.IndexFromMachineName(d.NodeConfig.MachineName))
+		klog.Infof("calculated static IPv6 %q for the %q container", ipv6.String(), d.NodeConfig.MachineName)
+		params.IPv6 = ipv6.String()
+	}
 	drv := d.DriverName()
 
 	listAddr := oci.DefaultBindIPV4
@@ -219,6 +230,15 @@ func (d *Driver) Create() error {
 	return nil
 }
 
+// createNetwork creates the dedicated network of the cluster, with an IPv6 subnet if the cluster uses IPv6
+func (d *Driver) createNetwork(networkName string) (gateway, ipv6Gateway net.IP, err error) {
+	if d.NodeConfig.IPv6 {
+		return oci.CreateNetworkWithIPv6(d.OCIBinary, networkName, d.NodeConfig.Subnet, d.NodeConfig.StaticIP, d.NodeConfig.IPv6Subnet)
+	}
+	gateway, err = oci.CreateNetwork(d.OCIBinary, networkName, d.NodeConfig.Subnet, d.NodeConfig.StaticIP)
+	return gateway, nil, err
+}
+
 // prepareSSH will generate keys and copy to the container so minikube ssh works
 func (d *Driver) prepareSSH() error {
 	keyPath := d.GetSSHKeyPath()
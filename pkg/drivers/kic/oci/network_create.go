@@ -37,6 +37,10 @@ import (
 // it is one octet more than the one used by KVM to avoid possible conflict
 const defaultFirstSubnetAddr = "192.168.49.0"
 
+// defaultFirstIPv6SubnetAddr is a first IPv6 subnet to be used on first kic cluster with IPv6 enabled
+// it is a unique local address mirroring defaultFirstSubnetAddr
+const defaultFirstIPv6SubnetAddr = "fd00:192:168:49::/64"
+
 // name of the default bridge network, used to lookup the MTU (see #9528)
 const dockerDefaultBridge = "bridge"
 
@@ -63,19 +67,42 @@ func firstSubnetAddr(subnet string) string {
 	return subnet
 }
 
+func firstIPv6SubnetAddr(subnet string) string {
+	if subnet == "" {
+		return defaultFirstIPv6SubnetAddr
+	}
+
+	return subnet
+}
+
 // CreateNetwork creates a network returns gateway and error, minikube creates one network per cluster
 func CreateNetwork(ociBin, networkName, subnet, staticIP string) (net.IP, error) {
+	info, err := createNetwork(ociBin, networkName, subnet, staticIP, false, "")
+	return info.gateway, err
+}
+
+// CreateNetworkWithIPv6 creates a dual-stack network and returns its IPv4 and IPv6 gateways, used by IPv6 and dual-stack clusters.
+// An existing network is reused as is, and returns a nil IPv6 gateway if it has no IPv6 subnet.
+func CreateNetworkWithIPv6(ociBin, networkName, subnet, staticIP, ipv6Subnet string) (net.IP, net.IP, error) {
+	info, err := createNetwork(ociBin, networkName, subnet, staticIP, true, ipv6Subnet)
+	return info.gateway, info.ipv6Gateway, err
+}
+
+func createNetwork(ociBin, networkName, subnet, staticIP string, ipv6 bool, ipv6Subnet string) (netInfo, error) {
 	defaultBridgeName := defaultBridgeName(ociBin)
 	if networkName == defaultBridgeName {
 		klog.Infof("skipping creating network since default network %s was specified", networkName)
-		return nil, nil
+		return netInfo{}, nil
 	}
 
 	// check if the network already exists
 	info, err := containerNetworkInspect(ociBin, networkName)
 	if err == nil {
 		klog.Infof("Found existing network %+v", info)
-		return info.gateway, nil
+		if ipv6 && info.ipv6Subnet == nil {
+			klog.Warningf("existing network %s has no IPv6 subnet", networkName)
+		}
+		return info, nil
 	}
 
 	// will try to get MTU from the docker network to avoid issue with systems with exotic MTU settings.
@@ -94,6 +121,7 @@ func CreateNetwork(ociBin, networkName, subnet, staticIP string) (net.IP, error)
 	}
 
 	// retry up to 5 times to create container network
+	ipv6SubnetAddr := firstIPv6SubnetAddr(ipv6Subnet)
 	for attempts, subnetAddr := 0, firstSubnetAddr(subnet); attempts < 5; attempts++ {
 		// Rather than iterate through all of the valid subnets, give up at 20 to avoid a lengthy user delay for something that is unlikely to work.
 		// will be like 192.168.49.0/24,..., 192.168.220.0/24 (in increment steps of 9)
@@ -101,25 +129,38 @@ func CreateNetwork(ociBin, networkName, subnet, staticIP string) (net.IP, error)
 		subnet, err = network.FreeSubnet(subnetAddr, 9, tries)
 		if err != nil {
 			klog.Errorf("failed to find free subnet for %s network %s after %d attempts: %v", ociBin, networkName, 20, err)
-			return nil, fmt.Errorf("un-retryable: %w", err)
+			return netInfo{}, fmt.Errorf("un-retryable: %w", err)
+		}
+		var ipv6Subnet *network.Parameters
+		if ipv6 {
+			// will be like fd00:192:168:49::/64,..., fd00:192:168:5c::/64
+			ipv6Subnet, err = network.FreeSubnetIPv6(ipv6SubnetAddr, 1, 20)
+			if err != nil {
+				klog.Errorf("failed to find free IPv6 subnet for %s network %s: %v", ociBin, networkName, err)
+				return netInfo{}, fmt.Errorf("un-retryable: %w", err)
+			}
 		}
-		info.gateway, err = tryCreateDockerNetwork(ociBin, subnet, info.mtu, networkName)
+		info.gateway, info.ipv6Gateway, err = tryCreateDockerNetwork(ociBin, subnet, ipv6Subnet, info.mtu, networkName)
 		if err == nil {
 			klog.Infof("%s network %s %s created", ociBin, networkName, subnet.CIDR)
-			return info.gateway, nil
+			return info, nil
 		}
 		// don't retry if error is not address is taken
 		if !(errors.Is(err, ErrNetworkSubnetTaken) || errors.Is(err, ErrNetworkGatewayTaken)) {
 			klog.Errorf("error while trying to create %s network %s %s: %v", ociBin, networkName, subnet.CIDR, err)
-			return nil, fmt.Errorf("un-retryable: %w", err)
+			return netInfo{}, fmt.Errorf("un-retryable: %w", err)
 		}
 		klog.Warningf("failed to create %s network %s %s, will retry: %v", ociBin, networkName, subnet.CIDR, err)
 		subnetAddr = subnet.IP
+		if ipv6Subnet != nil {
+			ipv6SubnetAddr = ipv6Subnet.CIDR
+		}
 	}
-	return info.gateway, fmt.Errorf("failed to create %s network %s: %w", ociBin, networkName, err)
+	return info, fmt.Errorf("failed to create %s network %s: %w", ociBin, networkName, err)
 }
 
-func tryCreateDockerNetwork(ociBin string, subnet *network.Parameters, mtu int, name string) (net.IP, error) {
+// tryCreateDockerNetwork creates a network with the subnet, and also with the IPv6 subnet if it is not nil
+func tryCreateDockerNetwork(ociBin string, subnet, ipv6Subnet *network.Parameters, mtu int, name string) (net.IP, net.IP, error) {
 	gateway := net.ParseIP(subnet.Gateway)
 	klog.Infof("attempt to create %s network %s %s with gateway %s and MTU of %d ...", ociBin, name, subnet.CIDR, subnet.Gateway, mtu)
 	args := []string{
@@ -129,6 +170,12 @@ func tryCreateDockerNetwork(ociBin string, subnet *network.Parameters, mtu int,
 		fmt.Sprintf("--subnet=%s", subnet.CIDR),
 		fmt.Sprintf("--gateway=%s", subnet.Gateway),
 	}
+	var ipv6Gateway net.IP
+	if ipv6Subnet != nil {
+		klog.Infof("enabling IPv6 on %s network %s with subnet %s and gateway %s", ociBin, name, ipv6Subnet.CIDR, ipv6Subnet.Gateway)
+		ipv6Gateway = net.ParseIP(ipv6Subnet.Gateway)
+		args = append(args, "--ipv6", fmt.Sprintf("--subnet=%s", ipv6Subnet.CIDR), fmt.Sprintf("--gateway=%s", ipv6Subnet.Gateway))
+	}
 	if ociBin == Docker {
 		// options documentation https://docs.docker.com/engine/reference/commandline/network_create/#bridge-driver-options
 		args = append(args, "-o")
@@ -149,28 +196,30 @@ func tryCreateDockerNetwork(ociBin string, subnet *network.Parameters, mtu int,
 		klog.Warningf("failed to create %s network %s %s with gateway %s and mtu of %d: %v", ociBin, name, subnet.CIDR, subnet.Gateway, mtu, err)
 		// Pool overlaps with other one on this address space
 		if strings.Contains(rr.Output(), "Pool overlaps") {
-			return nil, ErrNetworkSubnetTaken
+			return nil, nil, ErrNetworkSubnetTaken
 		}
 		if strings.Contains(rr.Output(), "failed to allocate gateway") && strings.Contains(rr.Output(), "Address already in use") {
-			return nil, ErrNetworkGatewayTaken
+			return nil, nil, ErrNetworkGatewayTaken
 		}
 		if strings.Contains(rr.Output(), "is being used by a network interface") {
-			return nil, ErrNetworkGatewayTaken
+			return nil, nil, ErrNetworkGatewayTaken
 		}
 		if strings.Contains(rr.Output(), "is already used on the host or by another config") {
-			return nil, ErrNetworkGatewayTaken
+			return nil, nil, ErrNetworkGatewayTaken
 		}
-		return nil, fmt.Errorf("create %s network %s %s with gateway %s and MTU of %d: %w", ociBin, name, subnet.CIDR, subnet.Gateway, mtu, err)
+		return nil, nil, fmt.Errorf("create %s network %s %s with gateway %s and MTU of %d: %w", ociBin, name, subnet.CIDR, subnet.Gateway, mtu, err)
 	}
-	return gateway, nil
+	return gateway, ipv6Gateway, nil
 }
 
 // netInfo holds part of a docker or podman network information relevant to kic drivers
 type netInfo struct {
-	name    string
-	subnet  *net.IPNet
-	gateway net.IP
-	mtu     int
+	name        string
+	subnet      *net.IPNet
+	gateway     net.IP
+	ipv6Subnet  *net.IPNet
+	ipv6Gateway net.IP
+	mtu         int
 }
 
 func containerNetworkInspect(ociBin string, name string) (netInfo, error) {
@@ -196,7 +245,7 @@ type networkInspect struct {
 var dockerInspectGetter = func(name string) (*RunResult, error) {
 	// hack -- 'support ancient versions of docker again (template parsing issue) #10362' and resolve 'Template parsing error: template: :1: unexpected "=" in operand' / 'exit status 64'
 	// note: docker v18.09.7 and older use go v1.10.8 and older, whereas support for '=' operator in go templates came in go v1.11
-	cmd := exec.Command(Docker, "network", "inspect", name, "--format", `{"Name": "{{.Name}}","Driver": "{{.Driver}}","Subnet": "{{range .IPAM.Config}}{{.Subnet}}{{end}}","Gateway": "{{range .IPAM.Config}}{{.Gateway}}{{end}}","MTU": {{if (index .Options "com.docker.network.driver.mtu")}}{{(index .Options "com.docker.network.driver.mtu")}}{{else}}0{{end}}, "ContainerIPs": [{{range $k,$v := .Containers }}"{{$v.IPv4Address}}",{{end}}]}`)
+	cmd := exec.Command(Docker, "network", "inspect", name, "--format", `{"Name": "{{.Name}}","Driver": "{{.Driver}}","Subnet": "{{range .IPAM.Config}}{{.Subnet}},{{end}}","Gateway": "{{range .IPAM.Config}}{{.Gateway}},{{end}}","MTU": {{if (index .Options "com.docker.network.driver.mtu")}}{{(index .Options "com.docker.network.driver.mtu")}}{{else}}0{{end}}, "ContainerIPs": [{{range $k,$v := .Containers }}"{{$v.IPv4Address}}",{{end}}]}`)
 	rr, err := runCmd(cmd)
 	// remove extra ',' after the last element in the ContainerIPs slice
 	rr.Stdout = *bytes.NewBuffer(bytes.ReplaceAll(rr.Stdout.Bytes(), []byte(",]"), []byte("]")))
@@ -217,28 +266,54 @@ func dockerNetworkInspect(name string) (netInfo, error) {
 		return info, err
 	}
 
-	// results looks like {"Name": "bridge","Driver": "bridge","Subnet": "172.17.0.0/16","Gateway": "172.17.0.1","MTU": 1500, "ContainerIPs": ["172.17.0.3/16", "172.17.0.2/16"]}
+	// results looks like {"Name": "bridge","Driver": "bridge","Subnet": "172.17.0.0/16,","Gateway": "172.17.0.1,","MTU": 1500, "ContainerIPs": ["172.17.0.3/16", "172.17.0.2/16"]}
+	// with IPv6 enabled, subnets and gateways look like "172.17.0.0/16,fd00::/64," and "172.17.0.1,fd00::1,"
 	if err := json.Unmarshal(rr.Stdout.Bytes(), &vals); err != nil {
 		return info, fmt.Errorf("error parsing network inspect output: %q", rr.Stdout.String())
 	}
 
-	info.gateway = net.ParseIP(vals.Gateway)
 	info.mtu = vals.MTU
-
-	_, info.subnet, err = net.ParseCIDR(vals.Subnet)
-	if err != nil {
-		return info, errors.Wrapf(err, "parse subnet for %s", name)
+	if err := info.setSubnets(strings.Split(vals.Subnet, ","), strings.Split(vals.Gateway, ",")); err != nil {
+		return info, err
 	}
 
 	return info, nil
 }
 
+// setSubnets records the IPv4 and IPv6 subnets of a network and their gateways, given in the same order
+func (info *netInfo) setSubnets(subnets, gateways []string) error {
+	for i, s := range subnets {
+		if s == "" {
+			continue
+		}
+		_, subnet, err := net.ParseCIDR(s)
+		if err != nil {
+			return errors.Wrapf(err, "parse subnet for %s", info.name)
+		}
+		var gateway net.IP
+		if i < len(gateways) {
+			gateway = net.ParseIP(gateways[i])
+		}
+		if subnet.IP.To4() != nil {
+			if info.subnet == nil {
+				info.subnet, info.gateway = subnet, gateway
+			}
+		} else if info.ipv6Subnet == nil {
+			info.ipv6Subnet, info.ipv6Gateway = subnet, gateway
+		}
+	}
+	if info.subnet == nil && info.ipv6Subnet == nil {
+		return fmt.Errorf("parse subnet for %s: no subnet found in %q", info.name, subnets)
+	}
+	return nil
+}
+
 var podmanInspectGetter = func(name string) (*RunResult, error) {
 	v, err := podmanVersion()
 	if err != nil {
 		return nil, errors.Wrapf(err, "podman version")
 	}
-	format := `{{range .}}{{if eq .Driver "bridge"}}{{(index .Subnets 0).Subnet}},{{(index .Subnets 0).Gateway}}{{end}}{{end}}`
+	format := `{{range .}}{{if eq .Driver "bridge"}}{{range .Subnets}}{{.Subnet}},{{.Gateway}},{{end}}{{end}}{{end}}`
 	if v.LT(semver.Version{Major: 4, Minor: 0, Patch: 0}) {
 		// format was changed in Podman 4.0.0: https://github.com/kubernetes/minikube/issues/13861#issuecomment-1082639236
 		format = `{{range .plugins}}{{if eq .type "bridge"}}{{(index (index .ipam.ranges 0) 0).subnet}},{{(index (index .ipam.ranges 0) 0).gateway}}{{end}}{{end}}`
@@ -264,16 +339,17 @@ func podmanNetworkInspect(name string) (netInfo, error) {
 		return info, fmt.Errorf("no bridge network found for %s", name)
 	}
 
-	// results looks like 172.17.0.0/16,172.17.0.1,1500
+	// results looks like 172.17.0.0/16,172.17.0.1, and with IPv6 enabled 172.17.0.0/16,172.17.0.1,fd00::/64,fd00::1,
 	vals := strings.Split(output, ",")
-
-	if len(vals) >= 2 {
-		info.gateway = net.ParseIP(vals[1])
+	var subnets, gateways []string
+	for i := 0; i < len(vals); i += 2 {
+		subnets = append(subnets, vals[i])
+		if i+1 < len(vals) {
+			gateways = append(gateways, vals[i+1])
+		}
 	}
-
-	_, info.subnet, err = net.ParseCIDR(vals[0])
-	if err != nil {
-		return info, errors.Wrapf(err, "parse subnet for %s", name)
+	if err := info.setSubnets(subnets, gateways); err != nil {
+		return info, err
 	}
 
 	return info, nil
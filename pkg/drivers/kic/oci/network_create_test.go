@@ -37,6 +37,8 @@ func TestDockerInspect(t *testing.T) {
 		dockerInspectResponse string
 		gateway               string
 		subnetIP              string
+		ipv6Gateway           string
+		ipv6SubnetIP          string
 		mtu                   int
 	}{
 		{
@@ -53,6 +55,15 @@ func TestDockerInspect(t *testing.T) {
 			subnetIP:              "172.19.0.0",
 			mtu:                   0,
 		},
+		{
+			name:                  "withIPv6",
+			dockerInspectResponse: `{"Name": "m2","Driver": "bridge","Subnet": "172.19.0.0/16,fd00:192:168:49::/64,","Gateway": "172.19.0.1,fd00:192:168:49::1,","MTU": 1500, "ContainerIPs": []}`,
+			gateway:               "172.19.0.1",
+			subnetIP:              "172.19.0.0",
+			ipv6Gateway:           "fd00:192:168:49::1",
+			ipv6SubnetIP:          "fd00:192:168:49::",
+			mtu:                   1500,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -79,6 +90,19 @@ func TestDockerInspect(t *testing.T) {
 			if !netInfo.subnet.IP.Equal(net.ParseIP(tc.subnetIP)) {
 				t.Errorf("Expected subnet to be %v but got %v", tc.subnetIP, netInfo.subnet.IP)
 			}
+
+			if tc.ipv6SubnetIP == "" {
+				if netInfo.ipv6Subnet != nil {
+					t.Errorf("Expected no IPv6 subnet but got %v", netInfo.ipv6Subnet)
+				}
+				return
+			}
+			if netInfo.ipv6Subnet == nil || !netInfo.ipv6Subnet.IP.Equal(net.ParseIP(tc.ipv6SubnetIP)) {
+				t.Errorf("Expected IPv6 subnet to be %v but got %v", tc.ipv6SubnetIP, netInfo.ipv6Subnet)
+			}
+			if !netInfo.ipv6Gateway.Equal(net.ParseIP(tc.ipv6Gateway)) {
+				t.Errorf("Expected IPv6 gateway to be %v but got %v", tc.ipv6Gateway, netInfo.ipv6Gateway)
+			}
 		})
 	}
 }
@@ -105,6 +129,7 @@ func TestPodmanInspect(t *testing.T) {
 		podmanInspectResponse string
 		gateway               net.IP
 		subnetIP              string
+		ipv6Subnet            string
 	}{
 		{
 			name:                  "WithGateway",
@@ -118,6 +143,13 @@ func TestPodmanInspect(t *testing.T) {
 			gateway:               emptyGateway,
 			subnetIP:              subnetIP.String(),
 		},
+		{
+			name:                  "WithIPv6",
+			podmanInspectResponse: "172.17.0.0/16,172.17.0.1,fd00:192:168:49::/64,fd00:192:168:49::1,",
+			gateway:               gateway,
+			subnetIP:              subnetIP.String(),
+			ipv6Subnet:            "fd00:192:168:49::/64",
+		},
 	}
 
 	for _, tc := range tests {
@@ -140,6 +172,10 @@ func TestPodmanInspect(t *testing.T) {
 			if netInfo.subnet.String() != tc.subnetIP {
 				t.Errorf("Expected subnet to be %v but got %v", tc.subnetIP, netInfo.subnet)
 			}
+
+			if tc.ipv6Subnet != "" && (netInfo.ipv6Subnet == nil || netInfo.ipv6Subnet.String() != tc.ipv6Subnet) {
+				t.Errorf("Expected IPv6 subnet to be %v but got %v", tc.ipv6Subnet, netInfo.ipv6Subnet)
+			}
 		})
 	}
 }
@@ -190,6 +190,10 @@ func CreateContainerNode(p CreateParams) error { //nolint to suppress cyclomatic
 		runArgs = append(runArgs, "--network", p.Network)
 		runArgs = append(runArgs, "--ip", p.IP)
 	}
+	if p.Network != "" && p.IPv6 != "" {
+		// like kind, make sure IPv6 is enabled and forwarded in the node, regardless of the host defaults
+		runArgs = append(runArgs, "--ip6", p.IPv6, "--sysctl", "net.ipv6.conf.all.disable_ipv6=0", "--sysctl", "net.ipv6.conf.all.forwarding=1")
+	}
 	if p.GPUs != "" {
 		runArgs = append(runArgs, "--gpus", "all", "--env", "NVIDIA_DRIVER_CAPABILITIES=all")
 	}
@@ -342,6 +346,22 @@ func StartContainer(ociBin string, container string) error {
 	return nil
 }
 
+// PauseContainer freezes all the processes of a container, keeping their memory
+func PauseContainer(ociBin string, container string) error {
+	if _, err := runCmd(exec.Command(ociBin, "pause", container)); err != nil {
+		return errors.Wrapf(err, "pausing %s", container)
+	}
+	return nil
+}
+
+// UnpauseContainer thaws the processes of a container frozen by PauseContainer
+func UnpauseContainer(ociBin string, container string) error {
+	if _, err := runCmd(exec.Command(ociBin, "unpause", container)); err != nil {
+		return errors.Wrapf(err, "unpausing %s", container)
+	}
+	return nil
+}
+
 // ContainerID returns id of a container name
 func ContainerID(ociBin string, nameOrID string) (string, error) {
 	rr, err := runCmd(exec.Command(ociBin, "container", "inspect", "-f", "{{.Id}}", nameOrID))
@@ -67,6 +67,8 @@ type Config struct {
 	Network           string            // network to run with kic
 	Subnet            string            // subnet to be used on kic cluster
 	StaticIP          string            // static IP for the kic cluster
+	IPv6              bool              // whether the kic cluster network and node use IPv6, for IPv6 and dual-stack clusters
+	IPv6Subnet        string            // IPv6 subnet to be used on kic cluster
 	ExtraArgs         []string          // a list of any extra option to pass to oci binary during creation time, for example --expose 8080...
 	ListenAddress     string            // IP Address to listen to
 	GPUs              string            // add NVIDIA GPU devices to the container
@@ -170,6 +170,10 @@ func (d *Driver) GetState() (st state.State, err error) {
 		return state.None, errors.Wrap(err, "getting domain state")
 	}
 	st = machineState(lvs)
+	if st == state.Stopped && hasManagedSave(dom) {
+		// suspended to disk, starting the domain restores its memory
+		st = state.Saved
+	}
 	return // st, err
 }
 
@@ -434,6 +438,20 @@ func (d *Driver) Stop() (err error) {
 		return errors.Wrap(err, "getting state of VM")
 	}
 
+	if s == state.Saved {
+		// the memory was saved to disk by Suspend, discard it so that the next start boots afresh
+		dom, conn, err := d.getDomain()
+		if err != nil {
+			return errors.Wrap(err, "getting connection")
+		}
+		defer func() {
+			if ferr := closeDomain(dom, conn); ferr != nil {
+				err = ferr
+			}
+		}()
+		return errors.Wrap(dom.ManagedSaveRemove(0), "removing managed save image")
+	}
+
 	if s != state.Stopped {
 		dom, conn, err := d.getDomain()
 		defer func() {
@@ -518,8 +536,8 @@ func (d *Driver) destroyRunningDomain(dom *libvirt.Domain) error {
 		return errors.Wrap(err, "getting domain state")
 	}
 
-	// if the domain is not running, we don't destroy it
-	if state != libvirt.DOMAIN_RUNNING {
+	// if the domain is not running or suspended, we don't destroy it
+	if state != libvirt.DOMAIN_RUNNING && state != libvirt.DOMAIN_PAUSED {
 		log.Warnf("Domain %s already destroyed, skipping...", d.MachineName)
 		return nil
 	}
@@ -546,7 +564,8 @@ func (d *Driver) undefineDomain(conn *libvirt.Connect, dom *libvirt.Domain) erro
 		return nil
 	}
 
-	return dom.UndefineFlags(libvirt.DOMAIN_UNDEFINE_KEEP_NVRAM)
+	// the domain may have been suspended to disk
+	return dom.UndefineFlags(libvirt.DOMAIN_UNDEFINE_KEEP_NVRAM | libvirt.DOMAIN_UNDEFINE_MANAGED_SAVE)
 }
 
 // lvErr will return libvirt Error struct containing specific libvirt error code, domain, message and level
@@ -0,0 +1,105 @@
+//go:build linux
+
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kvm
+
+import (
+	"github.com/docker/machine/libmachine/drivers"
+	"github.com/docker/machine/libmachine/log"
+	"github.com/pkg/errors"
+	"libvirt.org/go/libvirt"
+)
+
+// Suspend freezes the domain, keeping its memory in RAM, or saves its memory to disk and stops it if toDisk is set.
+// A domain saved to disk is restored by Start.
+func (d *Driver) Suspend(toDisk bool) (err error) {
+	dom, conn, err := d.getDomain()
+	if err != nil {
+		return errors.Wrap(err, "getting connection")
+	}
+	defer func() {
+		if ferr := closeDomain(dom, conn); ferr != nil {
+			err = ferr
+		}
+	}()
+
+	if toDisk {
+		log.Info("Saving domain memory to disk...")
+		return errors.Wrap(dom.ManagedSave(0), "managedsave")
+	}
+	log.Info("Suspending domain...")
+	return errors.Wrap(dom.Suspend(), "suspend")
+}
+
+// Resume thaws a domain frozen by Suspend, or restores a domain saved to disk, and waits for its IP address
+func (d *Driver) Resume() (err error) {
+	// the networks may have been stopped by a reboot of the host meanwhile
+	if err := d.ensureNetwork(); err != nil {
+		return errors.Wrap(err, "ensuring active networks")
+	}
+
+	dom, conn, err := d.getDomain()
+	if err != nil {
+		return errors.Wrap(err, "getting connection")
+	}
+	defer func() {
+		if ferr := closeDomain(dom, conn); ferr != nil {
+			err = ferr
+		}
+	}()
+
+	lvs, _, err := dom.GetState()
+	if err != nil {
+		return errors.Wrap(err, "getting domain state")
+	}
+	switch lvs {
+	case libvirt.DOMAIN_PAUSED:
+		log.Info("Resuming domain...")
+		if err := dom.Resume(); err != nil {
+			return errors.Wrap(err, "resume")
+		}
+	case libvirt.DOMAIN_SHUTOFF:
+		// creating a domain which has a managed save image restores it
+		log.Info("Restoring domain from disk...")
+		if err := dom.Create(); err != nil {
+			return errors.Wrap(err, "restore")
+		}
+	case libvirt.DOMAIN_RUNNING:
+		log.Info("Domain is already running")
+	default:
+		return errors.Errorf("unable to resume domain in state %s", machineState(lvs))
+	}
+
+	// the DHCP lease may have expired while the domain was frozen, reserve it again
+	log.Info("Waiting to get IP...")
+	if err := d.waitForStaticIP(conn); err != nil {
+		return errors.Wrap(err, "IP not available after waiting")
+	}
+	log.Info("Waiting for SSH to be available...")
+	return drivers.WaitForSSH(d)
+}
+
+// hasManagedSave returns whether the memory of the domain was saved to disk by Suspend
+func hasManagedSave(dom *libvirt.Domain) bool {
+	saved, err := dom.HasManagedSaveImage(0)
+	if err != nil {
+		log.Debugf("unable to check for a managed save image: %v", err)
+		return false
+	}
+	return saved
+}
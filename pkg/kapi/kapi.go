@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -69,6 +70,15 @@ func Client(context string) (*kubernetes.Clientset, error) {
 	return kubernetes.NewForConfig(c)
 }
 
+// DynamicClient gets the dynamic Kubernetes client for a kubectl context name, used to access CRDs such as Gateway API resources
+func DynamicClient(context string) (dynamic.Interface, error) {
+	c, err := ClientConfig(context)
+	if err != nil {
+		return nil, err
+	}
+	return dynamic.NewForConfig(c)
+}
+
 // WaitForPods waits for all matching pods to become Running or finish successfully and at least one matching pod exists.
 func WaitForPods(c kubernetes.Interface, ns string, selector string, timeOut ...time.Duration) error {
 	start := time.Now()
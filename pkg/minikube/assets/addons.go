@@ -21,7 +21,6 @@ import (
 	"os"
 	"runtime"
 	"strings"
-	"time"
 
 	semver "github.com/blang/semver/v4"
 	"github.com/pkg/errors"
@@ -46,6 +45,9 @@ type Addon struct {
 
 	// Registries currently only shows the default registry of images
 	Registries map[string]string
+
+	// Values are the values configuring the addon, set with minikube addons configure
+	Values []Value
 }
 
 // NetworkInfo contains control plane node IP address used for add on template
@@ -795,6 +797,36 @@ var Addons = map[string]*Addon{
 		}),
 }
 
+// autoPauseArgs returns the flags of the auto-pause daemon implementing the policy, quoted for a systemd unit
+func autoPauseArgs(p config.AutoPausePolicy) string {
+	var args []string
+	if len(p.Namespaces) > 0 {
+		args = append(args, "--namespaces="+strings.Join(p.Namespaces, ","))
+	}
+	if len(p.ExemptNamespaces) > 0 {
+		args = append(args, "--exempt-namespaces="+strings.Join(p.ExemptNamespaces, ","))
+	}
+	if p.ExemptSelector != "" {
+		args = append(args, "--exempt-selector="+p.ExemptSelector)
+	}
+	if p.WakeOnServices {
+		args = append(args, "--wake-on-services")
+	}
+	if len(p.NeverPause) > 0 {
+		// windows may contain commas, so they are separated by semicolons
+		args = append(args, "--never-pause="+strings.Join(p.NeverPause, ";"))
+		if p.TimeZone != "" {
+			args = append(args, "--time-zone="+p.TimeZone)
+		}
+	}
+	var b strings.Builder
+	for _, a := range args {
+		// systemd expands % specifiers even within quotes
+		fmt.Fprintf(&b, " %q", strings.ReplaceAll(a, "%", "%%"))
+	}
+	return b.String()
+}
+
 // parseMapString creates a map based on `str` which is encoded as <key1>=<value1>,<key2>=<value2>,...
 func parseMapString(str string) map[string]string {
 	mapResult := make(map[string]string)
@@ -911,18 +943,19 @@ func GenerateTemplateData(addon *Addon, cc *config.ClusterConfig, netInfo Networ
 		return errors.Wrap(err, "parsing Kubernetes version")
 	}
 
+	values, err := addon.ResolveValues(cc)
+	if err != nil {
+		return errors.Wrap(err, "addon values")
+	}
+
 	opts := struct {
 		KubernetesVersion       map[string]uint64
 		PreOneTwentyKubernetes  bool
 		Arch                    string
 		ExoticArch              string
 		ImageRepository         string
-		LoadBalancerStartIP     string
-		LoadBalancerEndIP       string
-		CustomIngressCert       string
 		IngressAPIVersion       string
 		ContainerRuntime        string
-		RegistryAliases         string
 		Images                  map[string]string
 		Registries              map[string]string
 		CustomRegistries        map[string]string
@@ -930,17 +963,14 @@ func GenerateTemplateData(addon *Addon, cc *config.ClusterConfig, netInfo Networ
 		Environment             map[string]string
 		LegacyPodSecurityPolicy bool
 		LegacyRuntimeClass      bool
-		AutoPauseInterval       time.Duration
+		AutoPauseArgs           string
+		Values                  map[string]interface{}
 	}{
 		KubernetesVersion:      make(map[string]uint64),
 		PreOneTwentyKubernetes: false,
 		Arch:                   a,
 		ExoticArch:             ea,
 		ImageRepository:        cfg.ImageRepository,
-		LoadBalancerStartIP:    cfg.LoadBalancerStartIP,
-		LoadBalancerEndIP:      cfg.LoadBalancerEndIP,
-		CustomIngressCert:      cfg.CustomIngressCert,
-		RegistryAliases:        cfg.RegistryAliases,
 		IngressAPIVersion:      "v1", // api version for ingress (eg, "v1beta1"; defaults to "v1" for k8s 1.19+)
 		ContainerRuntime:       cfg.ContainerRuntime,
 		Images:                 images,
@@ -952,7 +982,8 @@ func GenerateTemplateData(addon *Addon, cc *config.ClusterConfig, netInfo Networ
 		},
 		LegacyPodSecurityPolicy: v.LT(semver.Version{Major: 1, Minor: 25}),
 		LegacyRuntimeClass:      v.LT(semver.Version{Major: 1, Minor: 25}),
-		AutoPauseInterval:       cc.AutoPauseInterval,
+		AutoPauseArgs:           autoPauseArgs(cc.AutoPausePolicy),
+		Values:                  values,
 	}
 	if opts.ImageRepository != "" && !strings.HasSuffix(opts.ImageRepository, "/") {
 		opts.ImageRepository += "/"
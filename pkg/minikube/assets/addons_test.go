@@ -373,3 +373,32 @@ func checkMatches(t *testing.T, name string, got, expected map[string]string) {
 		t.Errorf("expected %q to be %q, but got %q", name, expected[name], got[name])
 	}
 }
+
+func TestAutoPauseArgs(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy config.AutoPausePolicy
+		want   string
+	}{
+		{"default", config.AutoPausePolicy{}, ""},
+		{
+			"full",
+			config.AutoPausePolicy{
+				Namespaces:       []string{"kube-system", "default"},
+				ExemptNamespaces: []string{"db"},
+				ExemptSelector:   "app=db",
+				WakeOnServices:   true,
+				NeverPause:       []string{"Mon-Fri 09:00-18:00", "Sat,Sun 10:00-12:00"},
+				TimeZone:         "Europe/Berlin",
+			},
+			` "--namespaces=kube-system,default" "--exempt-namespaces=db" "--exempt-selector=app=db" "--wake-on-services" "--never-pause=Mon-Fri 09:00-18:00;Sat,Sun 10:00-12:00" "--time-zone=Europe/Berlin"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := autoPauseArgs(tc.policy); got != tc.want {
+				t.Errorf("autoPauseArgs() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
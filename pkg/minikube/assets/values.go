@@ -0,0 +1,320 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+// ValueType is the type of an addon value
+type ValueType string
+
+const (
+	// StringValue is any string, the default type
+	StringValue ValueType = "string"
+	// IntValue is an integer
+	IntValue ValueType = "int"
+	// BoolValue is true or false
+	BoolValue ValueType = "bool"
+	// DurationValue is a positive duration like 1m30s
+	DurationValue ValueType = "duration"
+	// IPValue is an IP address
+	IPValue ValueType = "ip"
+	// ListValue is a list of strings, separated by commas on the command line
+	ListValue ValueType = "list"
+)
+
+var valueNameRe = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+
+// Value describes a value configuring an addon, rendered into its templates as .Values.<Name>
+type Value struct {
+	// Name is the key of the value in --set and values files
+	Name string `yaml:"name"`
+	// Type is string if empty
+	Type ValueType `yaml:"type,omitempty"`
+	// Default is used when the value is not set
+	Default     string `yaml:"default,omitempty"`
+	Description string `yaml:"description,omitempty"`
+	// Pattern is a regular expression the value, or each item of a list, must match
+	Pattern string `yaml:"pattern,omitempty"`
+	// Secret values are prompted for without echo and never shown, and are kept out of the cluster config
+	Secret bool `yaml:"secret,omitempty"`
+
+	// legacy returns the value stored in the cluster config by previous versions of minikube
+	legacy func(*config.ClusterConfig) string
+}
+
+// Parse converts the string form of the value to its type
+func (v Value) Parse(s string) (interface{}, error) {
+	if s == "" {
+		return v.zero(), nil
+	}
+	var re *regexp.Regexp
+	if v.Pattern != "" {
+		var err error
+		if re, err = regexp.Compile("^(?:" + v.Pattern + ")$"); err != nil {
+			return nil, fmt.Errorf("invalid pattern of %s: %v", v.Name, err)
+		}
+	}
+	switch v.Type {
+	case "", StringValue:
+		if re != nil && !re.MatchString(s) {
+			return nil, fmt.Errorf("%s: %q does not match %s", v.Name, s, v.Pattern)
+		}
+		return s, nil
+	case IntValue:
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %q is not an integer", v.Name, s)
+		}
+		return i, nil
+	case BoolValue:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %q is not a boolean", v.Name, s)
+		}
+		return b, nil
+	case DurationValue:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %q is not a duration", v.Name, s)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("%s: must be greater than 0s", v.Name)
+		}
+		return d, nil
+	case IPValue:
+		if net.ParseIP(s) == nil {
+			return nil, fmt.Errorf("%s: %q is not an IP address", v.Name, s)
+		}
+		return s, nil
+	case ListValue:
+		var l []string
+		for _, item := range strings.Split(s, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			if re != nil && !re.MatchString(item) {
+				return nil, fmt.Errorf("%s: %q does not match %s", v.Name, item, v.Pattern)
+			}
+			l = append(l, item)
+		}
+		return l, nil
+	}
+	return nil, fmt.Errorf("%s has the unknown type %q", v.Name, v.Type)
+}
+
+// zero returns the value of an unset value without a default
+func (v Value) zero() interface{} {
+	switch v.Type {
+	case IntValue:
+		return 0
+	case BoolValue:
+		return false
+	case DurationValue:
+		return time.Duration(0)
+	case ListValue:
+		return []string(nil)
+	}
+	return ""
+}
+
+// ValidateSchema checks the values of an addon are well formed
+func ValidateSchema(values []Value) error {
+	seen := map[string]bool{}
+	for _, v := range values {
+		if !valueNameRe.MatchString(v.Name) {
+			return fmt.Errorf("invalid value name %q: must be letters, digits and underscores, starting with a letter", v.Name)
+		}
+		if seen[v.Name] {
+			return fmt.Errorf("value %s is declared twice", v.Name)
+		}
+		seen[v.Name] = true
+		if _, err := v.Parse(v.Default); err != nil {
+			return fmt.Errorf("invalid default: %v", err)
+		}
+	}
+	return nil
+}
+
+// Value returns the schema of the value of the addon with the given name
+func (a *Addon) Value(name string) (Value, bool) {
+	for _, v := range a.Values {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return Value{}, false
+}
+
+// ValueNames returns the names of the values of the addon, sorted
+func (a *Addon) ValueNames() []string {
+	var names []string
+	for _, v := range a.Values {
+		names = append(names, v.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RawValue returns the string form of a value of the addon: the one set for the profile, else the one
+// stored by a previous version of minikube, else the default
+func (a *Addon) RawValue(cc *config.ClusterConfig, v Value) string {
+	if v.Secret && cc.Name != "" {
+		secrets, err := SecretValues(cc.Name)
+		if err != nil {
+			klog.Warningf("unable to read the secret addon values of %s: %v", cc.Name, err)
+		}
+		if s, ok := secrets[a.Name()][v.Name]; ok {
+			return s
+		}
+	}
+	if s, ok := cc.AddonValues[a.Name()][v.Name]; ok {
+		return s
+	}
+	if v.legacy != nil {
+		if s := v.legacy(cc); s != "" {
+			return s
+		}
+	}
+	return v.Default
+}
+
+// SecretValues returns the secret addon values of a profile by addon and value name. They are kept in a file
+// only readable by the user, instead of the cluster config which is shown by commands like "minikube profile list".
+func SecretValues(profile string) (map[string]map[string]string, error) {
+	values := map[string]map[string]string{}
+	b, err := os.ReadFile(localpath.AddonSecrets(profile))
+	if os.IsNotExist(err) {
+		return values, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &values); err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", localpath.AddonSecrets(profile), err)
+	}
+	return values, nil
+}
+
+// SaveSecretValues saves the secret addon values of a profile, readable by the user only
+func SaveSecretValues(profile string, values map[string]map[string]string) error {
+	b, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	path := localpath.AddonSecrets(profile)
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		return err
+	}
+	// WriteFile keeps the mode of an existing file
+	return os.Chmod(path, 0o600)
+}
+
+// ResolveValues returns the typed values of the addon for the profile, as rendered into its templates
+func (a *Addon) ResolveValues(cc *config.ClusterConfig) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	for _, v := range a.Values {
+		val, err := v.Parse(a.RawValue(cc, v))
+		if err != nil {
+			return nil, err
+		}
+		values[v.Name] = val
+	}
+	return values, nil
+}
+
+// ValidateValues checks the values, in their string form, are declared by the addon and have the right type
+func (a *Addon) ValidateValues(values map[string]string) error {
+	for name, s := range values {
+		v, ok := a.Value(name)
+		if !ok {
+			if len(a.Values) == 0 {
+				return fmt.Errorf("the %s addon has no values", a.Name())
+			}
+			return fmt.Errorf("the %s addon has no value %q, valid values are: %s", a.Name(), name, strings.Join(a.ValueNames(), ", "))
+		}
+		if _, err := v.Parse(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// builtinValues are the values of the built-in addons
+var builtinValues = map[string][]Value{
+	"auto-pause": {
+		{Name: "interval", Type: DurationValue, Default: "1m0s", Description: "duration of inactivity before the cluster is paused",
+			legacy: func(cc *config.ClusterConfig) string {
+				if cc.AutoPauseInterval <= 0 {
+					return ""
+				}
+				return cc.AutoPauseInterval.String()
+			}},
+	},
+	"ingress": {
+		{Name: "customCert", Pattern: ".+/.+", Description: "default TLS certificate, as namespace/secret",
+			legacy: func(cc *config.ClusterConfig) string { return cc.KubernetesConfig.CustomIngressCert }},
+	},
+	"metallb": {
+		{Name: "startIP", Type: IPValue, Description: "first IP address of the load balancer pool",
+			legacy: func(cc *config.ClusterConfig) string { return cc.KubernetesConfig.LoadBalancerStartIP }},
+		{Name: "endIP", Type: IPValue, Description: "last IP address of the load balancer pool",
+			legacy: func(cc *config.ClusterConfig) string { return cc.KubernetesConfig.LoadBalancerEndIP }},
+	},
+	"registry-aliases": {
+		{Name: "aliases", Type: ListValue, Pattern: `[a-zA-Z0-9-_]+(\.[a-zA-Z0-9-_]+)+`, Description: "host names resolving to the registry addon",
+			legacy: func(cc *config.ClusterConfig) string {
+				return strings.Join(strings.Fields(cc.KubernetesConfig.RegistryAliases), ",")
+			}},
+	},
+	"registry-creds": {
+		{Name: "awsAccessKeyID", Default: "changeme", Description: "AWS access key ID"},
+		{Name: "awsSecretAccessKey", Default: "changeme", Description: "AWS secret access key", Secret: true},
+		{Name: "awsSessionToken", Description: "AWS session token", Secret: true},
+		{Name: "awsRegion", Default: "changeme", Description: "AWS region"},
+		{Name: "awsAccount", Default: "changeme", Description: "12 digit AWS account IDs, separated by commas"},
+		{Name: "awsRole", Default: "changeme", Description: "ARN of the AWS role to assume"},
+		{Name: "gcrCredentialsFile", Description: "path of the Google application default credentials"},
+		{Name: "gcrURL", Default: "https://gcr.io", Description: "Google Container Registry URL"},
+		{Name: "dockerServer", Default: "changeme", Description: "Docker registry server URL"},
+		{Name: "dockerUser", Default: "changeme", Description: "Docker registry user name"},
+		{Name: "dockerPassword", Default: "changeme", Description: "Docker registry password", Secret: true},
+		{Name: "acrURL", Default: "changeme", Description: "Azure Container Registry URL"},
+		{Name: "acrClientID", Default: "changeme", Description: "Azure service principal ID"},
+		{Name: "acrPassword", Default: "changeme", Description: "Azure service principal password", Secret: true},
+	},
+}
+
+func init() {
+	for name, values := range builtinValues {
+		Addons[name].Values = values
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestBuiltinValues(t *testing.T) {
+	for name, values := range builtinValues {
+		if _, ok := Addons[name]; !ok {
+			t.Errorf("values of the unknown addon %s", name)
+		}
+		if err := ValidateSchema(values); err != nil {
+			t.Errorf("values of %s: %v", name, err)
+		}
+	}
+}
+
+func TestValueParse(t *testing.T) {
+	tests := []struct {
+		value Value
+		in    string
+		want  interface{}
+		err   bool
+	}{
+		{Value{Name: "s"}, "text", "text", false},
+		{Value{Name: "s", Pattern: ".+/.+"}, "ns/secret", "ns/secret", false},
+		{Value{Name: "s", Pattern: ".+/.+"}, "secret", nil, true},
+		{Value{Name: "i", Type: IntValue}, "3", 3, false},
+		{Value{Name: "i", Type: IntValue}, "three", nil, true},
+		{Value{Name: "b", Type: BoolValue}, "true", true, false},
+		{Value{Name: "d", Type: DurationValue}, "1m30s", 90 * time.Second, false},
+		{Value{Name: "d", Type: DurationValue}, "-1m", nil, true},
+		{Value{Name: "d", Type: DurationValue}, "", time.Duration(0), false},
+		{Value{Name: "ip", Type: IPValue}, "192.168.49.100", "192.168.49.100", false},
+		{Value{Name: "ip", Type: IPValue}, "192.168.49", nil, true},
+		{Value{Name: "l", Type: ListValue}, "a.com, b.org,", []string{"a.com", "b.org"}, false},
+		{Value{Name: "l", Type: ListValue, Pattern: `\w+\.\w+`}, "a.com,b", nil, true},
+		{Value{Name: "l", Type: ListValue}, "", []string(nil), false},
+		{Value{Name: "x", Type: "float"}, "1.5", nil, true},
+	}
+	for _, tc := range tests {
+		got, err := tc.value.Parse(tc.in)
+		if (err != nil) != tc.err {
+			t.Errorf("%s.Parse(%q) error = %v, want error %v", tc.value.Name, tc.in, err, tc.err)
+			continue
+		}
+		if diff := cmp.Diff(tc.want, got); !tc.err && diff != "" {
+			t.Errorf("%s.Parse(%q) mismatch (-want +got):\n%s", tc.value.Name, tc.in, diff)
+		}
+	}
+}
+
+func TestResolveValues(t *testing.T) {
+	cc := &config.ClusterConfig{
+		KubernetesConfig: config.KubernetesConfig{
+			LoadBalancerStartIP: "192.168.49.100",
+			LoadBalancerEndIP:   "192.168.49.120",
+			RegistryAliases:     "example.com example.org",
+		},
+		AddonValues: map[string]map[string]string{"metallb": {"endIP": "192.168.49.110"}},
+	}
+	tests := []struct {
+		addon string
+		want  map[string]interface{}
+	}{
+		// set values win over the ones stored by previous versions
+		{"metallb", map[string]interface{}{"startIP": "192.168.49.100", "endIP": "192.168.49.110"}},
+		{"registry-aliases", map[string]interface{}{"aliases": []string{"example.com", "example.org"}}},
+		{"auto-pause", map[string]interface{}{"interval": time.Minute}},
+		{"dashboard", map[string]interface{}{}},
+	}
+	for _, tc := range tests {
+		got, err := Addons[tc.addon].ResolveValues(cc)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.addon, err)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("%s values mismatch (-want +got):\n%s", tc.addon, diff)
+		}
+	}
+}
+
+func TestValidateValues(t *testing.T) {
+	a := Addons["metallb"]
+	if err := a.ValidateValues(map[string]string{"startIP": "10.0.0.1"}); err != nil {
+		t.Errorf("valid values: %v", err)
+	}
+	if err := a.ValidateValues(map[string]string{"startIp": "10.0.0.1"}); err == nil {
+		t.Errorf("unknown value should fail")
+	}
+	if err := a.ValidateValues(map[string]string{"startIP": "first"}); err == nil {
+		t.Errorf("invalid IP should fail")
+	}
+	if err := Addons["dashboard"].ValidateValues(map[string]string{"replicas": "2"}); err == nil {
+		t.Errorf("addon without values should fail")
+	}
+}
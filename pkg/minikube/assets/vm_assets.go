@@ -18,10 +18,10 @@ package assets
 
 import (
 	"bytes"
-	"embed"
 	"fmt"
 	"html/template"
 	"io"
+	"io/fs"
 	"os"
 	"path"
 	"path/filepath"
@@ -308,9 +308,9 @@ func NewMemoryAsset(d []byte, targetDir, targetName, permissions string) *Memory
 	}
 }
 
-// BinAsset is a bindata (binary data) asset
+// BinAsset is a bindata (binary data) asset, read from the embedded addons or the directory of an addon plugin
 type BinAsset struct {
-	embed.FS
+	FS fs.ReadFileFS
 	BaseAsset
 	reader   io.ReadSeeker
 	template *template.Template
@@ -318,8 +318,8 @@ type BinAsset struct {
 }
 
 // MustBinAsset creates a new BinAsset, or panics if invalid
-func MustBinAsset(fs embed.FS, name, targetDir, targetName, permissions string) *BinAsset {
-	asset, err := NewBinAsset(fs, name, targetDir, targetName, permissions)
+func MustBinAsset(fsys fs.ReadFileFS, name, targetDir, targetName, permissions string) *BinAsset {
+	asset, err := NewBinAsset(fsys, name, targetDir, targetName, permissions)
 	if err != nil {
 		panic(fmt.Sprintf("Failed to define asset %s: %v", name, err))
 	}
@@ -327,9 +327,9 @@ func MustBinAsset(fs embed.FS, name, targetDir, targetName, permissions string)
 }
 
 // NewBinAsset creates a new BinAsset
-func NewBinAsset(fs embed.FS, name, targetDir, targetName, permissions string) (*BinAsset, error) {
+func NewBinAsset(fsys fs.ReadFileFS, name, targetDir, targetName, permissions string) (*BinAsset, error) {
 	m := &BinAsset{
-		FS: fs,
+		FS: fsys,
 		BaseAsset: BaseAsset{
 			SourcePath:  name,
 			TargetDir:   targetDir,
@@ -18,10 +18,10 @@ package audit
 
 import (
 	"bufio"
-	"encoding/json"
 	"fmt"
 	"os"
 	"os/user"
+	"strconv"
 	"strings"
 	"time"
 
@@ -55,64 +55,74 @@ func args() string {
 	return strings.Join(os.Args[2:], " ")
 }
 
+// startTimes holds the precise start times of the commands logged by this process, to calculate their duration
+var startTimes = map[string]time.Time{}
+
 // Log details about the executed command.
 func LogCommandStart() (string, error) {
 	if !shouldLog() {
 		return "", nil
 	}
 	id := uuid.New().String()
-	r := newRow(pflag.Arg(0), args(), userName(), version.GetVersion(), time.Now(), id)
+	now := time.Now()
+	r := newRow(pflag.Arg(0), args(), userName(), version.GetVersion(), now, id)
 	if err := appendToLog(r); err != nil {
 		return "", err
 	}
+	startTimes[id] = now
 	return r.id, nil
 }
 
+// LogCommandEnd logs the successful end of the command with the given id.
 func LogCommandEnd(id string) error {
+	return LogCommandExit(id, 0)
+}
+
+// LogCommandExit logs the end of the command with the given id, with its exit code and duration.
+func LogCommandExit(id string, exitCode int) error {
 	if id == "" {
 		return nil
 	}
 	if err := openAuditLog(); err != nil {
 		return err
 	}
-	defer closeAuditLog()
 	var logs []string
 	s := bufio.NewScanner(currentLogFile)
 	for s.Scan() {
 		logs = append(logs, s.Text())
 	}
+	closeAuditLog()
 	if err := s.Err(); err != nil {
 		return fmt.Errorf("failed to read from audit file: %v", err)
 	}
-	closeAuditLog()
 	rowSlice, err := logsToRows(logs)
 	if err != nil {
 		return fmt.Errorf("failed to convert logs to rows: %v", err)
 	}
-	// have to truncate the audit log while closed as Windows can't truncate an open file
-	if err := truncateAuditLog(); err != nil {
-		return fmt.Errorf("failed to truncate audit log: %v", err)
-	}
-	if err := openAuditLog(); err != nil {
-		return err
-	}
 	var entriesNeedsToUpdate int
 
+	// rows over the limit are moved to the archive instead of being dropped,
+	// before the audit log is rewritten so a failure to archive them loses nothing
 	startIndex := getStartIndex(len(rowSlice))
+	if err := archiveRows(rowSlice[:startIndex]); err != nil {
+		return fmt.Errorf("failed to archive audit log entries: %v", err)
+	}
 	rowSlice = rowSlice[startIndex:]
-	for _, v := range rowSlice {
+	for i, v := range rowSlice {
 		if v.id == id {
-			v.endTime = time.Now().Format(constants.TimeFormat)
+			now := time.Now()
+			v.endTime = now.Format(constants.TimeFormat)
+			v.exitCode = strconv.Itoa(exitCode)
+			if start, ok := startTimes[id]; ok {
+				v.duration = now.Sub(start).Round(time.Millisecond).String()
+			}
 			v.Data = v.toMap()
+			rowSlice[i] = v
 			entriesNeedsToUpdate++
 		}
-		auditLog, err := json.Marshal(v)
-		if err != nil {
-			return err
-		}
-		if _, err = currentLogFile.WriteString(string(auditLog) + "\n"); err != nil {
-			return fmt.Errorf("failed to write to audit log: %v", err)
-		}
+	}
+	if err := writeAuditLog(rowSlice); err != nil {
+		return err
 	}
 	if entriesNeedsToUpdate == 0 {
 		return fmt.Errorf("failed to find a log row with id equals to %v", id)
@@ -149,7 +159,7 @@ func shouldLog() bool {
 	}
 
 	// commands that should not be logged.
-	no := []string{"status", "version", "logs", "generate-docs", "profile"}
+	no := []string{"status", "version", "logs", "events", "audit", "generate-docs", "profile"}
 	a := pflag.Arg(0)
 	for _, c := range no {
 		if a == c {
@@ -17,14 +17,26 @@ limitations under the License.
 package audit
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
+	"github.com/spf13/viper"
 	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/out/register"
 )
 
+const (
+	// maxArchives is the number of rotated audit log archives kept, the first being the newest
+	maxArchives = 3
+	// defaultMaxArchiveSize is the size in megabytes at which the newest archive is rotated
+	defaultMaxArchiveSize = 10
+)
+
 var (
 	// currentLogFile the file that's used to store audit logs
 	currentLogFile *os.File
@@ -58,6 +70,7 @@ func closeAuditLog() {
 // appendToLog appends the row to the log file.
 func appendToLog(row *row) error {
 	ce := register.CloudEvent(row, row.toMap())
+	ce.SetTime(time.Now())
 	bs, err := ce.MarshalJSON()
 	if err != nil {
 		return fmt.Errorf("error marshalling event: %v", err)
@@ -72,10 +85,34 @@ func appendToLog(row *row) error {
 	return nil
 }
 
-// truncateAuditLog truncates the audit log file
-func truncateAuditLog() error {
-	if err := os.Truncate(auditPath(), 0); err != nil {
-		return fmt.Errorf("failed to truncate audit log: %v", err)
+// writeAuditLog replaces the audit log with the rows, through a temporary file renamed over it so the log is never left partially written
+func writeAuditLog(rows []row) error {
+	path := auditPath()
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create a temporary audit log: %v", err)
+	}
+	defer os.Remove(f.Name())
+	for _, r := range rows {
+		bs, err := json.Marshal(r)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		if _, err := f.Write(append(bs, '\n')); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to write to audit log: %v", err)
+		}
+	}
+	if err := f.Chmod(0644); err != nil {
+		klog.Warningf("failed to set the permissions of the audit log: %v", err)
+	}
+	// Windows can't rename an open file
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write to audit log: %v", err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace the audit log: %v", err)
 	}
 	return nil
 }
@@ -86,3 +123,62 @@ func auditPath() string {
 	}
 	return localpath.AuditLog()
 }
+
+// archiveRows appends the rows removed from the audit log to its newest archive, rotating the archives when it reached the max size
+func archiveRows(rows []row) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	path := archivePath(1)
+	if st, err := os.Stat(path); err == nil && st.Size() >= maxArchiveSize() {
+		if err := rotateArchives(); err != nil {
+			return err
+		}
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open the audit log archive: %v", err)
+	}
+	defer f.Close()
+	for _, r := range rows {
+		bs, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(append(bs, '\n')); err != nil {
+			return fmt.Errorf("unable to write to the audit log archive: %v", err)
+		}
+	}
+	return nil
+}
+
+// rotateArchives shifts every archive to the next number, dropping the oldest one
+func rotateArchives() error {
+	for i := maxArchives; i > 1; i-- {
+		if err := os.Rename(archivePath(i-1), archivePath(i)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to rotate the audit log archive: %v", err)
+		}
+	}
+	return nil
+}
+
+func maxArchiveSize() int64 {
+	size := defaultMaxArchiveSize
+	if viper.IsSet(config.MaxAuditSize) && viper.GetInt(config.MaxAuditSize) > 0 {
+		size = viper.GetInt(config.MaxAuditSize)
+	}
+	return int64(size) * 1024 * 1024
+}
+
+func archivePath(n int) string {
+	return fmt.Sprintf("%s.%d", auditPath(), n)
+}
+
+// auditFiles returns the audit log archives and the audit log, oldest first
+func auditFiles() []string {
+	var files []string
+	for i := maxArchives; i > 0; i-- {
+		files = append(files, archivePath(i))
+	}
+	return append(files, auditPath())
+}
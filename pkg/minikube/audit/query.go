@@ -0,0 +1,277 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/constants"
+)
+
+// Statuses of the Filter
+const (
+	StatusSuccess    = "success"
+	StatusFailure    = "failure"
+	StatusUnfinished = "unfinished"
+)
+
+// Export formats
+const (
+	FormatTable       = "table"
+	FormatJSONLines   = "jsonl"
+	FormatCSV         = "csv"
+	FormatCloudEvents = "cloudevents"
+)
+
+// Filter selects entries of the audit log, empty fields match all entries
+type Filter struct {
+	Profile string
+	Command string
+	User    string
+	Since   time.Time
+	Until   time.Time
+	// Status is one of StatusSuccess, StatusFailure, StatusUnfinished or an exit code
+	Status string
+}
+
+// Entry is a command recorded in the audit log
+type Entry struct {
+	ID        string    `json:"id"`
+	Command   string    `json:"command"`
+	Args      string    `json:"args"`
+	Profile   string    `json:"profile"`
+	User      string    `json:"user"`
+	Version   string    `json:"version"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   string    `json:"endTime,omitempty"`
+	// ExitCode is nil for commands that did not finish, or were recorded before exit codes were
+	ExitCode *int   `json:"exitCode,omitempty"`
+	Duration string `json:"duration,omitempty"`
+
+	row row
+}
+
+// ValidateStatus returns an error if status is not a valid Filter status
+func ValidateStatus(status string) error {
+	switch status {
+	case "", StatusSuccess, StatusFailure, StatusUnfinished:
+		return nil
+	}
+	if _, err := strconv.Atoi(status); err != nil {
+		return fmt.Errorf("invalid status %q, must be one of %s, %s, %s or an exit code", status, StatusSuccess, StatusFailure, StatusUnfinished)
+	}
+	return nil
+}
+
+// Query returns the entries of the audit log and its archives matching the filter, oldest first
+func Query(f Filter) ([]Entry, error) {
+	var entries []Entry
+	for _, path := range auditFiles() {
+		rows, err := readRows(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			e := newEntry(r)
+			if f.matches(e) {
+				entries = append(entries, e)
+			}
+		}
+	}
+	return entries, nil
+}
+
+// readRows reads the rows of an audit log file, skipping invalid lines
+func readRows(path string) ([]row, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %v", path, err)
+	}
+	defer f.Close()
+
+	var rows []row
+	s := bufio.NewScanner(f)
+	s.Buffer(make([]byte, 64*1024), 1024*1024)
+	for s.Scan() {
+		r := row{}
+		if err := json.Unmarshal(s.Bytes(), &r); err != nil {
+			klog.Warningf("skipping invalid audit log entry %q: %v", s.Text(), err)
+			continue
+		}
+		r.assignFields()
+		rows = append(rows, r)
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read from %s: %v", path, err)
+	}
+	return rows, nil
+}
+
+func newEntry(r row) Entry {
+	e := Entry{
+		ID:       r.id,
+		Command:  r.command,
+		Args:     r.args,
+		Profile:  r.profile,
+		User:     r.user,
+		Version:  r.version,
+		EndTime:  r.endTime,
+		Duration: r.duration,
+		row:      r,
+	}
+	if e.ID == "" {
+		// entries logged before the row id was recorded are identified by their event id
+		e.ID = r.ID
+	}
+	if t, err := time.Parse(time.RFC3339, r.Time); err == nil {
+		e.StartTime = t
+	} else {
+		e.StartTime = parseTime(r.startTime)
+	}
+	if code, err := strconv.Atoi(r.exitCode); err == nil {
+		e.ExitCode = &code
+	}
+	return e
+}
+
+// parseTime parses the times of the audit log, recorded in constants.TimeFormat or RFC1123 by older versions
+func parseTime(s string) time.Time {
+	for _, layout := range []string{constants.TimeFormat, time.RFC1123} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (f Filter) matches(e Entry) bool {
+	switch {
+	case f.Profile != "" && e.Profile != f.Profile:
+		return false
+	case f.Command != "" && e.Command != f.Command:
+		return false
+	case f.User != "" && e.User != f.User:
+		return false
+	case !f.Since.IsZero() && e.StartTime.Before(f.Since):
+		return false
+	case !f.Until.IsZero() && e.StartTime.After(f.Until):
+		return false
+	}
+
+	switch f.Status {
+	case "":
+		return true
+	case StatusSuccess:
+		return e.ExitCode != nil && *e.ExitCode == 0
+	case StatusFailure:
+		return e.ExitCode != nil && *e.ExitCode != 0
+	case StatusUnfinished:
+		return e.EndTime == ""
+	}
+	code, err := strconv.Atoi(f.Status)
+	return err == nil && e.ExitCode != nil && *e.ExitCode == code
+}
+
+// Export writes the entries to w in one of the export formats
+func Export(w io.Writer, entries []Entry, format string) error {
+	switch format {
+	case FormatTable:
+		return exportTable(w, entries)
+	case FormatJSONLines:
+		enc := json.NewEncoder(w)
+		for _, e := range entries {
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatCSV:
+		return exportCSV(w, entries)
+	case FormatCloudEvents:
+		for _, e := range entries {
+			bs, err := json.Marshal(e.row)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintln(w, string(bs)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("invalid format %q, must be one of %s", format, strings.Join([]string{FormatTable, FormatJSONLines, FormatCSV, FormatCloudEvents}, ", "))
+}
+
+var exportHeaders = []string{"Command", "Args", "Profile", "User", "Version", "Start Time", "End Time", "Exit Code", "Duration"}
+
+func (e Entry) fields() []string {
+	exitCode := ""
+	if e.ExitCode != nil {
+		exitCode = strconv.Itoa(*e.ExitCode)
+	}
+	start := e.row.startTime
+	if !e.StartTime.IsZero() {
+		start = e.StartTime.Local().Format(constants.TimeFormat)
+	}
+	return []string{e.Command, e.Args, e.Profile, e.User, e.Version, start, e.EndTime, exitCode, e.Duration}
+}
+
+func exportTable(w io.Writer, entries []Entry) error {
+	t := tablewriter.NewWriter(w)
+	t.SetHeader(exportHeaders)
+	t.SetAutoFormatHeaders(false)
+	t.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+	t.SetCenterSeparator("|")
+	for _, e := range entries {
+		t.Append(e.fields())
+	}
+	t.Render()
+	return nil
+}
+
+func exportCSV(w io.Writer, entries []Entry) error {
+	cw := csv.NewWriter(w)
+	headers := append([]string{"ID"}, exportHeaders...)
+	if err := cw.Write(headers); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		fields := e.fields()
+		if !e.StartTime.IsZero() {
+			// machine readable start time, the table format is for humans
+			fields[5] = e.StartTime.Format(time.RFC3339)
+		}
+		if err := cw.Write(append([]string{e.ID}, fields...)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+const queryLog = `{"data":{"args":"-p mini1","command":"start","endTime":"03 Feb 21 15:33 MST","profile":"mini1","startTime":"03 Feb 21 15:30 MST","user":"user1","exitCode":"0","duration":"2m32.1s"},"datacontenttype":"application/json","id":"1","source":"https://minikube.sigs.k8s.io/","specversion":"1.0","type":"io.k8s.sigs.minikube.audit","time":"2021-02-03T15:30:33Z"}
+{"data":{"args":"-p mini1","command":"start","endTime":"04 Feb 21 10:01 MST","profile":"mini1","startTime":"04 Feb 21 10:00 MST","user":"user2","exitCode":"80","duration":"45s"},"datacontenttype":"application/json","id":"2","source":"https://minikube.sigs.k8s.io/","specversion":"1.0","type":"io.k8s.sigs.minikube.audit","time":"2021-02-04T10:00:00Z"}
+not json
+{"data":{"args":"","command":"addons","endTime":"","profile":"minikube","startTime":"Fri, 05 Feb 2021 09:00:00 UTC","user":"user1"},"datacontenttype":"application/json","id":"3","source":"https://minikube.sigs.k8s.io/","specversion":"1.0","type":"io.k8s.sigs.minikube.audit"}
+`
+
+func TestQuery(t *testing.T) {
+	dir := t.TempDir()
+	auditOverrideFilename = filepath.Join(dir, "audit.json")
+	defer func() { auditOverrideFilename = "" }()
+	if err := os.WriteFile(auditOverrideFilename, []byte(queryLog), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		ids    []string
+	}{
+		{"all", Filter{}, []string{"1", "2", "3"}},
+		{"profile", Filter{Profile: "mini1"}, []string{"1", "2"}},
+		{"user and command", Filter{User: "user1", Command: "start"}, []string{"1"}},
+		{"success", Filter{Status: StatusSuccess}, []string{"1"}},
+		{"failure", Filter{Status: StatusFailure}, []string{"2"}},
+		{"unfinished", Filter{Status: StatusUnfinished}, []string{"3"}},
+		{"exit code", Filter{Status: "80"}, []string{"2"}},
+		{"since", Filter{Since: time.Date(2021, 2, 4, 0, 0, 0, 0, time.UTC)}, []string{"2", "3"}},
+		{"until", Filter{Until: time.Date(2021, 2, 4, 0, 0, 0, 0, time.UTC)}, []string{"1"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			entries, err := Query(tc.filter)
+			if err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			var ids []string
+			for _, e := range entries {
+				ids = append(ids, e.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tc.ids, ",") {
+				t.Errorf("Query(%+v) = %v, expected %v", tc.filter, ids, tc.ids)
+			}
+		})
+	}
+
+	if err := ValidateStatus("crashed"); err == nil {
+		t.Errorf("ValidateStatus of an invalid status expected an error")
+	}
+}
+
+func TestExport(t *testing.T) {
+	code := 0
+	entries := []Entry{{ID: "1", Command: "start", Profile: "mini1", User: "user1", StartTime: time.Date(2021, 2, 3, 15, 30, 33, 0, time.UTC), ExitCode: &code, Duration: "2m32s"}}
+
+	var b bytes.Buffer
+	if err := Export(&b, entries, FormatCSV); err != nil {
+		t.Fatalf("Export(csv) error = %v", err)
+	}
+	records, err := csv.NewReader(&b).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid csv %q: %v", b.String(), err)
+	}
+	if len(records) != 2 || records[1][0] != "1" || records[1][6] != "2021-02-03T15:30:33Z" || records[1][8] != "0" {
+		t.Errorf("Export(csv) = %v", records)
+	}
+
+	b.Reset()
+	if err := Export(&b, entries, FormatJSONLines); err != nil {
+		t.Fatalf("Export(jsonl) error = %v", err)
+	}
+	if !strings.Contains(b.String(), `"exitCode":0`) || strings.Count(b.String(), "\n") != 1 {
+		t.Errorf("Export(jsonl) = %q", b.String())
+	}
+
+	if err := Export(&b, entries, "xml"); err == nil {
+		t.Errorf("Export of an invalid format expected an error")
+	}
+}
+
+func TestArchiveRows(t *testing.T) {
+	dir := t.TempDir()
+	auditOverrideFilename = filepath.Join(dir, "audit.json")
+	defer func() { auditOverrideFilename = "" }()
+	viper.Set(config.MaxAuditSize, 1)
+	defer viper.Set(config.MaxAuditSize, nil)
+
+	// an archive over the max size is rotated before new rows are added
+	if err := os.WriteFile(archivePath(1), bytes.Repeat([]byte("x"), 1024*1024), 0644); err != nil {
+		t.Fatal(err)
+	}
+	r := newRow("start", "", "user1", "v1.34.0", time.Now(), "1")
+	r.Data = r.toMap()
+	if err := archiveRows([]row{*r}); err != nil {
+		t.Fatalf("archiveRows() error = %v", err)
+	}
+	if st, err := os.Stat(archivePath(2)); err != nil || st.Size() != 1024*1024 {
+		t.Errorf("expected the full archive to be rotated to %s: %v", archivePath(2), err)
+	}
+	rows, err := readRows(archivePath(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].id != "1" {
+		t.Errorf("expected the new archive to hold the archived row, got %+v", rows)
+	}
+}
+
+func TestLogCommandExitKeepsLogWhenArchivingFails(t *testing.T) {
+	dir := t.TempDir()
+	auditOverrideFilename = filepath.Join(dir, "audit.json")
+	defer func() { auditOverrideFilename = "" }()
+	viper.Set(config.MaxAuditEntries, 1)
+	defer viper.Set(config.MaxAuditEntries, nil)
+
+	for _, id := range []string{"1", "2"} {
+		if err := appendToLog(newRow("start", "", "user1", "v1.34.0", time.Now(), id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a directory in place of the archive makes archiving fail
+	if err := os.Mkdir(archivePath(1), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := LogCommandExit("2", 0); err == nil {
+		t.Fatalf("LogCommandExit() expected an error when archiving fails")
+	}
+	rows, err := readRows(auditOverrideFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Errorf("expected the audit log to keep its 2 rows, got %d", len(rows))
+	}
+
+	if err := os.Remove(archivePath(1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := LogCommandExit("2", 3); err != nil {
+		t.Fatalf("LogCommandExit() error = %v", err)
+	}
+	rows, err = readRows(auditOverrideFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].id != "2" || rows[0].exitCode != "3" {
+		t.Errorf("expected the audit log to hold the exited row, got %+v", rows)
+	}
+	if archived, err := readRows(archivePath(1)); err != nil || len(archived) != 1 || archived[0].id != "1" {
+		t.Errorf("expected the archive to hold the first row, got %+v: %v", archived, err)
+	}
+}
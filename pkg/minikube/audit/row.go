@@ -35,10 +35,13 @@ type row struct {
 	Source          string            `json:"source"`
 	TypeField       string            `json:"type"`
 	DataContentType string            `json:"datacontenttype"`
+	Time            string            `json:"time,omitempty"`
 	Data            map[string]string `json:"data"`
 	args            string
 	command         string
+	duration        string
 	endTime         string
+	exitCode        string
 	id              string
 	profile         string
 	startTime       string
@@ -56,7 +59,9 @@ func (e *row) Type() string {
 func (e *row) assignFields() {
 	e.args = e.Data["args"]
 	e.command = e.Data["command"]
+	e.duration = e.Data["duration"]
 	e.endTime = e.Data["endTime"]
+	e.exitCode = e.Data["exitCode"]
 	e.profile = e.Data["profile"]
 	e.startTime = e.Data["startTime"]
 	e.user = e.Data["user"]
@@ -70,7 +75,9 @@ func (e *row) toMap() map[string]string {
 	return map[string]string{
 		"args":      e.args,
 		"command":   e.command,
+		"duration":  e.duration,
 		"endTime":   e.endTime,
+		"exitCode":  e.exitCode,
 		"profile":   e.profile,
 		"startTime": e.startTime,
 		"user":      e.user,
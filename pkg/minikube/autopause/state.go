@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autopause
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+)
+
+var (
+	// Dir is where the auto-pause daemon keeps its state and history within the node
+	Dir = filepath.Join(vmpath.GuestPersistentDir, "auto-pause")
+	// StateFile is the file holding the current state of the auto-pause daemon
+	StateFile = filepath.Join(Dir, "state.json")
+	// HistoryFile is the file the pauses and unpauses are appended to, as JSON lines
+	HistoryFile = filepath.Join(Dir, "history.jsonl")
+
+	// maxHistorySize is the size of the history at which it is rotated, only the previous history is kept
+	maxHistorySize int64 = 1024 * 1024
+)
+
+// Actions of the auto-pause daemon
+const (
+	Paused   = "pause"
+	Unpaused = "unpause"
+)
+
+// State is the current state of the auto-pause daemon
+type State struct {
+	Paused bool `json:"paused"`
+	// LastActivity is when an API request or service traffic was last seen
+	LastActivity time.Time `json:"lastActivity"`
+	// PauseAt is when the cluster will be paused if it stays idle
+	PauseAt time.Time `json:"pauseAt"`
+	// Blocked is the never-pause window in effect, if any
+	Blocked string `json:"blocked,omitempty"`
+}
+
+// Describe returns a short human readable description of the state, as of now
+func (s State) Describe(now time.Time) string {
+	switch {
+	case s.Paused:
+		return fmt.Sprintf("Paused (idle since %s)", s.LastActivity.Local().Format(time.Kitchen))
+	case s.Blocked != "":
+		return fmt.Sprintf("Active (never pausing during %s)", s.Blocked)
+	}
+	left := s.PauseAt.Sub(now).Round(time.Second)
+	if left < 0 {
+		left = 0
+	}
+	return fmt.Sprintf("Pausing in %s", left)
+}
+
+// Entry is an entry of the pause and unpause history
+type Entry struct {
+	Time       time.Time `json:"time"`
+	Action     string    `json:"action"`
+	Reason     string    `json:"reason"`
+	Containers int       `json:"containers"`
+}
+
+// WriteState saves the state, it is meant to be called by the daemon within the node
+func WriteState(s State) error {
+	if err := os.MkdirAll(Dir, 0755); err != nil {
+		return err
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	tmp := StateFile + ".tmp"
+	if err := os.WriteFile(tmp, b, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, StateFile)
+}
+
+// Record appends an entry to the history, it is meant to be called by the daemon within the node
+func Record(e Entry) error {
+	if err := os.MkdirAll(Dir, 0755); err != nil {
+		return err
+	}
+	if fi, err := os.Stat(HistoryFile); err == nil && fi.Size() > maxHistorySize {
+		if err := os.Rename(HistoryFile, HistoryFile+".1"); err != nil {
+			return err
+		}
+	}
+	f, err := os.OpenFile(HistoryFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(b, '\n'))
+	return err
+}
+
+// ReadState reads the state of the auto-pause daemon of the node behind r
+func ReadState(r command.Runner) (State, error) {
+	var s State
+	rr, err := r.RunCmd(exec.Command("sudo", "cat", StateFile))
+	if err != nil {
+		return s, errors.Wrap(err, "reading auto-pause state")
+	}
+	if err := json.Unmarshal(rr.Stdout.Bytes(), &s); err != nil {
+		return s, errors.Wrap(err, "parsing auto-pause state")
+	}
+	return s, nil
+}
+
+// ReadHistory reads the pause and unpause history of the node behind r, oldest first
+func ReadHistory(r command.Runner) ([]Entry, error) {
+	// the previous history may not exist, and neither may the current one if the cluster was never paused
+	script := fmt.Sprintf("sudo cat %s.1 %s 2>/dev/null || true", HistoryFile, HistoryFile)
+	rr, err := r.RunCmd(exec.Command("/bin/bash", "-c", script))
+	if err != nil {
+		return nil, errors.Wrap(err, "reading auto-pause history")
+	}
+	return parseHistory(rr.Stdout.Bytes()), nil
+}
+
+func parseHistory(b []byte) []Entry {
+	var es []Entry
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			continue
+		}
+		var e Entry
+		// skip lines torn by a crash rather than losing the whole history
+		if err := json.Unmarshal([]byte(line), &e); err == nil {
+			es = append(es, e)
+		}
+	}
+	return es
+}
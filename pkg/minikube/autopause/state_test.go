@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autopause
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDescribe(t *testing.T) {
+	now := time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		state State
+		want  string
+	}{
+		{"countdown", State{PauseAt: now.Add(45 * time.Second)}, "Pausing in 45s"},
+		{"overdue", State{PauseAt: now.Add(-time.Second)}, "Pausing in 0s"},
+		{"blocked", State{PauseAt: now, Blocked: "Mon-Fri 09:00-18:00"}, "Active (never pausing during Mon-Fri 09:00-18:00)"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.state.Describe(now); got != tc.want {
+				t.Errorf("Describe() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseHistory(t *testing.T) {
+	history := `{"time":"2024-06-03T12:00:00Z","action":"pause","reason":"idle for 1m0s","containers":8}
+{"time":"2024-06-03T12:05:00Z","action":"unp
+{"time":"2024-06-03T12:06:00Z","action":"unpause","reason":"API request","containers":8}
+`
+	es := parseHistory([]byte(history))
+	if len(es) != 2 {
+		t.Fatalf("parseHistory() returned %d entries, want 2: %+v", len(es), es)
+	}
+	if es[0].Action != Paused || es[1].Action != Unpaused || es[1].Reason != "API request" {
+		t.Errorf("parseHistory() = %+v", es)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autopause
+
+import (
+	"bufio"
+	"bytes"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/command"
+)
+
+// ServiceConnections returns how many connections kube-proxy has forwarded to NodePort and LoadBalancer services so far.
+// Only connections from outside the cluster go through these rules, so a growing count means a user is reaching a service.
+// The count drops when kube-proxy rewrites its rules, which resets their counters.
+// It is read from the iptables rules of kube-proxy, so it is always 0 when kube-proxy runs in ipvs or nftables mode.
+func ServiceConnections(r command.Runner) (uint64, error) {
+	rr, err := r.RunCmd(exec.Command("sudo", "iptables-save", "-t", "nat", "-c"))
+	if err != nil {
+		return 0, errors.Wrap(err, "iptables-save")
+	}
+	return parseServiceConnections(rr.Stdout.Bytes()), nil
+}
+
+// parseServiceConnections sums the packet counters of the rules of the KUBE-NODEPORTS chain and of the load balancer rules of
+// the KUBE-SERVICES chain, as printed by iptables-save -c. The nat table only sees the first packet of each connection.
+func parseServiceConnections(b []byte) uint64 {
+	var total uint64
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for s.Scan() {
+		line := s.Text()
+		// [pkts:bytes] -A CHAIN ...
+		if !strings.HasPrefix(line, "[") {
+			continue
+		}
+		counters, rule, ok := strings.Cut(line[1:], "] ")
+		if !ok {
+			continue
+		}
+		if !strings.HasPrefix(rule, "-A KUBE-NODEPORTS ") && !(strings.HasPrefix(rule, "-A KUBE-SERVICES ") && strings.Contains(rule, "loadbalancer IP")) {
+			continue
+		}
+		pkts, _, _ := strings.Cut(counters, ":")
+		n, err := strconv.ParseUint(pkts, 10, 64)
+		if err != nil {
+			continue
+		}
+		total += n
+	}
+	return total
+}
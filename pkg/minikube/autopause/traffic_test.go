@@ -0,0 +1,36 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autopause
+
+import "testing"
+
+func TestParseServiceConnections(t *testing.T) {
+	save := `# Generated by iptables-save v1.8.9
+*nat
+:KUBE-NODEPORTS - [0:0]
+:KUBE-SERVICES - [0:0]
+[12:720] -A KUBE-NODEPORTS -p tcp -m comment --comment "default/web:http" -m tcp --dport 30080 -j KUBE-EXT-ABC
+[3:180] -A KUBE-NODEPORTS -p tcp -m comment --comment "default/api:http" -m tcp --dport 30081 -j KUBE-EXT-DEF
+[5:300] -A KUBE-SERVICES -d 10.96.12.7/32 -p tcp -m comment --comment "default/lb:http loadbalancer IP" -m tcp --dport 80 -j KUBE-EXT-GHI
+[900:54000] -A KUBE-SERVICES -d 10.96.0.10/32 -p udp -m comment --comment "kube-system/kube-dns:dns cluster IP" -m udp --dport 53 -j KUBE-SVC-TCOU
+[40:2400] -A KUBE-SERVICES -m comment --comment "kubernetes service nodeports" -m addrtype --dst-type LOCAL -j KUBE-NODEPORTS
+COMMIT
+`
+	if got := parseServiceConnections([]byte(save)); got != 20 {
+		t.Errorf("parseServiceConnections() = %d, want 20", got)
+	}
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package autopause holds the policy, state and history of the auto-pause addon, shared by its daemon and the minikube CLI
+package autopause
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var weekdays = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+// Window is a recurring time window, like "Mon-Fri 09:00-18:00"
+type Window struct {
+	// Days are the days of the week the window starts on, every day if empty
+	Days map[time.Weekday]bool
+	// Start and End are offsets from midnight, End is before Start if the window wraps around midnight
+	Start time.Duration
+	End   time.Duration
+
+	spec string
+}
+
+// String returns the window as it was given
+func (w Window) String() string {
+	return w.spec
+}
+
+// ParseWindow parses a window given as "[DAYS ]HH:MM-HH:MM", where DAYS is a comma separated list of days or ranges of days
+func ParseWindow(s string) (Window, error) {
+	w := Window{spec: strings.TrimSpace(s)}
+	fields := strings.Fields(s)
+	var hours string
+	switch len(fields) {
+	case 1:
+		hours = fields[0]
+	case 2:
+		days, err := parseDays(fields[0])
+		if err != nil {
+			return w, err
+		}
+		w.Days = days
+		hours = fields[1]
+	default:
+		return w, fmt.Errorf("invalid window %q, expected [DAYS ]HH:MM-HH:MM", s)
+	}
+
+	start, end, ok := strings.Cut(hours, "-")
+	if !ok {
+		return w, fmt.Errorf("invalid hours %q, expected HH:MM-HH:MM", hours)
+	}
+	var err error
+	if w.Start, err = parseClock(start); err != nil {
+		return w, err
+	}
+	if w.End, err = parseClock(end); err != nil {
+		return w, err
+	}
+	if w.Start == w.End {
+		return w, fmt.Errorf("invalid hours %q, the window is empty", hours)
+	}
+	return w, nil
+}
+
+// ParseWindows parses a list of windows
+func ParseWindows(specs []string) ([]Window, error) {
+	var ws []Window
+	for _, s := range specs {
+		w, err := ParseWindow(s)
+		if err != nil {
+			return nil, err
+		}
+		ws = append(ws, w)
+	}
+	return ws, nil
+}
+
+// Contains returns whether t falls within the window, in the location of t
+func (w Window) Contains(t time.Time) bool {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	since := t.Sub(midnight)
+	if w.Start < w.End {
+		return w.startsOn(t.Weekday()) && since >= w.Start && since < w.End
+	}
+	// the window wraps around midnight, it either started today or yesterday
+	if since >= w.Start {
+		return w.startsOn(t.Weekday())
+	}
+	return since < w.End && w.startsOn((t.Weekday()+6)%7)
+}
+
+func (w Window) startsOn(d time.Weekday) bool {
+	return len(w.Days) == 0 || w.Days[d]
+}
+
+// Active returns the first of the windows t falls within
+func Active(ws []Window, t time.Time) (Window, bool) {
+	for _, w := range ws {
+		if w.Contains(t) {
+			return w, true
+		}
+	}
+	return Window{}, false
+}
+
+func parseClock(s string) (time.Duration, error) {
+	h, m, ok := strings.Cut(s, ":")
+	if !ok {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", s)
+	}
+	hh, err := strconv.Atoi(h)
+	if err != nil || hh < 0 || hh > 24 {
+		return 0, fmt.Errorf("invalid hour in %q", s)
+	}
+	mm, err := strconv.Atoi(m)
+	if err != nil || mm < 0 || mm > 59 || (hh == 24 && mm != 0) {
+		return 0, fmt.Errorf("invalid minute in %q", s)
+	}
+	return time.Duration(hh)*time.Hour + time.Duration(mm)*time.Minute, nil
+}
+
+func parseDays(s string) (map[time.Weekday]bool, error) {
+	days := map[time.Weekday]bool{}
+	for _, part := range strings.Split(s, ",") {
+		first, last, isRange := strings.Cut(part, "-")
+		from, err := parseDay(first)
+		if err != nil {
+			return nil, err
+		}
+		to := from
+		if isRange {
+			if to, err = parseDay(last); err != nil {
+				return nil, err
+			}
+		}
+		for d := from; ; d = (d + 1) % 7 {
+			days[d] = true
+			if d == to {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+func parseDay(s string) (time.Weekday, error) {
+	for i, d := range weekdays {
+		if strings.EqualFold(s, d) {
+			return time.Weekday(i), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid day %q, expected one of %s", s, strings.Join(weekdays, ", "))
+}
+
+// LocalTimeZone returns the local time zone for LoadTimeZone: its IANA name like Europe/Berlin when it is known,
+// or else the current offset from UTC like UTC+02:00, which does not follow daylight saving time
+func LocalTimeZone() string {
+	if tz := strings.TrimPrefix(os.Getenv("TZ"), ":"); tz != "" {
+		if _, err := time.LoadLocation(tz); err == nil {
+			return tz
+		}
+	}
+	if p, err := filepath.EvalSymlinks("/etc/localtime"); err == nil {
+		if _, name, ok := strings.Cut(p, "zoneinfo/"); ok {
+			if _, err := time.LoadLocation(name); err == nil {
+				return name
+			}
+		}
+	}
+	_, offset := time.Now().Zone()
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	return fmt.Sprintf("UTC%c%02d:%02d", sign, offset/3600, offset%3600/60)
+}
+
+// LoadTimeZone returns the time zone of an IANA name like Europe/Berlin, or of an offset from UTC like UTC+02:00
+func LoadTimeZone(name string) (*time.Location, error) {
+	if offset, ok := strings.CutPrefix(name, "UTC"); ok && offset != "" {
+		sign := 1
+		switch offset[0] {
+		case '+':
+		case '-':
+			sign = -1
+		default:
+			return nil, fmt.Errorf("invalid time zone %q", name)
+		}
+		hours, minutes, _ := strings.Cut(offset[1:], ":")
+		h, err := strconv.Atoi(hours)
+		if err != nil || h > 14 {
+			return nil, fmt.Errorf("invalid time zone %q", name)
+		}
+		m := 0
+		if minutes != "" {
+			if m, err = strconv.Atoi(minutes); err != nil || m > 59 {
+				return nil, fmt.Errorf("invalid time zone %q", name)
+			}
+		}
+		return time.FixedZone(name, sign*(h*3600+m*60)), nil
+	}
+	return time.LoadLocation(name)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autopause
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWindow(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantErr bool
+	}{
+		{"09:00-18:00", false},
+		{"Mon-Fri 09:00-18:00", false},
+		{"Sat,Sun 10:00-12:00", false},
+		{"Fri-Mon 22:00-06:00", false},
+		{"mon 00:00-24:00", false},
+		{"09:00", true},
+		{"09:00-09:00", true},
+		{"25:00-26:00", true},
+		{"09:60-10:00", true},
+		{"Someday 09:00-10:00", true},
+		{"Mon 09:00-10:00 extra", true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.spec, func(t *testing.T) {
+			w, err := ParseWindow(tc.spec)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseWindow(%q) error = %v, wantErr %v", tc.spec, err, tc.wantErr)
+			}
+			if err == nil && w.String() != tc.spec {
+				t.Errorf("String() = %q, want %q", w.String(), tc.spec)
+			}
+		})
+	}
+}
+
+func TestWindowContains(t *testing.T) {
+	// 2024-06-03 is a Monday
+	at := func(day int, hhmm string) time.Time {
+		c, err := parseClock(hhmm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return time.Date(2024, 6, day, 0, 0, 0, 0, time.UTC).Add(c)
+	}
+	tests := []struct {
+		spec string
+		t    time.Time
+		want bool
+	}{
+		{"09:00-18:00", at(3, "09:00"), true},
+		{"09:00-18:00", at(3, "17:59"), true},
+		{"09:00-18:00", at(3, "18:00"), false},
+		{"09:00-18:00", at(3, "08:59"), false},
+		{"Mon-Fri 09:00-18:00", at(7, "12:00"), true},
+		{"Mon-Fri 09:00-18:00", at(8, "12:00"), false},
+		{"Sat,Sun 10:00-12:00", at(9, "11:00"), true},
+		{"Sat,Sun 10:00-12:00", at(10, "11:00"), false},
+		// wraps around midnight: Friday night until Saturday morning
+		{"Fri 22:00-06:00", at(7, "23:00"), true},
+		{"Fri 22:00-06:00", at(8, "05:59"), true},
+		{"Fri 22:00-06:00", at(8, "06:00"), false},
+		{"Fri 22:00-06:00", at(7, "05:00"), false},
+		// ranges may wrap around the end of the week
+		{"Fri-Mon 09:00-10:00", at(9, "09:30"), true},
+		{"Fri-Mon 09:00-10:00", at(4, "09:30"), false},
+	}
+	for _, tc := range tests {
+		w, err := ParseWindow(tc.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := w.Contains(tc.t); got != tc.want {
+			t.Errorf("%q.Contains(%s) = %t, want %t", tc.spec, tc.t.Format("Mon 15:04"), got, tc.want)
+		}
+	}
+}
+
+func TestActive(t *testing.T) {
+	ws, err := ParseWindows([]string{"Sat 10:00-12:00", "Mon-Fri 09:00-18:00"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, ok := Active(ws, time.Date(2024, 6, 4, 10, 0, 0, 0, time.UTC))
+	if !ok || w.String() != "Mon-Fri 09:00-18:00" {
+		t.Errorf("Active() = %q, %t, want the weekday window", w, ok)
+	}
+	if _, ok := Active(ws, time.Date(2024, 6, 9, 10, 0, 0, 0, time.UTC)); ok {
+		t.Errorf("Active() on a Sunday = true, want false")
+	}
+}
+
+func TestLoadTimeZone(t *testing.T) {
+	summer := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	winter := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		summer, winter int
+	}{
+		// the offset of a named zone follows daylight saving time
+		{"Europe/Berlin", 2 * 3600, 3600},
+		{"UTC+05:30", 5*3600 + 30*60, 5*3600 + 30*60},
+		{"UTC-03", -3 * 3600, -3 * 3600},
+		{"UTC", 0, 0},
+	}
+	for _, tc := range tests {
+		loc, err := LoadTimeZone(tc.name)
+		if err != nil {
+			t.Errorf("LoadTimeZone(%q) error = %v", tc.name, err)
+			continue
+		}
+		if _, got := summer.In(loc).Zone(); got != tc.summer {
+			t.Errorf("LoadTimeZone(%q) summer offset = %d, expected %d", tc.name, got, tc.summer)
+		}
+		if _, got := winter.In(loc).Zone(); got != tc.winter {
+			t.Errorf("LoadTimeZone(%q) winter offset = %d, expected %d", tc.name, got, tc.winter)
+		}
+	}
+	for _, name := range []string{"UTC*02:00", "UTC+2x", "UTC+02:75", "Mars/Olympus"} {
+		if _, err := LoadTimeZone(name); err == nil {
+			t.Errorf("LoadTimeZone(%q) expected an error", name)
+		}
+	}
+
+	t.Setenv("TZ", "America/New_York")
+	if got := LocalTimeZone(); got != "America/New_York" {
+		t.Errorf("LocalTimeZone() = %q, expected the zone of TZ", got)
+	}
+	if _, err := LoadTimeZone(LocalTimeZone()); err != nil {
+		t.Errorf("LoadTimeZone(LocalTimeZone()) error = %v", err)
+	}
+}
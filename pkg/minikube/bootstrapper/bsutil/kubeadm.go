@@ -136,7 +136,7 @@ func GenerateKubeadmYAML(cc config.ClusterConfig, n config.Node, r cruntime.Mana
 		CertDir:           vmpath.GuestKubernetesCertsDir,
 		ServiceCIDR:       constants.DefaultServiceCIDR,
 		PodSubnet:         podCIDR,
-		AdvertiseAddress:  n.IP,
+		AdvertiseAddress:  config.NodeIP(cc, n),
 		APIServerPort:     nodePort,
 		KubernetesVersion: k8s.KubernetesVersion,
 		EtcdDataDir:       EtcdDataDir(),
@@ -149,7 +149,7 @@ func GenerateKubeadmYAML(cc config.ClusterConfig, n config.Node, r cruntime.Mana
 		ComponentOptions:           componentOpts,
 		FeatureArgs:                kubeadmFeatureArgs,
 		DNSDomain:                  k8s.DNSDomain,
-		NodeIP:                     n.IP,
+		NodeIP:                     config.NodeIPs(cc, n),
 		CgroupDriver:               cgroupDriver,
 		ClientCAFile:               path.Join(vmpath.GuestKubernetesCertsDir, "ca.crt"),
 		StaticPodPath:              vmpath.GuestManifestsDir,
@@ -162,6 +162,12 @@ func GenerateKubeadmYAML(cc config.ClusterConfig, n config.Node, r cruntime.Mana
 	if k8s.ServiceCIDR != "" {
 		opts.ServiceCIDR = k8s.ServiceCIDR
 	}
+	ipv6ServiceCIDR := constants.DefaultIPv6ServiceCIDR
+	if k8s.IPv6ServiceCIDR != "" {
+		ipv6ServiceCIDR = k8s.IPv6ServiceCIDR
+	}
+	// kubeadm takes comma separated subnets for dual-stack clusters, primary family first
+	opts.ServiceCIDR = config.JoinIPFamilies(cc, opts.ServiceCIDR, ipv6ServiceCIDR)
 
 	configTmpl := ktmpl.V1Beta1
 	// v1beta2 isn't required until v1.17.
@@ -315,6 +315,60 @@ func TestGenerateKubeadmYAML(t *testing.T) {
 	}
 }
 
+func TestGenerateKubeadmYAMLIPFamilies(t *testing.T) {
+	fcr := command.NewFakeCommandRunner()
+	fcr.SetCommandToOutput(map[string]string{
+		"sudo crictl info": "{\"config\": {\"containerd\": {\"runtimes\": {\"runc\": {\"options\": {\"SystemdCgroup\": true}}}}}}",
+	})
+	runtime, err := cruntime.New(cruntime.Config{Type: constants.Containerd, Runner: fcr, Socket: "/run/containerd/containerd.sock"})
+	if err != nil {
+		t.Fatalf("runtime: %v", err)
+	}
+	tests := []struct {
+		family string
+		want   []string
+	}{
+		{"ipv6", []string{
+			"advertiseAddress: fd00:192:168:49::2",
+			"node-ip: fd00:192:168:49::2",
+			`podSubnet: "fd00:10:244::/56"`,
+			"serviceSubnet: fd00:10:96::/112",
+			`clusterCIDR: "fd00:10:244::/56"`,
+		}},
+		{"dual", []string{
+			"advertiseAddress: 192.168.49.2",
+			"node-ip: 192.168.49.2,fd00:192:168:49::2",
+			`podSubnet: "10.244.0.0/16,fd00:10:244::/56"`,
+			"serviceSubnet: 10.96.0.0/12,fd00:10:96::/112",
+			`clusterCIDR: "10.244.0.0/16,fd00:10:244::/56"`,
+		}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.family, func(t *testing.T) {
+			cfg := config.ClusterConfig{
+				Name:     "mk",
+				IPFamily: tc.family,
+				KubernetesConfig: config.KubernetesConfig{
+					KubernetesVersion: constants.DefaultKubernetesVersion,
+					ContainerRuntime:  constants.Containerd,
+					CNI:               "kindnet",
+					IPv6ServiceCIDR:   constants.DefaultIPv6ServiceCIDR,
+				},
+				Nodes: []config.Node{{IP: "192.168.49.2", IPv6: "fd00:192:168:49::2", Name: "mk", ControlPlane: true}},
+			}
+			got, err := GenerateKubeadmYAML(cfg, cfg.Nodes[0], runtime)
+			if err != nil {
+				t.Fatalf("GenerateKubeadmYAML() error = %v", err)
+			}
+			for _, w := range tc.want {
+				if !strings.Contains(string(got), w) {
+					t.Errorf("kubeadm config does not contain %q:\n%s", w, got)
+				}
+			}
+		})
+	}
+}
+
 func TestEtcdExtraArgs(t *testing.T) {
 	expected := map[string]string{
 		"key": "value",
@@ -83,7 +83,7 @@ func extraKubeletOpts(mc config.ClusterConfig, nc config.Node, r cruntime.Manage
 	}
 
 	if _, ok := extraOpts["node-ip"]; !ok {
-		extraOpts["node-ip"] = nc.IP
+		extraOpts["node-ip"] = config.NodeIPs(mc, nc)
 	}
 
 	if _, ok := extraOpts["hostname-override"]; !ok {
@@ -43,12 +43,14 @@ import (
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/cruntime"
 	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/trace"
 	"k8s.io/minikube/pkg/util/retry"
 	kconst "k8s.io/minikube/third_party/kubeadm/app/constants"
 )
 
 // WaitForAPIServerProcess waits for api server to be healthy returns error if it doesn't
 func WaitForAPIServerProcess(r cruntime.Manager, bs bootstrapper.Bootstrapper, cfg config.ClusterConfig, cr command.Runner, start time.Time, timeout time.Duration) error {
+	defer trace.StartAsync("kverify.WaitForAPIServerProcess").End()
 	klog.Infof("waiting for apiserver process to appear ...")
 	err := wait.PollUntilContextTimeout(context.Background(), time.Millisecond*500, timeout, true, func(_ context.Context) (bool, error) {
 		if time.Since(start) > timeout {
@@ -56,7 +58,7 @@ func WaitForAPIServerProcess(r cruntime.Manager, bs bootstrapper.Bootstrapper, c
 		}
 
 		if time.Since(start) > minLogCheckTime {
-			announceProblems(r, bs, cfg, cr)
+			announceProblems(r, bs, cfg, cr, nil)
 			time.Sleep(kconst.APICallRetryInterval * 5)
 		}
 
@@ -85,6 +87,7 @@ func APIServerPID(cr command.Runner) (int, error) {
 
 // WaitForHealthyAPIServer waits for api server status to be running
 func WaitForHealthyAPIServer(r cruntime.Manager, bs bootstrapper.Bootstrapper, cfg config.ClusterConfig, cr command.Runner, client *kubernetes.Clientset, start time.Time, hostname string, port int, timeout time.Duration) error {
+	defer trace.StartAsync("kverify.WaitForHealthyAPIServer").End()
 	klog.Infof("waiting for apiserver healthz status ...")
 	hStart := time.Now()
 
@@ -94,7 +97,7 @@ func WaitForHealthyAPIServer(r cruntime.Manager, bs bootstrapper.Bootstrapper, c
 		}
 
 		if time.Since(start) > minLogCheckTime {
-			announceProblems(r, bs, cfg, cr)
+			announceProblems(r, bs, cfg, cr, nil)
 			time.Sleep(kconst.APICallRetryInterval * 5)
 		}
 
@@ -249,8 +252,18 @@ func apiServerHealthz(hostname string, port int) (state.State, error) {
 
 // apiServerHealthzNow hits the /healthz endpoint and returns libmachine style state.State
 func apiServerHealthzNow(hostname string, port int) (state.State, error) {
-	url := fmt.Sprintf("https://%s/healthz", net.JoinHostPort(hostname, fmt.Sprint(port)))
-	klog.Infof("Checking apiserver healthz at %s ...", url)
+	return apiServerEndpointNow(hostname, port, "healthz", 5*time.Second)
+}
+
+// APIServerReadyz hits the /readyz endpoint once, for checks repeated often that have to return quickly
+func APIServerReadyz(hostname string, port int, timeout time.Duration) (state.State, error) {
+	return apiServerEndpointNow(hostname, port, "readyz", timeout)
+}
+
+// apiServerEndpointNow hits a health endpoint of the apiserver and returns libmachine style state.State
+func apiServerEndpointNow(hostname string, port int, endpoint string, timeout time.Duration) (state.State, error) {
+	url := fmt.Sprintf("https://%s/%s", net.JoinHostPort(hostname, fmt.Sprint(port)), endpoint)
+	klog.Infof("Checking apiserver %s at %s ...", endpoint, url)
 	cert, err := os.ReadFile(localpath.CACert())
 	if err != nil {
 		klog.Infof("ca certificate: %v", err)
@@ -262,7 +275,7 @@ func apiServerHealthzNow(hostname string, port int) (state.State, error) {
 		Proxy:           nil, // Avoid using a proxy to speak to a local host
 		TLSClientConfig: &tls.Config{RootCAs: pool},
 	}
-	client := &http.Client{Transport: tr, Timeout: 5 * time.Second}
+	client := &http.Client{Transport: tr, Timeout: timeout}
 	resp, err := client.Get(url)
 	// Connection refused, usually.
 	if err != nil {
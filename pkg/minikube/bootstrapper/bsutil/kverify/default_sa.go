@@ -26,11 +26,13 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/trace"
 	kconst "k8s.io/minikube/third_party/kubeadm/app/constants"
 )
 
 // WaitForDefaultSA waits for the default service account to be created.
 func WaitForDefaultSA(cs *kubernetes.Clientset, timeout time.Duration) error {
+	defer trace.StartAsync("kverify.WaitForDefaultSA").End()
 	klog.Info("waiting for default service account to be created ...")
 	start := time.Now()
 	saReady := func(ctx context.Context) (bool, error) {
@@ -22,11 +22,14 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/problem"
+	"k8s.io/minikube/pkg/trace"
 	"k8s.io/minikube/pkg/util/retry"
 	kconst "k8s.io/minikube/third_party/kubeadm/app/constants"
 )
@@ -99,6 +102,7 @@ func (e *ErrNetworkNotReady) Error() string {
 
 // NodePressure verfies that node is not under disk, memory, pid or network pressure.
 func NodePressure(cs *kubernetes.Clientset) error {
+	defer trace.StartAsync("kverify.NodePressure").End()
 	klog.Info("verifying NodePressure condition ...")
 	start := time.Now()
 	defer func() {
@@ -121,33 +125,59 @@ func NodePressure(cs *kubernetes.Clientset) error {
 	for _, n := range ns.Items {
 		klog.Infof("node storage ephemeral capacity is %s", n.Status.Capacity.StorageEphemeral())
 		klog.Infof("node cpu capacity is %s", n.Status.Capacity.Cpu().AsDec())
-		for _, c := range n.Status.Conditions {
-			pc := NodeCondition{Type: c.Type, Status: c.Status, Reason: c.Reason, Message: c.Message}
-			if pc.DiskPressure() {
-				return &ErrDiskPressure{
-					NodeCondition: pc,
-				}
-			}
+		if errs := UnwantedConditions(n); len(errs) > 0 {
+			return errs[0]
+		}
+	}
+	return nil
+}
 
-			if pc.MemoryPressure() {
-				return &ErrMemoryPressure{
-					NodeCondition: pc,
-				}
-			}
+// UnwantedConditions returns the disk, memory, pid and network pressure conditions of a node as errors
+func UnwantedConditions(n v1.Node) []error {
+	var errs []error
+	for _, c := range n.Status.Conditions {
+		pc := NodeCondition{Type: c.Type, Status: c.Status, Reason: c.Reason, Message: c.Message}
+		if pc.DiskPressure() {
+			errs = append(errs, &ErrDiskPressure{
+				NodeCondition: pc,
+			})
+		}
 
-			if pc.PIDPressure() {
-				return &ErrPIDPressure{
-					NodeCondition: pc,
-				}
-			}
+		if pc.MemoryPressure() {
+			errs = append(errs, &ErrMemoryPressure{
+				NodeCondition: pc,
+			})
+		}
 
-			if pc.NetworkUnavailable() {
-				return &ErrNetworkNotReady{
-					NodeCondition: pc,
-				}
-			}
+		if pc.PIDPressure() {
+			errs = append(errs, &ErrPIDPressure{
+				NodeCondition: pc,
+			})
+		}
 
+		if pc.NetworkUnavailable() {
+			errs = append(errs, &ErrNetworkNotReady{
+				NodeCondition: pc,
+			})
 		}
 	}
-	return nil
+	return errs
+}
+
+// NodeProblems matches the unwanted conditions of the nodes against the problem rules
+func NodeProblems(cs kubernetes.Interface, e *problem.Engine, version semver.Version) []problem.Match {
+	ns, err := cs.CoreV1().Nodes().List(context.Background(), meta.ListOptions{})
+	if err != nil {
+		klog.Warningf("unable to list nodes for problems: %v", err)
+		return nil
+	}
+	var matches []problem.Match
+	for _, n := range ns.Items {
+		for _, err := range UnwantedConditions(n) {
+			if rule := e.Match(problem.NodeComponent, err.Error(), version); rule != nil {
+				matches = append(matches, problem.Match{Rule: rule, Source: n.Name, Line: err.Error()})
+			}
+		}
+	}
+	return matches
 }
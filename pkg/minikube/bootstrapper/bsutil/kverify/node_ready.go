@@ -22,16 +22,19 @@ import (
 	"fmt"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	core "k8s.io/api/core/v1"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/trace"
 	kconst "k8s.io/minikube/third_party/kubeadm/app/constants"
 )
 
 // WaitNodeCondition waits for specified condition of node name.
 func WaitNodeCondition(cs *kubernetes.Clientset, name string, condition core.NodeConditionType, timeout time.Duration) error {
+	defer trace.StartAsync("kverify.WaitNodeCondition", attribute.String("node", name), attribute.String("condition", string(condition))).End()
 	klog.Infof("waiting up to %v for node %q to be %q ...", timeout, name, condition)
 	start := time.Now()
 	defer func() {
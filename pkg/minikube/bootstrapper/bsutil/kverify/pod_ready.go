@@ -28,11 +28,13 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/trace"
 	kconst "k8s.io/minikube/third_party/kubeadm/app/constants"
 )
 
 // WaitExtra calls waitPodCondition for all system-critical pods including those with specified labels.
 func WaitExtra(cs *kubernetes.Clientset, labels []string, timeout time.Duration) error {
+	defer trace.StartAsync("kverify.WaitExtra").End()
 	klog.Infof("extra waiting up to %v for all system-critical pods including labels %v to be %q ...", timeout, labels, core.PodReady)
 	start := time.Now()
 	defer func() {
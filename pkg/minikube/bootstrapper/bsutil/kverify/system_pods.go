@@ -34,18 +34,21 @@ import (
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/cruntime"
 	"k8s.io/minikube/pkg/minikube/logs"
+	"k8s.io/minikube/pkg/minikube/problem"
+	"k8s.io/minikube/pkg/trace"
 	"k8s.io/minikube/pkg/util/retry"
 	kconst "k8s.io/minikube/third_party/kubeadm/app/constants"
 )
 
 // WaitForSystemPods verifies essential pods for running kurnetes is running
 func WaitForSystemPods(r cruntime.Manager, bs bootstrapper.Bootstrapper, cfg config.ClusterConfig, cr command.Runner, client *kubernetes.Clientset, start time.Time, timeout time.Duration) error {
+	defer trace.StartAsync("kverify.WaitForSystemPods").End()
 	klog.Info("waiting for kube-system pods to appear ...")
 	pStart := time.Now()
 
 	podList := func() error {
 		if time.Since(start) > minLogCheckTime {
-			announceProblems(r, bs, cfg, cr)
+			announceProblems(r, bs, cfg, cr, client)
 			time.Sleep(kconst.APICallRetryInterval * 5)
 		}
 
@@ -113,6 +116,7 @@ func ExpectAppsRunning(cs *kubernetes.Clientset, expected []string) error {
 
 // WaitForAppsRunning waits for expected Apps To be running
 func WaitForAppsRunning(cs *kubernetes.Clientset, expected []string, timeout time.Duration) error {
+	defer trace.StartAsync("kverify.WaitForAppsRunning").End()
 	klog.Info("waiting for k8s-apps to be running ...")
 	start := time.Now()
 
@@ -147,11 +151,14 @@ func podStatusMsg(pod core.Pod) string {
 	return sb.String()
 }
 
-// announceProblems checks for problems, and slows polling down if any are found
-func announceProblems(r cruntime.Manager, bs bootstrapper.Bootstrapper, cfg config.ClusterConfig, cr command.Runner) {
-	problems := logs.FindProblems(r, bs, cfg, cr)
+// announceProblems checks the logs, and the node state when the API server is up, for problems, and slows polling down if any are found
+func announceProblems(r cruntime.Manager, bs bootstrapper.Bootstrapper, cfg config.ClusterConfig, cr command.Runner, client kubernetes.Interface) {
+	problems := logs.DetectProblems(r, bs, cfg, cr)
+	if client != nil {
+		problems = append(problems, NodeProblems(client, problem.Default(), problem.ParseKubernetesVersion(cfg.KubernetesConfig.KubernetesVersion))...)
+	}
 	if len(problems) > 0 {
-		logs.OutputProblems(problems, 5, os.Stderr)
+		logs.OutputMatches(problems, 5, os.Stderr)
 		time.Sleep(kconst.APICallRetryInterval * 15)
 	}
 }
@@ -267,6 +267,20 @@ func generateProfileCerts(cfg config.ClusterConfig, n config.Node, shared shared
 	// append ip addresses of all control-plane nodes
 	for _, n := range config.ControlPlanes(cfg) {
 		apiServerIPs = append(apiServerIPs, net.ParseIP(n.IP))
+		if config.HasIPv6(cfg) && n.IPv6 != "" {
+			apiServerIPs = append(apiServerIPs, net.ParseIP(n.IPv6))
+		}
+	}
+	if config.HasIPv6(cfg) {
+		ipv6ServiceCIDR := k8s.IPv6ServiceCIDR
+		if ipv6ServiceCIDR == "" {
+			ipv6ServiceCIDR = constants.DefaultIPv6ServiceCIDR
+		}
+		ipv6ServiceIP, err := util.ServiceClusterIP(ipv6ServiceCIDR)
+		if err != nil {
+			return nil, errors.Wrap(err, "get IPv6 service cluster ip")
+		}
+		apiServerIPs = append(apiServerIPs, ipv6ServiceIP, net.IPv6loopback)
 	}
 	if config.IsHA(cfg) {
 		apiServerIPs = append(apiServerIPs, net.ParseIP(cfg.KubernetesConfig.APIServerHAVIP))
@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bugreport bundles the diagnostics of a cluster into a redacted tarball, described by a manifest
+package bugreport
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// ManifestName is the name of the manifest in the bundle
+const ManifestName = "manifest.json"
+
+// File is an entry of the manifest
+type File struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Size        int    `json:"size"`
+	// Error is why the contents could not be collected, there is no file in the bundle then
+	Error string `json:"error,omitempty"`
+}
+
+// Manifest describes the contents of a bundle
+type Manifest struct {
+	Profile string    `json:"profile"`
+	Version string    `json:"minikubeVersion"`
+	Created time.Time `json:"created"`
+	// Redacted is the note on what was redacted from the files
+	Redacted string `json:"redacted"`
+	Files    []File `json:"files"`
+}
+
+// Bundle collects the redacted files of a bug report
+type Bundle struct {
+	manifest Manifest
+	contents map[string][]byte
+}
+
+// New returns an empty bundle for a profile
+func New(profile, version string) *Bundle {
+	return &Bundle{
+		manifest: Manifest{
+			Profile:  profile,
+			Version:  version,
+			Created:  time.Now().UTC(),
+			Redacted: "passwords, tokens, secrets, credentials in URLs and private keys are replaced by " + redacted,
+		},
+		contents: map[string][]byte{},
+	}
+}
+
+// Add adds a text file to the bundle, redacted
+func (b *Bundle) Add(name, description string, content []byte) {
+	content = Redact(content)
+	b.contents[name] = content
+	b.manifest.Files = append(b.manifest.Files, File{Name: name, Description: description, Size: len(content)})
+}
+
+// AddJSON adds a JSON file to the bundle, with the values of the secret keys redacted
+func (b *Bundle) AddJSON(name, description string, content []byte) {
+	rc, err := RedactJSON(content)
+	if err != nil {
+		klog.Warningf("%s is not valid JSON, redacting it as text: %v", name, err)
+		b.Add(name, description, content)
+		return
+	}
+	b.Add(name, description, rc)
+}
+
+// AddError records in the manifest that a file could not be collected
+func (b *Bundle) AddError(name, description string, err error) {
+	klog.Warningf("unable to collect %s for the bug report: %v", name, err)
+	b.manifest.Files = append(b.manifest.Files, File{Name: name, Description: description, Error: string(Redact([]byte(err.Error())))})
+}
+
+// Manifest returns the manifest of the bundle
+func (b *Bundle) Manifest() Manifest {
+	return b.manifest
+}
+
+// Write writes the bundle as a gzipped tarball, the manifest first
+func (b *Bundle) Write(w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	manifest, err := json.MarshalIndent(b.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFile(tw, ManifestName, manifest, b.manifest.Created); err != nil {
+		return err
+	}
+	for _, f := range b.manifest.Files {
+		content, ok := b.contents[f.Name]
+		if !ok {
+			continue
+		}
+		if err := writeFile(tw, f.Name, content, b.manifest.Created); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func writeFile(tw *tar.Writer, name string, content []byte, modTime time.Time) error {
+	hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), ModTime: modTime}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("writing %s: %v", name, err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		return fmt.Errorf("writing %s: %v", name, err)
+	}
+	return nil
+}
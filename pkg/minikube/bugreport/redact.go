@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bugreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// redacted replaces the secrets in the files
+const redacted = "<redacted>"
+
+var (
+	// privateKeyRe matches PEM private keys
+	privateKeyRe = regexp.MustCompile(`-----BEGIN ([A-Z ]*)PRIVATE KEY-----[\s\S]*?-----END ([A-Z ]*)PRIVATE KEY-----`)
+	// urlCredentialsRe matches the user info of URLs, like proxy credentials
+	urlCredentialsRe = regexp.MustCompile(`://[^/\s:@"']+:[^/\s@"']+@`)
+	// bearerRe matches bearer tokens of authorization headers
+	bearerRe = regexp.MustCompile(`(?i)(bearer\s+)[^\s"']+`)
+	// jwtRe matches JSON web tokens, like service account tokens
+	jwtRe = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)
+	// bootstrapTokenRe matches kubeadm bootstrap tokens
+	bootstrapTokenRe = regexp.MustCompile(`\b[a-z0-9]{6}\.[a-z0-9]{16}\b`)
+	// assignmentRe matches the values assigned to secret keys, like "password: x", "--token=x" or "client-secret x"
+	assignmentRe = regexp.MustCompile(`(?i)((?:password|passwd|token|secret|api[_-]?key|credentials?)["']?\s*[:=]\s*["']?)[^\s"',]+`)
+)
+
+// secretKeys are the parts of JSON keys whose values are redacted
+var secretKeys = []string{"password", "passwd", "token", "secret", "apikey", "api_key", "credential", "privatekey"}
+
+// Redact replaces the secrets found in text
+func Redact(content []byte) []byte {
+	content = privateKeyRe.ReplaceAll(content, []byte("-----BEGIN ${1}PRIVATE KEY-----"+redacted+"-----END ${2}PRIVATE KEY-----"))
+	content = urlCredentialsRe.ReplaceAll(content, []byte("://"+redacted+"@"))
+	content = bearerRe.ReplaceAll(content, []byte("${1}"+redacted))
+	content = jwtRe.ReplaceAll(content, []byte(redacted))
+	content = bootstrapTokenRe.ReplaceAll(content, []byte(redacted))
+	return assignmentRe.ReplaceAll(content, []byte("${1}"+redacted))
+}
+
+// RedactJSON replaces the values of the secret keys of a JSON document, and the secrets found in its strings
+func RedactJSON(content []byte) ([]byte, error) {
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(content))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	out, err := json.MarshalIndent(redactValue(v), "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func redactValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			if isSecretKey(k) {
+				if s, ok := val.(string); ok && s == "" {
+					continue
+				}
+				t[k] = redacted
+				continue
+			}
+			t[k] = redactValue(val)
+		}
+		return t
+	case []interface{}:
+		for i := range t {
+			t[i] = redactValue(t[i])
+		}
+		return t
+	case string:
+		return string(Redact([]byte(t)))
+	}
+	return v
+}
+
+func isSecretKey(key string) bool {
+	k := strings.ToLower(key)
+	for _, s := range secretKeys {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,330 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil/kverify"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+// controlPlaneComponents maps the component names of the status to the component label of their static pods
+var controlPlaneComponents = map[string]string{
+	"etcd":               "etcd",
+	"controller-manager": "kube-controller-manager",
+	"scheduler":          "kube-scheduler",
+}
+
+// cniDaemonSets are the daemonsets of the CNIs minikube can deploy, bridge and custom CNIs have none
+var cniDaemonSets = []struct {
+	namespace string
+	name      string
+}{
+	{"kube-system", "kindnet"},
+	{"kube-system", "calico-node"},
+	{"kube-flannel", "kube-flannel-ds"},
+	{"kube-system", "cilium"},
+}
+
+// waitingErrors are the reasons of waiting containers that will not recover on their own
+var waitingErrors = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+}
+
+// AddComponentHealth adds the health of the Kubernetes components, the CNI, the enabled addons and the node conditions to a cluster state.
+// It should only be called when the API server is running, as it queries it.
+func AddComponentHealth(cs *State, client kubernetes.Interface, cc *config.ClusterConfig) error {
+	ctx := context.Background()
+	pods, err := client.CoreV1().Pods(meta.NamespaceAll).List(ctx, meta.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "list pods")
+	}
+	nodes, err := client.CoreV1().Nodes().List(ctx, meta.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "list nodes")
+	}
+
+	if cs.Components == nil {
+		cs.Components = map[string]BaseState{}
+	}
+	cs.Components["coredns"] = podsState("coredns", filterPods(pods.Items, "kube-system", labels.SelectorFromSet(labels.Set{"k8s-app": "kube-dns"})))
+	if cc.Addons["storage-provisioner"] {
+		cs.Components["storage-provisioner"] = podsState("storage-provisioner", podsNamed(pods.Items, "kube-system", "storage-provisioner"))
+	}
+	if ds := cniDaemonSet(ctx, client); ds != nil {
+		selector, err := meta.LabelSelectorAsSelector(ds.Spec.Selector)
+		if err != nil {
+			return errors.Wrapf(err, "selector of daemonset %s", ds.Name)
+		}
+		cs.Components["cni"] = podsState(ds.Name, filterPods(pods.Items, ds.Namespace, selector))
+	}
+
+	cs.Addons = addonsState(pods.Items, cc)
+
+	controlPlanes := map[string]bool{}
+	for _, n := range cc.Nodes {
+		controlPlanes[config.MachineName(*cc, n)] = n.ControlPlane
+	}
+	for i := range cs.Nodes {
+		ns := &cs.Nodes[i]
+		if ns.Components == nil {
+			ns.Components = map[string]BaseState{}
+		}
+		if controlPlanes[ns.Name] {
+			for name, component := range controlPlaneComponents {
+				selector := labels.SelectorFromSet(labels.Set{"component": component})
+				ns.Components[name] = podsState(name, podsOnNode(filterPods(pods.Items, "kube-system", selector), ns.Name))
+			}
+		}
+		for _, n := range nodes.Items {
+			if n.Name == ns.Name {
+				ns.Components["conditions"] = conditionsState(n)
+			}
+		}
+	}
+
+	degrade(cs)
+	return nil
+}
+
+// cniDaemonSet returns the daemonset of the CNI deployed to the cluster, or nil if it has none
+func cniDaemonSet(ctx context.Context, client kubernetes.Interface) *apps.DaemonSet {
+	for _, d := range cniDaemonSets {
+		ds, err := client.AppsV1().DaemonSets(d.namespace).Get(ctx, d.name, meta.GetOptions{})
+		if err == nil {
+			return ds
+		}
+		klog.Infof("no CNI daemonset %s/%s: %v", d.namespace, d.name, err)
+	}
+	return nil
+}
+
+// addonsState returns the state of the enabled addons that run workloads, found by the images of their pods
+func addonsState(pods []v1.Pod, cc *config.ClusterConfig) map[string]BaseState {
+	states := map[string]BaseState{}
+	for name, enabled := range cc.Addons {
+		addon, ok := assets.Addons[name]
+		// storage-provisioner has its own component
+		if !enabled || !ok || name == "storage-provisioner" || len(addon.Images) == 0 {
+			continue
+		}
+		var repos []string
+		for image, ref := range addon.Images {
+			if custom, ok := cc.CustomAddonImages[image]; ok {
+				ref = custom
+			}
+			repos = append(repos, imageRepository(ref))
+		}
+		var matched []v1.Pod
+		for _, p := range pods {
+			if podRunsImage(p, repos) {
+				matched = append(matched, p)
+			}
+		}
+		states[name] = podsState(name, matched)
+	}
+	return states
+}
+
+// podsState returns the state of a component from the state of its pods
+func podsState(name string, pods []v1.Pod) BaseState {
+	bs := BaseState{Name: name}
+	if len(pods) == 0 {
+		bs.StatusCode = NotFound
+		bs.StatusName = codeNames[NotFound]
+		bs.StatusDetail = fmt.Sprintf("no %s pods were found", name)
+		return bs
+	}
+
+	ready := 0
+	var problems []string
+	failing := false
+	for _, p := range pods {
+		if podReady(p) {
+			ready++
+			continue
+		}
+		problem := fmt.Sprintf("pod %s is %s", p.Name, p.Status.Phase)
+		for _, c := range p.Status.ContainerStatuses {
+			if w := c.State.Waiting; w != nil && w.Reason != "" {
+				problem = fmt.Sprintf("pod %s container %s is %s", p.Name, c.Name, w.Reason)
+				failing = failing || waitingErrors[w.Reason]
+			}
+		}
+		if p.Status.Phase == v1.PodFailed {
+			failing = true
+		}
+		problems = append(problems, problem)
+	}
+	sort.Strings(problems)
+
+	switch {
+	case ready == len(pods):
+		bs.StatusCode = OK
+	case ready > 0:
+		bs.StatusCode = Degraded
+	case failing:
+		bs.StatusCode = Error
+	default:
+		// pending or not ready yet, without errors that need help to resolve
+		bs.StatusCode = Starting
+	}
+	bs.StatusName = codeNames[bs.StatusCode]
+	bs.StatusDetail = codeDetails[bs.StatusCode]
+	if len(problems) > 0 {
+		bs.StatusDetail = fmt.Sprintf("%d of %d pods are ready: %s", ready, len(pods), strings.Join(problems, ", "))
+	}
+	return bs
+}
+
+// conditionsState returns the state of the pressure conditions of a node detected by kverify
+func conditionsState(n v1.Node) BaseState {
+	bs := BaseState{Name: "conditions", StatusCode: OK, StatusName: codeNames[OK], StatusDetail: "the node has no disk, memory, PID or network pressure"}
+	var problems []string
+	for _, err := range kverify.UnwantedConditions(n) {
+		problems = append(problems, err.Error())
+	}
+	if len(problems) > 0 {
+		bs.StatusCode = Warning
+		bs.StatusName = codeNames[Warning]
+		bs.StatusDetail = strings.Join(problems, ", ")
+	}
+	return bs
+}
+
+// degrade lowers the status of a healthy cluster when some of its components or addons are not healthy
+func degrade(cs *State) {
+	if cs.StatusCode != OK && cs.StatusCode != OKHAppy {
+		return
+	}
+	var unhealthy, warnings []string
+	check := func(name string, bs BaseState) {
+		switch {
+		case bs.StatusCode == Warning:
+			warnings = append(warnings, name)
+		case bs.StatusCode >= 300 || bs.StatusCode < 200:
+			unhealthy = append(unhealthy, name)
+		}
+	}
+	for name, bs := range cs.Components {
+		check(name, bs)
+	}
+	for name, bs := range cs.Addons {
+		check("addon "+name, bs)
+	}
+	for _, ns := range cs.Nodes {
+		for name, bs := range ns.Components {
+			check(ns.Name+" "+name, bs)
+		}
+	}
+	sort.Strings(unhealthy)
+	sort.Strings(warnings)
+
+	switch {
+	case len(unhealthy) > 0:
+		cs.StatusCode = Degraded
+		cs.StatusDetail = "unhealthy components: " + strings.Join(unhealthy, ", ")
+	case len(warnings) > 0:
+		cs.StatusCode = Warning
+		cs.StatusDetail = "components with warnings: " + strings.Join(warnings, ", ")
+	default:
+		return
+	}
+	cs.StatusName = codeNames[cs.StatusCode]
+}
+
+func filterPods(pods []v1.Pod, namespace string, selector labels.Selector) []v1.Pod {
+	var matched []v1.Pod
+	for _, p := range pods {
+		if p.Namespace == namespace && selector.Matches(labels.Set(p.Labels)) {
+			matched = append(matched, p)
+		}
+	}
+	return matched
+}
+
+func podsNamed(pods []v1.Pod, namespace, name string) []v1.Pod {
+	var matched []v1.Pod
+	for _, p := range pods {
+		if p.Namespace == namespace && p.Name == name {
+			matched = append(matched, p)
+		}
+	}
+	return matched
+}
+
+func podsOnNode(pods []v1.Pod, node string) []v1.Pod {
+	var matched []v1.Pod
+	for _, p := range pods {
+		if p.Spec.NodeName == node {
+			matched = append(matched, p)
+		}
+	}
+	return matched
+}
+
+func podReady(p v1.Pod) bool {
+	if p.Status.Phase == v1.PodSucceeded {
+		return true
+	}
+	for _, c := range p.Status.Conditions {
+		if c.Type == v1.PodReady {
+			return c.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// podRunsImage returns if one of the containers of a pod runs an image of one of the repositories
+func podRunsImage(p v1.Pod, repos []string) bool {
+	containers := append(append([]v1.Container{}, p.Spec.InitContainers...), p.Spec.Containers...)
+	for _, c := range containers {
+		image := imageRepository(c.Image)
+		for _, repo := range repos {
+			if image == repo || strings.HasSuffix(image, "/"+repo) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// imageRepository returns the repository of an image reference, without its tag and digest
+func imageRepository(ref string) string {
+	ref = strings.SplitN(ref, "@", 2)[0]
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		ref = ref[:i]
+	}
+	return ref
+}
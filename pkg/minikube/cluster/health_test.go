@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"strings"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func testPod(namespace, name, node, image string, podLabels map[string]string, ready bool, waiting string) *v1.Pod {
+	status := v1.ConditionFalse
+	if ready {
+		status = v1.ConditionTrue
+	}
+	p := &v1.Pod{
+		ObjectMeta: meta.ObjectMeta{Namespace: namespace, Name: name, Labels: podLabels},
+		Spec:       v1.PodSpec{NodeName: node, Containers: []v1.Container{{Name: "c", Image: image}}},
+		Status: v1.PodStatus{
+			Phase:      v1.PodRunning,
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: status}},
+		},
+	}
+	if waiting != "" {
+		p.Status.ContainerStatuses = []v1.ContainerStatus{{Name: "c", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: waiting}}}}
+	}
+	return p
+}
+
+func TestAddComponentHealth(t *testing.T) {
+	objects := []runtime.Object{
+		testPod("kube-system", "etcd-minikube", "minikube", "registry.k8s.io/etcd:3.5.15-0", map[string]string{"component": "etcd"}, true, ""),
+		testPod("kube-system", "kube-controller-manager-minikube", "minikube", "registry.k8s.io/kube-controller-manager:v1.31.0", map[string]string{"component": "kube-controller-manager"}, true, ""),
+		testPod("kube-system", "kube-scheduler-minikube", "minikube", "registry.k8s.io/kube-scheduler:v1.31.0", map[string]string{"component": "kube-scheduler"}, false, "CrashLoopBackOff"),
+		testPod("kube-system", "coredns-1", "minikube", "registry.k8s.io/coredns/coredns:v1.11.1", map[string]string{"k8s-app": "kube-dns"}, true, ""),
+		testPod("kube-system", "storage-provisioner", "minikube", "gcr.io/k8s-minikube/storage-provisioner:v5", nil, true, ""),
+		testPod("kube-system", "kindnet-abc", "minikube", "docker.io/kindest/kindnetd:v20240813", map[string]string{"app": "kindnet"}, true, ""),
+		testPod("kube-system", "metrics-server-1", "minikube", "registry.k8s.io/metrics-server/metrics-server:v0.7.2@sha256:ffcb2bf004d6aa0a17d90e0247cf94f2865c8901dcab4427034c341951c239f9", nil, false, ""),
+		&apps.DaemonSet{
+			ObjectMeta: meta.ObjectMeta{Namespace: "kube-system", Name: "kindnet"},
+			Spec:       apps.DaemonSetSpec{Selector: &meta.LabelSelector{MatchLabels: map[string]string{"app": "kindnet"}}},
+		},
+		&v1.Node{
+			ObjectMeta: meta.ObjectMeta{Name: "minikube"},
+			Status:     v1.NodeStatus{Conditions: []v1.NodeCondition{{Type: v1.NodeDiskPressure, Status: v1.ConditionTrue, Reason: "KubeletHasDiskPressure"}}},
+		},
+	}
+	client := fake.NewSimpleClientset(objects...)
+
+	cc := &config.ClusterConfig{
+		Name:   "minikube",
+		Nodes:  []config.Node{{Name: "", ControlPlane: true}},
+		Addons: map[string]bool{"storage-provisioner": true, "metrics-server": true, "default-storageclass": true},
+	}
+	cs := State{
+		BaseState:  BaseState{Name: "minikube", StatusCode: OK, StatusName: "OK"},
+		Components: map[string]BaseState{"kubeconfig": {Name: "kubeconfig", StatusCode: OK}},
+		Nodes:      []NodeState{{BaseState: BaseState{Name: "minikube", StatusCode: OK}, Components: map[string]BaseState{"kubelet": {Name: "kubelet", StatusCode: OK}}}},
+	}
+	if err := AddComponentHealth(&cs, client, cc); err != nil {
+		t.Fatalf("AddComponentHealth() error = %v", err)
+	}
+
+	codes := map[string]int{
+		"coredns":             cs.Components["coredns"].StatusCode,
+		"storage-provisioner": cs.Components["storage-provisioner"].StatusCode,
+		"cni":                 cs.Components["cni"].StatusCode,
+		"etcd":                cs.Nodes[0].Components["etcd"].StatusCode,
+		"controller-manager":  cs.Nodes[0].Components["controller-manager"].StatusCode,
+		"scheduler":           cs.Nodes[0].Components["scheduler"].StatusCode,
+		"conditions":          cs.Nodes[0].Components["conditions"].StatusCode,
+		"metrics-server":      cs.Addons["metrics-server"].StatusCode,
+	}
+	expected := map[string]int{
+		"coredns":             OK,
+		"storage-provisioner": OK,
+		"cni":                 OK,
+		"etcd":                OK,
+		"controller-manager":  OK,
+		"scheduler":           Error,
+		"conditions":          Warning,
+		"metrics-server":      Starting,
+	}
+	for name, code := range expected {
+		if codes[name] != code {
+			t.Errorf("%s status code = %d, expected %d", name, codes[name], code)
+		}
+	}
+	if _, ok := cs.Addons["default-storageclass"]; ok {
+		t.Errorf("addons without images should not be reported")
+	}
+	if detail := cs.Nodes[0].Components["scheduler"].StatusDetail; !strings.Contains(detail, "CrashLoopBackOff") {
+		t.Errorf("scheduler detail = %q, expected the waiting reason", detail)
+	}
+	if cs.StatusCode != Degraded || !strings.Contains(cs.StatusDetail, "minikube scheduler") {
+		t.Errorf("cluster status = %d %q, expected degraded by the scheduler", cs.StatusCode, cs.StatusDetail)
+	}
+}
+
+func TestAddComponentHealthDisabledStorageProvisioner(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		testPod("kube-system", "etcd-minikube", "minikube", "registry.k8s.io/etcd:3.5.15-0", map[string]string{"component": "etcd"}, true, ""),
+		testPod("kube-system", "kube-controller-manager-minikube", "minikube", "registry.k8s.io/kube-controller-manager:v1.31.0", map[string]string{"component": "kube-controller-manager"}, true, ""),
+		testPod("kube-system", "kube-scheduler-minikube", "minikube", "registry.k8s.io/kube-scheduler:v1.31.0", map[string]string{"component": "kube-scheduler"}, true, ""),
+		testPod("kube-system", "coredns-1", "minikube", "registry.k8s.io/coredns/coredns:v1.11.1", map[string]string{"k8s-app": "kube-dns"}, true, ""),
+		&v1.Node{ObjectMeta: meta.ObjectMeta{Name: "minikube"}},
+	)
+	cc := &config.ClusterConfig{
+		Name:   "minikube",
+		Nodes:  []config.Node{{Name: "", ControlPlane: true}},
+		Addons: map[string]bool{"storage-provisioner": false},
+	}
+	cs := State{
+		BaseState: BaseState{Name: "minikube", StatusCode: OK, StatusName: "OK"},
+		Nodes:     []NodeState{{BaseState: BaseState{Name: "minikube", StatusCode: OK}}},
+	}
+	if err := AddComponentHealth(&cs, client, cc); err != nil {
+		t.Fatalf("AddComponentHealth() error = %v", err)
+	}
+	if sp, ok := cs.Components["storage-provisioner"]; ok {
+		t.Errorf("storage-provisioner is disabled but reported: %+v", sp)
+	}
+	if cs.StatusCode == Degraded {
+		t.Errorf("cluster status = %d %q, expected it not to be degraded by a disabled addon", cs.StatusCode, cs.StatusDetail)
+	}
+}
+
+func TestImageRepository(t *testing.T) {
+	tests := map[string]string{
+		"registry.k8s.io/metrics-server/metrics-server:v0.7.2@sha256:ffcb": "registry.k8s.io/metrics-server/metrics-server",
+		"localhost:5000/registry:2.8.3":                                    "localhost:5000/registry",
+		"localhost:5000/registry":                                          "localhost:5000/registry",
+		"busybox":                                                          "busybox",
+	}
+	for ref, expected := range tests {
+		if got := imageRepository(ref); got != expected {
+			t.Errorf("imageRepository(%q) = %q, expected %q", ref, got, expected)
+		}
+	}
+}
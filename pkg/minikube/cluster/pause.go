@@ -17,6 +17,7 @@ limitations under the License.
 package cluster
 
 import (
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -28,11 +29,36 @@ import (
 	"k8s.io/minikube/pkg/util/retry"
 )
 
+// Selection selects the containers to pause or unpause
+type Selection struct {
+	// Namespaces is the namespaces to look into, nil for all
+	Namespaces []string
+	// Pods is the names of the pods to look into, empty for all
+	Pods []string
+	// Labels is the labels, as key=value, the pods to look into must all have
+	Labels []string
+}
+
+// options returns the options listing the selected containers in state
+func (sel Selection) options(state cruntime.ContainerState) cruntime.ListContainersOptions {
+	return cruntime.ListContainersOptions{State: state, Namespaces: sel.Namespaces, Pods: sel.Pods, Labels: sel.Labels}
+}
+
 // Pause pauses a Kubernetes cluster, retrying if necessary
 func Pause(cr cruntime.Manager, r command.Runner, namespaces []string) ([]string, error) {
+	return PauseExcept(cr, r, namespaces, nil)
+}
+
+// PauseExcept pauses a Kubernetes cluster but the containers in except, retrying if necessary
+func PauseExcept(cr cruntime.Manager, r command.Runner, namespaces []string, except []string) ([]string, error) {
+	return PauseSelection(cr, r, Selection{Namespaces: namespaces}, except)
+}
+
+// PauseSelection pauses the selected containers but the ones in except, retrying if necessary
+func PauseSelection(cr cruntime.Manager, r command.Runner, sel Selection, except []string) ([]string, error) {
 	var ids []string
 	tryPause := func() (err error) {
-		ids, err = pause(cr, r, namespaces)
+		ids, err = pause(cr, r, sel, except)
 		return err
 	}
 
@@ -42,22 +68,30 @@ func Pause(cr cruntime.Manager, r command.Runner, namespaces []string) ([]string
 	return ids, nil
 }
 
-// pause pauses a Kubernetes cluster
-func pause(cr cruntime.Manager, r command.Runner, namespaces []string) ([]string, error) {
+// pause pauses the selected containers
+func pause(cr cruntime.Manager, r command.Runner, sel Selection, except []string) ([]string, error) {
 	ids := []string{}
 
-	// Disable the kubelet so it does not attempt to restart paused pods
+	system, err := affectsKubeSystem(cr, sel)
+	if err != nil {
+		return ids, errors.Wrap(err, "list kube-system")
+	}
+
+	// Disable the kubelet so it does not attempt to restart paused pods, unless only workloads are paused
 	sm := sysinit.New(r)
 	klog.Info("kubelet running: ", sm.Active("kubelet"))
 
-	if err := sm.DisableNow("kubelet"); err != nil {
+	if !system {
+		klog.Infof("keeping kubelet running, %+v does not affect kube-system", sel)
+	} else if err := sm.DisableNow("kubelet"); err != nil {
 		return ids, errors.Wrap(err, "kubelet disable --now")
 	}
 
-	ids, err := cr.ListContainers(cruntime.ListContainersOptions{State: cruntime.Running, Namespaces: namespaces})
+	ids, err = cr.ListContainers(sel.options(cruntime.Running))
 	if err != nil {
 		return ids, errors.Wrap(err, "list running")
 	}
+	ids = withoutContainers(ids, except)
 
 	if len(ids) == 0 {
 		klog.Warningf("no running containers to pause")
@@ -68,18 +102,41 @@ func pause(cr cruntime.Manager, r command.Runner, namespaces []string) ([]string
 		return ids, errors.Wrap(err, "pausing containers")
 	}
 
-	if doesNamespaceContainKubeSystem(namespaces) {
+	if system {
 		pkgpause.CreatePausedFile(r)
 	}
 
 	return ids, nil
 }
 
+// affectsKubeSystem returns whether sel selects running containers of kube-system, which need the kubelet stopped
+func affectsKubeSystem(cr cruntime.Manager, sel Selection) (bool, error) {
+	if !doesNamespaceContainKubeSystem(sel.Namespaces) {
+		return false, nil
+	}
+	if len(sel.Pods) == 0 && len(sel.Labels) == 0 {
+		return true, nil
+	}
+
+	o := sel.options(cruntime.Running)
+	o.Namespaces = []string{"kube-system"}
+	ids, err := cr.ListContainers(o)
+	if err != nil {
+		return false, err
+	}
+	return len(ids) > 0, nil
+}
+
 // Unpause unpauses a Kubernetes cluster, retrying if necessary
 func Unpause(cr cruntime.Manager, r command.Runner, namespaces []string) ([]string, error) {
+	return UnpauseSelection(cr, r, Selection{Namespaces: namespaces})
+}
+
+// UnpauseSelection unpauses the selected containers, retrying if necessary
+func UnpauseSelection(cr cruntime.Manager, r command.Runner, sel Selection) ([]string, error) {
 	var ids []string
 	tryUnpause := func() (err error) {
-		ids, err = unpause(cr, r, namespaces)
+		ids, err = unpause(cr, r, sel)
 		return err
 	}
 
@@ -89,9 +146,9 @@ func Unpause(cr cruntime.Manager, r command.Runner, namespaces []string) ([]stri
 	return ids, nil
 }
 
-// unpause unpauses a Kubernetes cluster
-func unpause(cr cruntime.Manager, r command.Runner, namespaces []string) ([]string, error) {
-	ids, err := cr.ListContainers(cruntime.ListContainersOptions{State: cruntime.Paused, Namespaces: namespaces})
+// unpause unpauses the selected containers
+func unpause(cr cruntime.Manager, r command.Runner, sel Selection) ([]string, error) {
+	ids, err := cr.ListContainers(sel.options(cruntime.Paused))
 	if err != nil {
 		return ids, errors.Wrap(err, "list paused")
 	}
@@ -102,19 +159,52 @@ func unpause(cr cruntime.Manager, r command.Runner, namespaces []string) ([]stri
 		return ids, errors.Wrap(err, "unpause")
 	}
 
+	// The kubelet stays stopped while kube-system has paused containers left
+	paused, err := CheckIfPaused(cr, []string{"kube-system"})
+	if err != nil {
+		return ids, errors.Wrap(err, "check kube-system")
+	}
+	if paused {
+		klog.Infof("keeping kubelet stopped, kube-system is still paused")
+		return ids, nil
+	}
+
 	sm := sysinit.New(r)
 
 	if err := sm.Start("kubelet"); err != nil {
 		return ids, errors.Wrap(err, "kubelet start")
 	}
 
-	if doesNamespaceContainKubeSystem(namespaces) {
+	if doesNamespaceContainKubeSystem(sel.Namespaces) {
 		pkgpause.RemovePausedFile(r)
 	}
 
 	return ids, nil
 }
 
+// withoutContainers returns the ids not in except, runtimes may shorten the ids so prefixes match
+func withoutContainers(ids []string, except []string) []string {
+	if len(except) == 0 {
+		return ids
+	}
+	kept := []string{}
+	for _, id := range ids {
+		exempt := false
+		for _, e := range except {
+			if e != "" && (strings.HasPrefix(id, e) || strings.HasPrefix(e, id)) {
+				exempt = true
+				break
+			}
+		}
+		if exempt {
+			klog.Infof("not pausing exempt container %s", id)
+			continue
+		}
+		kept = append(kept, id)
+	}
+	return kept
+}
+
 // CheckIfPaused checks if the Kubernetes cluster is paused
 func CheckIfPaused(cr cruntime.Manager, namespaces []string) (bool, error) {
 	ids, err := cr.ListContainers(cruntime.ListContainersOptions{State: cruntime.Paused, Namespaces: namespaces})
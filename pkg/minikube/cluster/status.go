@@ -31,6 +31,7 @@ import (
 	"github.com/pkg/errors"
 
 	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/autopause"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil/kverify"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/constants"
@@ -38,6 +39,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/kubeconfig"
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/netchaos"
 	"k8s.io/minikube/pkg/minikube/out/register"
 	"k8s.io/minikube/pkg/version"
 )
@@ -74,9 +76,10 @@ const (
 
 	// 4xx signifies an error that requires help from the client to resolve
 
-	NotFound = 404
-	Stopped  = 405
-	Paused   = 418 // I'm a teapot!
+	NotFound  = 404
+	Stopped   = 405
+	Paused    = 418 // I'm a teapot!
+	Suspended = 419
 
 	// 5xx signifies a server-side error (that may be retryable)
 
@@ -96,7 +99,7 @@ var (
 		101: "Pausing",
 		102: "Unpausing",
 		110: "Stopping",
-		103: "Deleting",
+		120: "Deleting",
 
 		200: "OK",
 		201: "OKHAppy",
@@ -106,6 +109,7 @@ var (
 		404: "NotFound",
 		405: "Stopped",
 		418: "Paused",
+		419: "Suspended",
 
 		500: "Error",
 		507: "InsufficientStorage",
@@ -113,7 +117,25 @@ var (
 	}
 
 	codeDetails = map[int]string{
+		100: "minikube is starting the cluster",
+		101: "minikube is pausing the cluster",
+		102: "minikube is unpausing the cluster",
+		110: "minikube is stopping the cluster",
+		120: "minikube is deleting the cluster",
+
+		200: "running and able to serve requests",
+		201: "all control-plane nodes are running and able to serve requests",
+		203: "running, but reporting warnings",
+		204: "running, but some components or control-plane nodes are not healthy",
+
+		404: "does not exist",
+		405: "stopped",
+		418: "paused, run `minikube unpause` to resume it",
+		419: "suspended, run `minikube resume` to resume it",
+
+		500: "failed, check `minikube logs` for details",
 		507: "/var is almost out of disk space",
+		520: "the state could not be determined",
 	}
 )
 
@@ -128,6 +150,12 @@ type Status struct {
 	TimeToStop string `json:",omitempty"`
 	DockerEnv  string `json:",omitempty"`
 	PodManEnv  string `json:",omitempty"`
+	// AutoPause describes the auto-pause countdown of the control plane, when the addon is enabled
+	AutoPause string `json:",omitempty"`
+	// NextSchedule describes the next run of the recurring schedules of the cluster, on the control plane
+	NextSchedule string `json:",omitempty"`
+	// NetworkChaos describes the network faults injected on the traffic sent by the node
+	NetworkChaos []string `json:",omitempty"`
 }
 
 // State holds a cluster state representation
@@ -138,8 +166,12 @@ type State struct {
 
 	BinaryVersion string
 	TimeToStop    string `json:",omitempty"`
+	AutoPause     string `json:",omitempty"`
+	NextSchedule  string `json:",omitempty"`
 	Components    map[string]BaseState
-	Nodes         []NodeState
+	// Addons holds the state of the enabled addons that run workloads
+	Addons map[string]BaseState `json:",omitempty"`
+	Nodes  []NodeState
 }
 
 // NodeState holds a node state representation
@@ -158,7 +190,7 @@ type BaseState struct {
 	// Name is a human-readable name for the status code
 	StatusName string
 	// StatusDetail is long human-readable string describing why this particular status code was chosen
-	StatusDetail string `json:",omitempty"` // Not yet implemented
+	StatusDetail string `json:",omitempty"`
 
 	// Step is which workflow step the object is at.
 	Step string `json:",omitempty"`
@@ -212,10 +244,12 @@ func GetState(sts []*Status, profile string, cc *config.ClusterConfig) State {
 			StatusDetail: codeDetails[sc],
 		},
 
-		TimeToStop: sts[0].TimeToStop,
+		TimeToStop:   sts[0].TimeToStop,
+		AutoPause:    sts[0].AutoPause,
+		NextSchedule: sts[0].NextSchedule,
 
 		Components: map[string]BaseState{
-			"kubeconfig": {Name: "kubeconfig", StatusCode: statusCode(sts[0].Kubeconfig), StatusName: codeNames[statusCode(sts[0].Kubeconfig)]},
+			"kubeconfig": {Name: "kubeconfig", StatusCode: statusCode(sts[0].Kubeconfig), StatusName: codeNames[statusCode(sts[0].Kubeconfig)], StatusDetail: kubeconfigDetail(sts[0].Kubeconfig)},
 		},
 	}
 	healthyCPs := 0
@@ -234,10 +268,12 @@ func GetState(sts []*Status, profile string, cc *config.ClusterConfig) State {
 			ns.Components["apiserver"] = BaseState{Name: "apiserver", StatusCode: statusCode(st.APIServer)}
 		}
 
-		// Convert status codes to status names
+		// Convert status codes to status names and details
 		ns.StatusName = codeNames[ns.StatusCode]
+		ns.StatusDetail = codeDetails[ns.StatusCode]
 		for k, v := range ns.Components {
 			v.StatusName = codeNames[v.StatusCode]
+			v.StatusDetail = codeDetails[v.StatusCode]
 			ns.Components[k] = v
 		}
 
@@ -371,6 +407,9 @@ func NodeStatus(api libmachine.API, cc config.ClusterConfig, n config.Node) (*St
 		return st, nil
 	}
 	st.Host = hs
+	if machine.IsSuspended(hs) {
+		st.Host = codeNames[Suspended]
+	}
 
 	// If it's not running, quickly bail out rather than delivering conflicting messages
 	if st.Host != state.Running.String() {
@@ -417,9 +456,13 @@ func NodeStatus(api libmachine.API, cc config.ClusterConfig, n config.Node) (*St
 
 	stk := kverify.ServiceStatus(cr, "kubelet")
 	st.Kubelet = stk.String()
-	if cc.ScheduledStop != nil {
-		initiationTime := time.Unix(cc.ScheduledStop.InitiationTime, 0)
-		st.TimeToStop = time.Until(initiationTime.Add(cc.ScheduledStop.Duration)).String()
+	if at, ok := config.ScheduledStop(cc); ok {
+		st.TimeToStop = time.Until(at).String()
+	}
+	for _, r := range cc.NetworkChaos {
+		if r.From == name {
+			st.NetworkChaos = append(st.NetworkChaos, fmt.Sprintf("to %s: %s", r.To, netchaos.Describe(r)))
+		}
 	}
 	if os.Getenv(constants.MinikubeActiveDockerdEnv) != "" {
 		st.DockerEnv = "in-use"
@@ -452,6 +495,15 @@ func NodeStatus(api libmachine.API, cc config.ClusterConfig, n config.Node) (*St
 		st.Kubeconfig = Misconfigured
 	}
 
+	// the auto-pause daemon only runs on the primary control plane
+	if cc.Addons["auto-pause"] && config.IsPrimaryControlPlane(cc, n) {
+		if aps, err := autopause.ReadState(cr); err != nil {
+			klog.Warningf("auto-pause state: %v", err)
+		} else {
+			st.AutoPause = aps.Describe(time.Now())
+		}
+	}
+
 	sta, err := kverify.APIServerStatus(cr, hostname, port)
 	klog.Infof("%s apiserver status = %s (err=%v)", name, stk, err)
 
@@ -492,6 +544,17 @@ func readEventLog(name string) ([]cloudevents.Event, time.Time, error) {
 	return events, st.ModTime(), scanner.Err()
 }
 
+// kubeconfigDetail explains the state of the kubeconfig
+func kubeconfigDetail(st string) string {
+	switch st {
+	case Configured:
+		return "kubectl is configured to use the cluster"
+	case Misconfigured:
+		return "kubectl points to a stale endpoint, run `minikube update-context` to fix it"
+	}
+	return codeDetails[statusCode(st)]
+}
+
 // statusCode returns a status code number given a name
 func statusCode(st string) int {
 	// legacy names
@@ -0,0 +1,250 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/docker/machine/libmachine"
+	"k8s.io/klog/v2"
+
+	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil/kverify"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/machine"
+)
+
+// DefaultResync is how often a Watcher recomputes the full state when nothing cheap to probe has changed
+const DefaultResync = 30 * time.Second
+
+// Transition is a change of the status code of the cluster, a node or one of their components
+type Transition struct {
+	Time    time.Time
+	Profile string
+	// Node is empty for the cluster itself and its components, such as "kubeconfig"
+	Node string `json:",omitempty"`
+	// Component is empty for the cluster or node itself
+	Component string `json:",omitempty"`
+
+	StatusCode   int
+	StatusName   string
+	StatusDetail string `json:",omitempty"`
+
+	// PreviousStatusCode is 0 for the first state observed
+	PreviousStatusCode int    `json:",omitempty"`
+	PreviousStatusName string `json:",omitempty"`
+}
+
+// Subject returns a short human-readable name of what transitioned, such as "cluster/minikube" or "node/minikube-m02/kubelet".
+// The primary node has the name of the cluster, so the kind of the object comes first.
+func (t Transition) Subject() string {
+	s := "cluster/" + t.Profile
+	if t.Node != "" {
+		s = "node/" + t.Node
+	}
+	if t.Component != "" {
+		s += "/" + t.Component
+	}
+	return s
+}
+
+// stateKey identifies an object within a State
+type stateKey struct {
+	node      string
+	component string
+}
+
+// flatten returns the states of the cluster, the nodes and their components by key
+func flatten(cs State) map[stateKey]BaseState {
+	m := map[stateKey]BaseState{{}: cs.BaseState}
+	for name, c := range cs.Components {
+		m[stateKey{component: name}] = c
+	}
+	for _, n := range cs.Nodes {
+		m[stateKey{node: n.Name}] = n.BaseState
+		for name, c := range n.Components {
+			m[stateKey{node: n.Name, component: name}] = c
+		}
+	}
+	return m
+}
+
+// Transitions returns the status code changes from prev to cur, in a stable order.
+// Objects that disappeared are reported as NotFound.
+func Transitions(prev, cur State, now time.Time) []Transition {
+	before := map[stateKey]BaseState{}
+	if prev.Name != "" {
+		before = flatten(prev)
+	}
+	after := flatten(cur)
+
+	var ts []Transition
+	add := func(k stateKey, from, to BaseState) {
+		ts = append(ts, Transition{
+			Time:               now,
+			Profile:            cur.Name,
+			Node:               k.node,
+			Component:          k.component,
+			StatusCode:         to.StatusCode,
+			StatusName:         to.StatusName,
+			StatusDetail:       to.StatusDetail,
+			PreviousStatusCode: from.StatusCode,
+			PreviousStatusName: from.StatusName,
+		})
+	}
+	for k, to := range after {
+		if from, ok := before[k]; !ok || from.StatusCode != to.StatusCode {
+			add(k, from, to)
+		}
+	}
+	for k, from := range before {
+		if _, ok := after[k]; !ok {
+			add(k, from, BaseState{StatusCode: NotFound, StatusName: codeNames[NotFound], StatusDetail: codeDetails[NotFound]})
+		}
+	}
+
+	// the cluster first, then its components, then each node followed by its components
+	sort.Slice(ts, func(i, j int) bool {
+		if ts[i].Node != ts[j].Node {
+			return ts[i].Node < ts[j].Node
+		}
+		return ts[i].Component < ts[j].Component
+	})
+	return ts
+}
+
+// readyzTimeout is how long the cheap probe waits for the readiness of the API server
+const readyzTimeout = 2 * time.Second
+
+// probe is the part of the state that is cheap to look up
+type probe struct {
+	hosts     string
+	eventLog  time.Time
+	journal   time.Time
+	apiserver string
+}
+
+// Watcher polls the state of a cluster and reports its transitions.
+// The full state is only recomputed when the host state, the API server readiness, the event log or the event journal changed,
+// or once per Resync, so that watching does not keep the nodes and API server busy.
+type Watcher struct {
+	api     libmachine.API
+	cc      *config.ClusterConfig
+	profile string
+
+	// Resync is the longest time between two full recomputes of the state
+	Resync time.Duration
+
+	last     State
+	lastFull time.Time
+	probe    probe
+
+	// hostname and port are the API server endpoint, looked up again on every full recompute
+	hostname string
+	port     int
+}
+
+// NewWatcher returns a Watcher for the cluster of the given profile
+func NewWatcher(api libmachine.API, cc *config.ClusterConfig, profile string) *Watcher {
+	return &Watcher{api: api, cc: cc, profile: profile, Resync: DefaultResync}
+}
+
+// cheapProbe looks up the host states via the driver, the readiness of the API server
+// and the modification times of the event log and the event journal
+func (w *Watcher) cheapProbe() probe {
+	var p probe
+	for _, n := range w.cc.Nodes {
+		name := config.MachineName(*w.cc, n)
+		hs, err := machine.Status(w.api, name)
+		if err != nil {
+			hs = err.Error()
+		}
+		p.hosts += fmt.Sprintf("%s=%s;", name, hs)
+	}
+	if st, err := os.Stat(localpath.EventLog(w.profile)); err == nil {
+		p.eventLog = st.ModTime()
+	}
+	if st, err := os.Stat(localpath.EventJournal(w.profile)); err == nil {
+		p.journal = st.ModTime()
+	}
+	p.apiserver = w.apiServerReadiness()
+	return p
+}
+
+// apiServerReadiness returns the readiness of the API server, probed with a single request.
+// With auto-pause the request would count as activity and keep the cluster running, so it is not probed.
+func (w *Watcher) apiServerReadiness() string {
+	if w.cc.Addons["auto-pause"] || w.hostname == "" {
+		return ""
+	}
+	st, err := kverify.APIServerReadyz(w.hostname, w.port, readyzTimeout)
+	if err != nil {
+		return st.String() + ": " + err.Error()
+	}
+	return st.String()
+}
+
+// lookupEndpoint looks up the API server endpoint of the primary control plane, it is empty when it is unknown
+func (w *Watcher) lookupEndpoint() {
+	w.hostname, w.port = "", 0
+	cp, err := config.ControlPlane(*w.cc)
+	if err != nil {
+		klog.Warningf("control plane: %v", err)
+		return
+	}
+	hostname, _, port, err := driver.ControlPlaneEndpoint(w.cc, &cp, w.cc.Driver)
+	if err != nil {
+		klog.Warningf("control plane endpoint: %v", err)
+		return
+	}
+	w.hostname, w.port = hostname, port
+}
+
+// state recomputes the full state of the cluster
+func (w *Watcher) state() State {
+	sts, err := GetStatus(w.api, w.cc)
+	if err != nil || len(sts) == 0 {
+		klog.Warningf("status error: %v", err)
+		detail := codeDetails[Unknown]
+		if err != nil {
+			detail = err.Error()
+		}
+		return State{BaseState: BaseState{Name: w.profile, StatusCode: Unknown, StatusName: codeNames[Unknown], StatusDetail: detail}}
+	}
+	return GetState(sts, w.profile, w.cc)
+}
+
+// Poll returns the transitions since the previous poll. The first poll reports the initial state.
+func (w *Watcher) Poll(now time.Time) []Transition {
+	p := w.cheapProbe()
+	if w.last.Name != "" && p == w.probe && now.Sub(w.lastFull) < w.Resync {
+		return nil
+	}
+	w.probe = p
+	w.lastFull = now
+
+	cur := w.state()
+	// the endpoint may have changed with the state, like the forwarded port after a restart
+	w.lookupEndpoint()
+	ts := Transitions(w.last, cur, now)
+	w.last = cur
+	return ts
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func testState(apiserver, workerHost, workerKubelet int) State {
+	base := func(name string, code int) BaseState {
+		return BaseState{Name: name, StatusCode: code, StatusName: codeNames[code]}
+	}
+	cs := State{
+		BaseState:  base("minikube", apiserver),
+		Components: map[string]BaseState{"kubeconfig": base("kubeconfig", OK)},
+		Nodes: []NodeState{
+			{BaseState: base("minikube", OK), Components: map[string]BaseState{"apiserver": base("apiserver", apiserver), "kubelet": base("kubelet", OK)}},
+		},
+	}
+	if workerHost != 0 {
+		cs.Nodes = append(cs.Nodes, NodeState{BaseState: base("minikube-m02", workerHost), Components: map[string]BaseState{"kubelet": base("kubelet", workerKubelet)}})
+	}
+	return cs
+}
+
+func TestTransitions(t *testing.T) {
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	subjects := func(ts []Transition) []string {
+		var s []string
+		for _, tr := range ts {
+			s = append(s, tr.Subject()+" "+tr.PreviousStatusName+">"+tr.StatusName)
+		}
+		return s
+	}
+
+	tests := []struct {
+		name string
+		prev State
+		cur  State
+		want []string
+	}{
+		{
+			name: "initial",
+			cur:  testState(OK, 0, 0),
+			want: []string{"cluster/minikube >OK", "cluster/minikube/kubeconfig >OK", "node/minikube >OK", "node/minikube/apiserver >OK", "node/minikube/kubelet >OK"},
+		},
+		{
+			name: "unchanged",
+			prev: testState(OK, Stopped, Stopped),
+			cur:  testState(OK, Stopped, Stopped),
+		},
+		{
+			name: "apiserver paused",
+			prev: testState(OK, OK, OK),
+			cur:  testState(Paused, OK, OK),
+			want: []string{"cluster/minikube OK>Paused", "node/minikube/apiserver OK>Paused"},
+		},
+		{
+			name: "worker kubelet stopped",
+			prev: testState(OK, OK, OK),
+			cur:  testState(OK, OK, Stopped),
+			want: []string{"node/minikube-m02/kubelet OK>Stopped"},
+		},
+		{
+			name: "worker deleted",
+			prev: testState(OK, OK, OK),
+			cur:  testState(OK, 0, 0),
+			want: []string{"node/minikube-m02 OK>NotFound", "node/minikube-m02/kubelet OK>NotFound"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := Transitions(tc.prev, tc.cur, now)
+			if diff := cmp.Diff(tc.want, subjects(ts)); diff != "" {
+				t.Errorf("Transitions() mismatch (-want +got):\n%s", diff)
+			}
+			for _, tr := range ts {
+				if !tr.Time.Equal(now) || tr.Profile != "minikube" {
+					t.Errorf("unexpected time or profile: %+v", tr)
+				}
+			}
+		})
+	}
+}
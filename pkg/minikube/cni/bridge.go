@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"fmt"
 	"os/exec"
+	"strings"
 	"text/template"
 
 	"github.com/pkg/errors"
@@ -49,7 +50,11 @@ var bridgeConf = template.Must(template.New("bridge").Parse(`
       "hairpinMode": true,
       "ipam": {
           "type": "host-local",
-          "subnet": "{{.PodCIDR}}"
+{{- if eq (len .PodCIDRs) 1}}
+          "subnet": "{{index .PodCIDRs 0}}"
+{{- else}}
+          "ranges": [{{range $i, $cidr := .PodCIDRs}}{{if $i}}, {{end}}[{"subnet": "{{$cidr}}"}]{{end}}]
+{{- end}}
       }
     },
     {
@@ -76,7 +81,8 @@ func (c Bridge) String() string {
 }
 
 func (c Bridge) netconf() (assets.CopyableFile, error) {
-	input := &tmplInput{PodCIDR: DefaultPodCIDR}
+	// host-local takes one range set per IP family
+	input := struct{ PodCIDRs []string }{strings.Split(podCIDRs(c.cc), ",")}
 
 	b := bytes.Buffer{}
 	if err := bridgeConf.Execute(&b, input); err != nil {
@@ -110,5 +116,5 @@ func (c Bridge) Apply(r Runner) error {
 
 // CIDR returns the default CIDR used by this CNI
 func (c Bridge) CIDR() string {
-	return DefaultPodCIDR
+	return podCIDRs(c.cc)
 }
@@ -44,6 +44,9 @@ const (
 	// DefaultPodCIDR is the default CIDR to use in minikube CNI's.
 	DefaultPodCIDR = "10.244.0.0/16"
 
+	// DefaultIPv6PodCIDR is the default IPv6 CIDR to use in minikube CNI's, leaving room for a /64 per node.
+	DefaultIPv6PodCIDR = "fd00:10:244::/56"
+
 	// DefaultConfDir is the default CNI Config Directory path
 	DefaultConfDir = "/etc/cni/net.d"
 )
@@ -74,6 +77,11 @@ type tmplInput struct {
 	CNIConfDir   string
 }
 
+// podCIDRs returns the default pod CIDRs for the IP families of the cluster, comma separated
+func podCIDRs(cc config.ClusterConfig) string {
+	return config.JoinIPFamilies(cc, DefaultPodCIDR, DefaultIPv6PodCIDR)
+}
+
 // New returns a new CNI manager
 func New(cc *config.ClusterConfig) (Manager, error) {
 	if cc.KubernetesConfig.NetworkPlugin != "" && cc.KubernetesConfig.NetworkPlugin != "cni" {
@@ -127,7 +135,7 @@ func chooseDefault(cc config.ClusterConfig) Manager {
 	// For backwards compatibility with older profiles using --enable-default-cni
 	if cc.KubernetesConfig.EnableDefaultCNI {
 		klog.Infof("EnableDefaultCNI is true, recommending bridge")
-		return Bridge{}
+		return Bridge{cc: cc}
 	}
 
 	if len(cc.Nodes) > 1 || cc.MultiNodeRequested {
@@ -17,6 +17,7 @@ limitations under the License.
 package cni
 
 import (
+	"strings"
 	"testing"
 
 	"k8s.io/minikube/pkg/minikube/config"
@@ -52,3 +53,27 @@ func TestChooseDefault(t *testing.T) {
 		}
 	}
 }
+
+func TestBridgeNetconfIPFamilies(t *testing.T) {
+	tests := []struct {
+		family string
+		want   string
+	}{
+		{"", `"subnet": "10.244.0.0/16"`},
+		{"ipv6", `"subnet": "fd00:10:244::/56"`},
+		{"dual", `"ranges": [[{"subnet": "10.244.0.0/16"}], [{"subnet": "fd00:10:244::/56"}]]`},
+	}
+	for _, tc := range tests {
+		f, err := Bridge{cc: config.ClusterConfig{IPFamily: tc.family}}.netconf()
+		if err != nil {
+			t.Fatalf("netconf() error = %v", err)
+		}
+		b := make([]byte, f.GetLength())
+		if _, err := f.Read(b); err != nil {
+			t.Fatalf("reading netconf: %v", err)
+		}
+		if !strings.Contains(string(b), tc.want) {
+			t.Errorf("netconf() for IP family %q = %s; want it to contain %s", tc.family, b, tc.want)
+		}
+	}
+}
@@ -174,7 +174,7 @@ func (c KindNet) String() string {
 func (c KindNet) manifest() (assets.CopyableFile, error) {
 	input := &tmplInput{
 		DefaultRoute: "0.0.0.0/0", // assumes IPv4
-		PodCIDR:      DefaultPodCIDR,
+		PodCIDR:      podCIDRs(c.cc),
 		ImageName:    images.KindNet(c.cc.KubernetesConfig.ImageRepository),
 		CNIConfDir:   DefaultConfDir,
 	}
@@ -202,5 +202,5 @@ func (c KindNet) Apply(r Runner) error {
 
 // CIDR returns the default CIDR used by this CNI
 func (c KindNet) CIDR() string {
-	return DefaultPodCIDR
+	return podCIDRs(c.cc)
 }
@@ -58,6 +58,8 @@ const (
 	EmbedCerts = "EmbedCerts"
 	// MaxAuditEntries is the maximum number of audit entries to retain
 	MaxAuditEntries = "MaxAuditEntries"
+	// MaxAuditSize is the size in megabytes at which the audit log archive is rotated
+	MaxAuditSize = "MaxAuditSize"
 )
 
 var (
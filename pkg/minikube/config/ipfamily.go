@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IP families of a cluster
+const (
+	// IPv4Family is an IPv4-only cluster, the default
+	IPv4Family = "ipv4"
+	// IPv6Family is an IPv6-only cluster
+	IPv6Family = "ipv6"
+	// DualStackFamily is a dual-stack cluster, with IPv4 as the primary family
+	DualStackFamily = "dual"
+)
+
+// ValidateIPFamily returns an error if family is not a supported IP family
+func ValidateIPFamily(family string) error {
+	switch strings.ToLower(family) {
+	case "", IPv4Family, IPv6Family, DualStackFamily:
+		return nil
+	}
+	return fmt.Errorf("invalid IP family %q, must be one of %s, %s or %s", family, IPv4Family, IPv6Family, DualStackFamily)
+}
+
+// HasIPv4 returns if the cluster uses IPv4 addresses
+func HasIPv4(cc ClusterConfig) bool {
+	return !strings.EqualFold(cc.IPFamily, IPv6Family)
+}
+
+// HasIPv6 returns if the cluster uses IPv6 addresses
+func HasIPv6(cc ClusterConfig) bool {
+	return strings.EqualFold(cc.IPFamily, IPv6Family) || strings.EqualFold(cc.IPFamily, DualStackFamily)
+}
+
+// JoinIPFamilies returns the values of the IP families used by the cluster, comma separated
+// with the primary family first, as expected by the dual-stack Kubernetes flags
+func JoinIPFamilies(cc ClusterConfig, ipv4, ipv6 string) string {
+	var vals []string
+	if HasIPv4(cc) && ipv4 != "" {
+		vals = append(vals, ipv4)
+	}
+	if HasIPv6(cc) && ipv6 != "" {
+		vals = append(vals, ipv6)
+	}
+	return strings.Join(vals, ",")
+}
+
+// NodeIP returns the primary IP address of a node in the cluster
+func NodeIP(cc ClusterConfig, n Node) string {
+	if !HasIPv4(cc) && n.IPv6 != "" {
+		return n.IPv6
+	}
+	return n.IP
+}
+
+// NodeIPs returns the IP addresses of a node for the IP families of the cluster, comma separated as expected by the kubelet --node-ip flag
+func NodeIPs(cc ClusterConfig, n Node) string {
+	if ips := JoinIPFamilies(cc, n.IP, n.IPv6); ips != "" {
+		return ips
+	}
+	return n.IP
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import "testing"
+
+func TestIPFamilies(t *testing.T) {
+	n := Node{IP: "192.168.49.2", IPv6: "fd00::2"}
+	tests := []struct {
+		family  string
+		nodeIP  string
+		nodeIPs string
+		cidrs   string
+	}{
+		{"", "192.168.49.2", "192.168.49.2", "10.96.0.0/12"},
+		{IPv4Family, "192.168.49.2", "192.168.49.2", "10.96.0.0/12"},
+		{IPv6Family, "fd00::2", "fd00::2", "fd00:10:96::/112"},
+		{DualStackFamily, "192.168.49.2", "192.168.49.2,fd00::2", "10.96.0.0/12,fd00:10:96::/112"},
+	}
+	for _, tc := range tests {
+		cc := ClusterConfig{IPFamily: tc.family}
+		if got := NodeIP(cc, n); got != tc.nodeIP {
+			t.Errorf("NodeIP(%q) = %s, expected %s", tc.family, got, tc.nodeIP)
+		}
+		if got := NodeIPs(cc, n); got != tc.nodeIPs {
+			t.Errorf("NodeIPs(%q) = %s, expected %s", tc.family, got, tc.nodeIPs)
+		}
+		if got := JoinIPFamilies(cc, "10.96.0.0/12", "fd00:10:96::/112"); got != tc.cidrs {
+			t.Errorf("JoinIPFamilies(%q) = %s, expected %s", tc.family, got, tc.cidrs)
+		}
+	}
+
+	if got := NodeIPs(ClusterConfig{IPFamily: IPv6Family}, Node{IP: "192.168.49.2"}); got != "192.168.49.2" {
+		t.Errorf("NodeIPs without an IPv6 address = %s, expected the IPv4 address", got)
+	}
+	if err := ValidateIPFamily("ipv5"); err == nil {
+		t.Errorf("ValidateIPFamily(ipv5) expected an error")
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 	"k8s.io/klog/v2"
@@ -61,6 +62,20 @@ func IsPrimaryControlPlane(cc ClusterConfig, node Node) bool {
 	return cc.Nodes != nil && cc.Nodes[0].Name == node.Name
 }
 
+// ScheduledStop returns when the earliest one-off stop of the cluster runs, and false if none is scheduled
+func ScheduledStop(cc ClusterConfig) (time.Time, bool) {
+	var at time.Time
+	for _, s := range cc.Schedules {
+		if s.At == 0 || s.Operation != "stop" {
+			continue
+		}
+		if t := time.Unix(s.At, 0); at.IsZero() || t.Before(at) {
+			at = t
+		}
+	}
+	return at, !at.IsZero()
+}
+
 // IsValid checks if the profile has the essential info needed for a profile
 func (p *Profile) IsValid() bool {
 	if p.Config == nil {
@@ -76,15 +76,18 @@ type ClusterConfig struct {
 	KubernetesConfig        KubernetesConfig
 	Nodes                   []Node
 	Addons                  map[string]bool
-	CustomAddonImages       map[string]string // Maps image names to the image to use for addons. e.g. Dashboard -> registry.k8s.io/echoserver:1.4 makes dashboard addon use echoserver for its Dashboard deployment.
-	CustomAddonRegistries   map[string]string // Maps image names to the registry to use for addons. See CustomAddonImages for example.
-	VerifyComponents        map[string]bool   // map of components to verify and wait for after start.
+	CustomAddonImages       map[string]string            // Maps image names to the image to use for addons. e.g. Dashboard -> registry.k8s.io/echoserver:1.4 makes dashboard addon use echoserver for its Dashboard deployment.
+	CustomAddonRegistries   map[string]string            // Maps image names to the registry to use for addons. See CustomAddonImages for example.
+	AddonValues             map[string]map[string]string // values of the addons by addon and value name, set with --values and --set
+	VerifyComponents        map[string]bool              // map of components to verify and wait for after start.
 	StartHostTimeout        time.Duration
-	ScheduledStop           *ScheduledStopConfig
+	Schedules               []Schedule
 	ExposedPorts            []string // Only used by the docker and podman driver
 	ListenAddress           string   // Only used by the docker and podman driver
 	Network                 string   // only used by docker driver
 	Subnet                  string   // only used by the docker and podman driver
+	IPFamily                string   // ipv4, ipv6 or dual, IPv6 is only supported by the docker and podman driver
+	IPv6Subnet              string   // only used by the docker and podman driver
 	MultiNodeRequested      bool
 	ExtraDisks              int // currently only implemented for hyperkit and kvm2
 	CertExpiration          time.Duration
@@ -108,7 +111,20 @@ type ClusterConfig struct {
 	SSHAuthSock             string
 	SSHAgentPID             int
 	GPUs                    string
-	AutoPauseInterval       time.Duration // Specifies interval of time to wait before checking if cluster should be paused
+	AutoPauseInterval       time.Duration      // Specifies interval of time to wait before checking if cluster should be paused
+	AutoPausePolicy         AutoPausePolicy    // what auto-pause pauses, what counts as activity and when it may pause
+	PortForwards            []PortForward      // persistent port forwards run by `minikube port-forward`
+	NetworkChaos            []NetworkChaosRule // network faults between nodes injected by `minikube network chaos`
+}
+
+// AutoPausePolicy customizes the auto-pause addon
+type AutoPausePolicy struct {
+	Namespaces       []string // namespaces to pause, kube-system if empty
+	ExemptNamespaces []string // namespaces never paused
+	ExemptSelector   string   // label selector of the pods never paused
+	WakeOnServices   bool     // count NodePort and LoadBalancer traffic as activity, not just API requests
+	NeverPause       []string // time windows during which the cluster is never paused, like "Mon-Fri 09:00-18:00"
+	TimeZone         string   // time zone of the windows, an IANA name like Europe/Berlin or an offset like UTC+02:00
 }
 
 // KubernetesConfig contains the parameters used to configure the VM Kubernetes.
@@ -126,11 +142,12 @@ type KubernetesConfig struct {
 	NetworkPlugin       string
 	FeatureGates        string // https://kubernetes.io/docs/reference/command-line-tools-reference/feature-gates/
 	ServiceCIDR         string // the subnet which Kubernetes services will be deployed to
+	IPv6ServiceCIDR     string // the IPv6 subnet which Kubernetes services will be deployed to in IPv6 and dual-stack clusters
 	ImageRepository     string
-	LoadBalancerStartIP string // currently only used by MetalLB addon
-	LoadBalancerEndIP   string // currently only used by MetalLB addon
-	CustomIngressCert   string // used by Ingress addon
-	RegistryAliases     string // currently only used by registry-aliases addon
+	LoadBalancerStartIP string // superseded by the startIP value of the metallb addon, only read from existing profiles
+	LoadBalancerEndIP   string // superseded by the endIP value of the metallb addon, only read from existing profiles
+	CustomIngressCert   string // superseded by the customCert value of the ingress addon, only read from existing profiles
+	RegistryAliases     string // superseded by the aliases value of the registry-aliases addon, only read from existing profiles
 	ExtraOptions        ExtraOptionSlice
 
 	ShouldLoadCachedImages bool
@@ -143,6 +160,7 @@ type KubernetesConfig struct {
 type Node struct {
 	Name              string
 	IP                string
+	IPv6              string // only set in IPv6 and dual-stack clusters
 	Port              int
 	KubernetesVersion string
 	ContainerRuntime  string
@@ -175,9 +193,35 @@ type VersionedExtraOption struct {
 	GreaterThanOrEqual semver.Version
 }
 
-// ScheduledStopConfig contains information around scheduled stop
-// not yet used, will be used to show status of scheduled stop
-type ScheduledStopConfig struct {
-	InitiationTime int64
-	Duration       time.Duration
+// PortForward contains a persistent port forward definition
+type PortForward struct {
+	Name      string
+	Namespace string
+	Kind      string   // pod, deployment or service
+	Target    string   // name of the pod, deployment or service, empty when Selector is used
+	Selector  string   // label selector used to pick a pod when Target is empty
+	Ports     []string // each entry is formatted as [LOCAL:]REMOTE
+	Address   string   // local address to listen on
+}
+
+// NetworkChaosRule is a network fault injected on the traffic from one node to another
+type NetworkChaosRule struct {
+	From      string // machine name of the node sending the traffic
+	To        string // machine name of the node receiving the traffic
+	Latency   string `json:",omitempty"` // added delay, like 100ms
+	Jitter    string `json:",omitempty"` // random variation of the delay, like 10ms
+	Loss      string `json:",omitempty"` // percentage of dropped packets, like 1%
+	Rate      string `json:",omitempty"` // bandwidth limit in tc units, like 1mbit
+	Partition bool   `json:",omitempty"` // drop all traffic
+	Scenario  string `json:",omitempty"` // name of the scenario the rule was created by, if any
+}
+
+// Schedule is an operation run on a profile by the schedule daemon, at the times matched by a cron expression,
+// once the API server of the profile saw no client activity for a while, or once at a given time
+type Schedule struct {
+	Name      string
+	Operation string        // one of start, stop, pause, unpause and restart
+	Cron      string        // in the local time zone
+	Idle      time.Duration `json:",omitempty"` // instead of Cron, only for stop
+	At        int64         `json:",omitempty"` // instead of Cron, the unix time of a one-off run, after which the schedule is removed
 }
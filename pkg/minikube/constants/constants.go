@@ -78,6 +78,8 @@ const (
 	ClusterDNSDomain = "cluster.local"
 	// DefaultServiceCIDR is The CIDR to be used for service cluster IPs
 	DefaultServiceCIDR = "10.96.0.0/12"
+	// DefaultIPv6ServiceCIDR is the CIDR to be used for IPv6 service cluster IPs, kube-apiserver allows at most 20 host bits
+	DefaultIPv6ServiceCIDR = "fd00:10:96::/112"
 	// HostAlias is a DNS alias to the container/VM host IP
 	HostAlias = "host.minikube.internal"
 	// ControlPlaneAlias is a DNS alias pointing to the apiserver frontend
@@ -114,13 +116,6 @@ const (
 	// MinikubeRootlessEnv is used to force Rootless Docker/Podman driver
 	MinikubeRootlessEnv = "MINIKUBE_ROOTLESS"
 
-	// scheduled stop constants
-
-	// ScheduledStopEnvFile is the environment file for scheduled-stop
-	ScheduledStopEnvFile = "/var/lib/minikube/scheduled-stop/environment"
-	// ScheduledStopSystemdService is the service file for scheduled-stop
-	ScheduledStopSystemdService = "minikube-scheduled-stop"
-
 	// MinikubeExistingPrefix is used to save the original environment when executing docker-env
 	MinikubeExistingPrefix = "MINIKUBE_EXISTING_"
 
@@ -60,6 +60,11 @@ func crictlList(cr CommandRunner, root string, o ListContainersOptions) (*comman
 		baseCmd = append(baseCmd, fmt.Sprintf("--name=%s", o.Name))
 	}
 
+	// containers do not carry the labels of their pod, so look up the pods first
+	if len(o.Pods) > 0 || len(o.Labels) > 0 {
+		return crictlListPods(cr, baseCmd, o)
+	}
+
 	// shortcut for all namespaces
 	if len(o.Namespaces) == 0 {
 		return cr.RunCmd(exec.Command("sudo", baseCmd...))
@@ -75,6 +80,57 @@ func crictlList(cr CommandRunner, root string, o ListContainersOptions) (*comman
 	return cr.RunCmd(exec.Command("sudo", "-s", "eval", strings.Join(cmds, "; ")))
 }
 
+// crictlListPods returns the output of 'crictl ps' for the containers of the pods matching o
+func crictlListPods(cr CommandRunner, baseCmd []string, o ListContainersOptions) (*command.RunResult, error) {
+	var pods []string
+	for _, args := range crictlPodsArgs(o) {
+		rr, err := cr.RunCmd(exec.Command("sudo", args...))
+		if err != nil {
+			return rr, errors.Wrap(err, "crictl pods")
+		}
+		pods = append(pods, strings.Fields(rr.Stdout.String())...)
+	}
+	if len(pods) == 0 {
+		return &command.RunResult{}, nil
+	}
+
+	cmds := []string{}
+	for _, pod := range pods {
+		cmds = append(cmds, fmt.Sprintf("%s --pod %s", strings.Join(baseCmd, " "), pod))
+	}
+	return cr.RunCmd(exec.Command("sudo", "-s", "eval", strings.Join(cmds, "; ")))
+}
+
+// crictlPodsArgs returns the 'crictl pods' commands listing the pods matching o, one per namespace and pod name
+func crictlPodsArgs(o ListContainersOptions) [][]string {
+	namespaces := o.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+	pods := o.Pods
+	if len(pods) == 0 {
+		pods = []string{""}
+	}
+
+	cmds := [][]string{}
+	for _, ns := range namespaces {
+		for _, pod := range pods {
+			args := []string{"crictl", "pods", "--quiet"}
+			if ns != "" {
+				args = append(args, "--label", "io.kubernetes.pod.namespace="+ns)
+			}
+			if pod != "" {
+				args = append(args, "--label", "io.kubernetes.pod.name="+pod)
+			}
+			for _, l := range o.Labels {
+				args = append(args, "--label", l)
+			}
+			cmds = append(cmds, args)
+		}
+	}
+	return cmds
+}
+
 // listCRIContainers returns a list of containers
 func listCRIContainers(cr CommandRunner, root string, o ListContainersOptions) ([]string, error) {
 	rr, err := crictlList(cr, root, o)
@@ -167,6 +167,10 @@ type ListContainersOptions struct {
 	Name string
 	// Namespaces is the namespaces to look into
 	Namespaces []string
+	// Pods is the names of the pods to look into
+	Pods []string
+	// Labels is the labels, as key=value, the pods to look into must all have
+	Labels []string
 }
 
 // ListImagesOptions are the options to use for listing images
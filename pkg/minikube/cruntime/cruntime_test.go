@@ -808,3 +808,42 @@ func TestContainerFunctions(t *testing.T) {
 		})
 	}
 }
+
+func TestCrictlPodsArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		o    ListContainersOptions
+		want [][]string
+	}{
+		{
+			name: "labels",
+			o:    ListContainersOptions{Labels: []string{"app=kafka", "tier=backend"}},
+			want: [][]string{
+				{"crictl", "pods", "--quiet", "--label", "app=kafka", "--label", "tier=backend"},
+			},
+		},
+		{
+			name: "pods in namespaces",
+			o:    ListContainersOptions{Namespaces: []string{"default", "streaming"}, Pods: []string{"kafka-0"}},
+			want: [][]string{
+				{"crictl", "pods", "--quiet", "--label", "io.kubernetes.pod.namespace=default", "--label", "io.kubernetes.pod.name=kafka-0"},
+				{"crictl", "pods", "--quiet", "--label", "io.kubernetes.pod.namespace=streaming", "--label", "io.kubernetes.pod.name=kafka-0"},
+			},
+		},
+		{
+			name: "labeled pods",
+			o:    ListContainersOptions{Namespaces: []string{"default"}, Pods: []string{"kafka-0", "kafka-1"}, Labels: []string{"app=kafka"}},
+			want: [][]string{
+				{"crictl", "pods", "--quiet", "--label", "io.kubernetes.pod.namespace=default", "--label", "io.kubernetes.pod.name=kafka-0", "--label", "app=kafka"},
+				{"crictl", "pods", "--quiet", "--label", "io.kubernetes.pod.namespace=default", "--label", "io.kubernetes.pod.name=kafka-1", "--label", "app=kafka"},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, crictlPodsArgs(tc.o)); diff != "" {
+				t.Errorf("crictlPodsArgs(%+v) mismatch (-want +got):\n%s", tc.o, diff)
+			}
+		})
+	}
+}
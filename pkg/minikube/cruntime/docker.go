@@ -23,6 +23,8 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"regexp"
+	"slices"
 	"strings"
 	"text/template"
 	"time"
@@ -436,7 +438,29 @@ func (r *Docker) ListContainers(o ListContainersOptions) ([]string, error) {
 	}
 
 	nameFilter := KubernetesContainerPrefix + o.Name
-	if len(o.Namespaces) > 0 {
+	switch {
+	case len(o.Labels) > 0:
+		pods, err := r.labeledPods(o)
+		if err != nil {
+			return nil, errors.Wrap(err, "labeled pods")
+		}
+		if len(pods) == 0 {
+			return nil, nil
+		}
+		// Example result: k8s.*(kafka-0_default|kafka-1_default)
+		nameFilter = fmt.Sprintf("%s.*_(%s)_", nameFilter, strings.Join(pods, "|"))
+	case len(o.Pods) > 0:
+		namespaces := "[^_]+"
+		if len(o.Namespaces) > 0 {
+			namespaces = fmt.Sprintf("(%s)", strings.Join(o.Namespaces, "|"))
+		}
+		pods := []string{}
+		for _, pod := range o.Pods {
+			pods = append(pods, regexp.QuoteMeta(pod))
+		}
+		// Example result: k8s.*(kafka-0|kafka-1)_(default)
+		nameFilter = fmt.Sprintf("%s.*_(%s)_%s_", nameFilter, strings.Join(pods, "|"), namespaces)
+	case len(o.Namespaces) > 0:
 		// Example result: k8s.*(kube-system|kubernetes-dashboard)
 		nameFilter = fmt.Sprintf("%s.*_(%s)_", nameFilter, strings.Join(o.Namespaces, "|"))
 	}
@@ -455,6 +479,36 @@ func (r *Docker) ListContainers(o ListContainersOptions) ([]string, error) {
 	return ids, nil
 }
 
+// labeledPods returns the pods of o having all the labels of o, as pod_namespace like in the container names
+func (r *Docker) labeledPods(o ListContainersOptions) ([]string, error) {
+	// only the sandbox container carries the labels of the pod
+	args := []string{"ps", "-a", "--filter", "label=io.kubernetes.docker.type=podsandbox"}
+	for _, l := range o.Labels {
+		args = append(args, "--filter", "label="+l)
+	}
+	args = append(args, `--format={{.Label "io.kubernetes.pod.name"}}_{{.Label "io.kubernetes.pod.namespace"}}`)
+	rr, err := r.Runner.RunCmd(exec.Command("docker", args...))
+	if err != nil {
+		return nil, errors.Wrapf(err, "docker")
+	}
+
+	var pods []string
+	for _, line := range strings.Split(rr.Stdout.String(), "\n") {
+		pod, ns, ok := strings.Cut(line, "_")
+		if !ok {
+			continue
+		}
+		if len(o.Pods) > 0 && !slices.Contains(o.Pods, pod) {
+			continue
+		}
+		if len(o.Namespaces) > 0 && !slices.Contains(o.Namespaces, ns) {
+			continue
+		}
+		pods = append(pods, regexp.QuoteMeta(line))
+	}
+	return pods, nil
+}
+
 // KillContainers forcibly removes a running container based on ID
 func (r *Docker) KillContainers(ids []string) error {
 	if r.UseCRI {
@@ -24,8 +24,10 @@ import (
 
 	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/trace"
 )
 
 // DefaultKubeBinariesURL returns a URL to kube binaries
@@ -53,6 +55,7 @@ func binaryWithChecksumURL(binaryName, version, osName, archName, binaryURL stri
 
 // Binary will download a binary onto the host
 func Binary(binary, version, osName, archName, binaryURL string) (string, error) {
+	defer trace.StartAsync("download.Binary", attribute.String("binary", binary), attribute.String("version", version)).End()
 	targetDir := localpath.MakeMiniPath("cache", osName, archName, version)
 	targetFilepath := path.Join(targetDir, binary)
 	targetLock := targetFilepath + ".lock"
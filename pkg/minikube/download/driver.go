@@ -23,9 +23,11 @@ import (
 
 	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/style"
+	"k8s.io/minikube/pkg/trace"
 )
 
 func driverWithChecksumURL(name string, v semver.Version) string {
@@ -39,6 +41,7 @@ func driverWithArchAndChecksumURL(name string, v semver.Version) string {
 
 // Driver downloads an arbitrary driver
 func Driver(name string, destination string, v semver.Version) error {
+	defer trace.StartAsync("download.Driver", attribute.String("driver", name), attribute.String("version", v.String())).End()
 	out.Step(style.FileDownload, "Downloading driver {{.driver}}:", out.V{"driver": name})
 
 	archURL := driverWithArchAndChecksumURL(name, v)
@@ -33,12 +33,14 @@ import (
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/google/go-containerregistry/pkg/v1/tarball"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/detect"
 	"k8s.io/minikube/pkg/minikube/image"
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/out/register"
+	"k8s.io/minikube/pkg/trace"
 )
 
 var (
@@ -120,6 +122,7 @@ func isImageCorrectArch(img string) (bool, error) {
 
 // ImageToCache downloads img (if not present in cache) and writes it to the local cache directory
 func ImageToCache(img string) error {
+	defer trace.StartAsync("download.ImageToCache", attribute.String("image", img)).End()
 	f := imagePathInCache(img)
 	fileLock := f + ".lock"
 
@@ -251,6 +254,7 @@ func parseImage(img string) (*name.Tag, name.Reference, error) {
 // If online it will be: image:tag@sha256
 // If offline it will be: image:tag
 func CacheToDaemon(img string) (string, error) {
+	defer trace.StartAsync("download.CacheToDaemon", attribute.String("image", img)).End()
 	p := imagePathInCache(img)
 
 	tag, ref, err := parseImage(img)
@@ -32,6 +32,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/detect"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/style"
+	"k8s.io/minikube/pkg/trace"
 	"k8s.io/minikube/pkg/util/lock"
 	"k8s.io/minikube/pkg/version"
 )
@@ -82,6 +83,7 @@ func localISOPath(u *url.URL) string {
 
 // ISO downloads and returns the path to the downloaded ISO
 func ISO(urls []string, skipChecksum bool) (string, error) {
+	defer trace.StartAsync("download.ISO").End()
 	errs := map[string]string{}
 
 	for _, url := range urls {
@@ -29,6 +29,7 @@ import (
 	"strings"
 
 	"cloud.google.com/go/storage"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/api/option"
 
 	"github.com/pkg/errors"
@@ -38,6 +39,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/style"
+	"k8s.io/minikube/pkg/trace"
 )
 
 const (
@@ -157,6 +159,7 @@ var checkPreloadExists = PreloadExists
 
 // Preload caches the preloaded images tarball on the host machine
 func Preload(k8sVersion, containerRuntime, driverName string) error {
+	defer trace.StartAsync("download.Preload", attribute.String("kubernetes.version", k8sVersion), attribute.String("container.runtime", containerRuntime)).End()
 	targetPath := TarballPath(k8sVersion, containerRuntime)
 	targetLock := targetPath + ".lock"
 
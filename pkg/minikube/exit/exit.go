@@ -30,6 +30,8 @@ import (
 
 var (
 	shell bool
+	// hooks are called with the exit code before exiting
+	hooks []func(code int)
 )
 
 // SetShell configures if we are doing a shell configuration or not
@@ -67,8 +69,16 @@ func Message(r reason.Kind, format string, args ...out.V) {
 	Code(r.ExitCode)
 }
 
+// AddHook registers a function called with the exit code before exiting
+func AddHook(fn func(code int)) {
+	hooks = append(hooks, fn)
+}
+
 // Code will exit with a code
 func Code(code int) {
+	for _, fn := range hooks {
+		fn(code)
+	}
 	if shell {
 		out.Outputf(os.Stdout, "false exit code %d\n", code)
 	}
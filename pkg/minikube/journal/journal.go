@@ -0,0 +1,285 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package journal keeps a rotating timeline of the lifecycle events of each profile, stored as CloudEvents
+package journal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/out/register"
+)
+
+// typePrefix is the prefix of the CloudEvents types of journal events
+const typePrefix = "io.k8s.sigs.minikube."
+
+var (
+	// maxSize is the size of the journal at which it is rotated, only the previous journal is kept
+	maxSize int64 = 5 * 1024 * 1024
+	// pollInterval is how often Follow checks the journal for new events
+	pollInterval = time.Second
+
+	mu sync.Mutex
+)
+
+// Kind is the kind of a journal event
+type Kind string
+
+// Kinds of journal events
+const (
+	ClusterStarted     Kind = "cluster.started"
+	ClusterStartFailed Kind = "cluster.start.failed"
+	ClusterStopped     Kind = "cluster.stopped"
+	ClusterPaused      Kind = "cluster.paused"
+	ClusterUnpaused    Kind = "cluster.unpaused"
+	ClusterSuspended   Kind = "cluster.suspended"
+	ClusterResumed     Kind = "cluster.resumed"
+	AddonEnabled       Kind = "addon.enabled"
+	AddonDisabled      Kind = "addon.disabled"
+	NodeAdded          Kind = "node.added"
+	NodeDeleted        Kind = "node.deleted"
+	NodeStarted        Kind = "node.started"
+	NodeStopped        Kind = "node.stopped"
+	TunnelStarted      Kind = "tunnel.started"
+	TunnelStopped      Kind = "tunnel.stopped"
+	MountStarted       Kind = "mount.started"
+	MountStopped       Kind = "mount.stopped"
+	ScheduleRan        Kind = "schedule.ran"
+	ScheduleFailed     Kind = "schedule.failed"
+)
+
+// Event is an event of the journal
+type Event struct {
+	Time    time.Time
+	Kind    Kind
+	Message string
+	Data    map[string]string `json:",omitempty"`
+
+	raw []byte
+}
+
+// entry implements register.Log for journal events
+type entry struct {
+	kind Kind
+}
+
+// Type returns the cloud events compatible type of this struct
+func (e *entry) Type() string {
+	return typePrefix + string(e.kind)
+}
+
+// Record appends an event to the journal of a profile. Failures are only logged, the journal is best effort.
+func Record(profile string, kind Kind, message string, data map[string]string) {
+	if err := record(localpath.EventJournal(profile), time.Now(), kind, message, data); err != nil {
+		klog.Warningf("unable to record %s event to the journal of %s: %v", kind, profile, err)
+	}
+}
+
+// NewEvent returns an event that was recorded elsewhere than in the journal, like within a node
+func NewEvent(t time.Time, kind Kind, message string, data map[string]string) Event {
+	ev := Event{Time: t, Kind: kind, Message: message, Data: data}
+	bs, err := marshal(t, kind, message, data)
+	if err != nil {
+		klog.Warningf("unable to marshal %s event: %v", kind, err)
+	}
+	ev.raw = bs
+	return ev
+}
+
+func marshal(t time.Time, kind Kind, message string, data map[string]string) ([]byte, error) {
+	d := map[string]string{"message": message}
+	for k, v := range data {
+		d[k] = v
+	}
+	ev := register.CloudEvent(&entry{kind: kind}, d)
+	ev.SetTime(t)
+	return ev.MarshalJSON()
+}
+
+func record(path string, t time.Time, kind Kind, message string, data map[string]string) error {
+	bs, err := marshal(t, kind, message, data)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if st, err := os.Stat(path); err == nil && st.Size() >= maxSize {
+		if err := os.Rename(path, path+".1"); err != nil {
+			return err
+		}
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(bs, '\n'))
+	return err
+}
+
+// Read returns the events of the journal of a profile recorded since the given time, oldest first
+func Read(profile string, since time.Time) ([]Event, error) {
+	path := localpath.EventJournal(profile)
+	var events []Event
+	for _, p := range []string{path + ".1", path} {
+		evs, _, err := readFile(p, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, ev := range evs {
+			if !ev.Time.Before(since) {
+				events = append(events, ev)
+			}
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+	return events, nil
+}
+
+// Follow calls fn with the events recorded to the journal of a profile after it was called, until ctx is done
+func Follow(ctx context.Context, profile string, fn func(Event)) error {
+	path := localpath.EventJournal(profile)
+	var offset int64
+	if st, err := os.Stat(path); err == nil {
+		offset = st.Size()
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		if st, err := os.Stat(path); err == nil && st.Size() < offset {
+			// the journal was rotated, read the events recorded before the rotation first
+			evs, _, err := readFile(path+".1", offset)
+			if err != nil {
+				return err
+			}
+			for _, ev := range evs {
+				fn(ev)
+			}
+			offset = 0
+		}
+		evs, next, err := readFile(path, offset)
+		if err != nil {
+			return err
+		}
+		for _, ev := range evs {
+			fn(ev)
+		}
+		offset = next
+	}
+}
+
+// readFile reads the complete events of a journal file after offset, returning the offset after the last one
+func readFile(path string, offset int64) ([]Event, int64, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, offset, nil
+	}
+	if err != nil {
+		return nil, offset, err
+	}
+	defer f.Close()
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset, err
+	}
+
+	var events []Event
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			// an incomplete line is still being written, read it next time
+			return events, offset, nil
+		}
+		if err != nil {
+			return events, offset, err
+		}
+		offset += int64(len(line))
+		ev, err := parse(line)
+		if err != nil {
+			klog.Warningf("skipping invalid journal event %q: %v", strings.TrimSpace(string(line)), err)
+			continue
+		}
+		events = append(events, ev)
+	}
+}
+
+func parse(line []byte) (Event, error) {
+	line = []byte(strings.TrimSpace(string(line)))
+	ce := cloudevents.NewEvent()
+	if err := json.Unmarshal(line, &ce); err != nil {
+		return Event{}, err
+	}
+	data := map[string]string{}
+	if err := ce.DataAs(&data); err != nil {
+		return Event{}, err
+	}
+	ev := Event{
+		Time:    ce.Time(),
+		Kind:    Kind(strings.TrimPrefix(ce.Type(), typePrefix)),
+		Message: data["message"],
+		raw:     line,
+	}
+	delete(data, "message")
+	if len(data) > 0 {
+		ev.Data = data
+	}
+	return ev, nil
+}
+
+// CloudEvent returns the event as recorded in the journal, a CloudEvent in JSON format
+func (e Event) CloudEvent() string {
+	return string(e.raw)
+}
+
+// String returns the event as a line of text
+func (e Event) String() string {
+	var keys []string
+	for k := range e.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var details []string
+	for _, k := range keys {
+		details = append(details, fmt.Sprintf("%s=%s", k, e.Data[k]))
+	}
+	s := fmt.Sprintf("%s  %-20s %s", e.Time.Local().Format(time.DateTime), e.Kind, e.Message)
+	if len(details) > 0 {
+		s += "  (" + strings.Join(details, ", ") + ")"
+	}
+	return s
+}
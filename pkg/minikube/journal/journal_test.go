@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package journal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+func TestRecordAndRead(t *testing.T) {
+	t.Setenv(localpath.MinikubeHome, t.TempDir())
+	path := localpath.EventJournal("p1")
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	events := []struct {
+		kind Kind
+		msg  string
+		data map[string]string
+	}{
+		{ClusterStarted, "cluster was started", map[string]string{"driver": "docker"}},
+		{AddonEnabled, "addon metrics-server was enabled", map[string]string{"addon": "metrics-server"}},
+		{ClusterStopped, "cluster was stopped", nil},
+	}
+	for i, e := range events {
+		if err := record(path, start.Add(time.Duration(i)*time.Hour), e.kind, e.msg, e.data); err != nil {
+			t.Fatalf("record() error = %v", err)
+		}
+	}
+
+	got, err := Read("p1", start.Add(30*time.Minute))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Read() returned %d events, expected 2: %+v", len(got), got)
+	}
+	if got[0].Kind != AddonEnabled || got[0].Data["addon"] != "metrics-server" || got[0].Message != "addon metrics-server was enabled" {
+		t.Errorf("Read() first event = %+v, expected the addon event", got[0])
+	}
+	if got[1].Kind != ClusterStopped || got[1].Data != nil || !got[1].Time.Equal(start.Add(2*time.Hour)) {
+		t.Errorf("Read() second event = %+v, expected the stop event", got[1])
+	}
+}
+
+func TestRotation(t *testing.T) {
+	t.Setenv(localpath.MinikubeHome, t.TempDir())
+	defer func(size int64) { maxSize = size }(maxSize)
+	maxSize = 1
+
+	path := localpath.EventJournal("p1")
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := record(path, start.Add(time.Duration(i)*time.Second), NodeAdded, "node was added", nil); err != nil {
+			t.Fatalf("record() error = %v", err)
+		}
+	}
+	if _, err := os.Stat(path + ".1"); err != nil {
+		t.Errorf("expected a rotated journal: %v", err)
+	}
+	if matches, _ := filepath.Glob(path + "*"); len(matches) != 2 {
+		t.Errorf("expected only the current and previous journal, got %v", matches)
+	}
+	got, err := Read("p1", time.Time{})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("Read() returned %d events, expected the 2 events of the current and previous journal", len(got))
+	}
+}
+
+func TestFollow(t *testing.T) {
+	t.Setenv(localpath.MinikubeHome, t.TempDir())
+	defer func(d time.Duration) { pollInterval = d }(pollInterval)
+	pollInterval = 10 * time.Millisecond
+
+	Record("p1", TunnelStarted, "old", nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	got := make(chan Event, 1)
+	go func() {
+		_ = Follow(ctx, "p1", func(ev Event) { got <- ev })
+	}()
+	time.Sleep(50 * time.Millisecond)
+	Record("p1", TunnelStopped, "new", nil)
+
+	select {
+	case ev := <-got:
+		if ev.Kind != TunnelStopped {
+			t.Errorf("Follow() returned %+v, expected only the new event", ev)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Follow() did not return the new event")
+	}
+}
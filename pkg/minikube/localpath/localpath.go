@@ -19,7 +19,6 @@ package localpath
 import (
 	"os"
 	"os/exec"
-	"path"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -73,6 +72,42 @@ func EventLog(name string) string {
 	return filepath.Join(Profile(name), "events.json")
 }
 
+// TunnelLock returns the path to the lock held by the minikube tunnel of a profile
+func TunnelLock(name string) string {
+	return filepath.Join(Profile(name), ".tunnel_lock")
+}
+
+// TunnelPid returns the path to the file holding the pid of the minikube tunnel of a profile
+func TunnelPid(name string) string {
+	return filepath.Join(Profile(name), ".tunnel_pid")
+}
+
+// ProblemRules returns the path to the directory of user problem rule files
+func ProblemRules() string {
+	return filepath.Join(MiniPath(), "rules")
+}
+
+// AddonPlugins returns the path to the directory of the installed addon plugins, one directory per addon
+func AddonPlugins() string {
+	return filepath.Join(MiniPath(), "addon-plugins")
+}
+
+// EventJournal returns the path to the event journal of a profile
+// This log contains the timeline of lifecycle events of the cluster, like starts, stops and addon changes.
+func EventJournal(name string) string {
+	return filepath.Join(Profile(name), "journal.json")
+}
+
+// AddonSecrets returns the path to the secret addon values of a profile, which are kept out of its config
+func AddonSecrets(name string) string {
+	return filepath.Join(Profile(name), "addon-secrets.json")
+}
+
+// IdleState returns the path to the idle state of a profile, the client activity of its API server measured by the schedule daemon
+func IdleState(name string) string {
+	return filepath.Join(Profile(name), "idle.json")
+}
+
 // AuditLog returns the path to the audit log.
 // This log contains a history of commands run, by who, when, and what arguments.
 func AuditLog() string {
@@ -84,6 +119,17 @@ func LastStartLog() string {
 	return filepath.Join(MiniPath(), "logs", "lastStart.txt")
 }
 
+// TraceFile returns the path to the file written by the json tracer.
+// It contains one span per line for the last traced command.
+func TraceFile() string {
+	return filepath.Join(MiniPath(), "logs", "trace.json")
+}
+
+// SchedulerLog returns the path to the log of the schedule daemon.
+func SchedulerLog() string {
+	return filepath.Join(MiniPath(), "logs", "scheduler.log")
+}
+
 // ClientCert returns client certificate path, used by kubeconfig
 func ClientCert(name string) string {
 	newCert := filepath.Join(Profile(name), "client.crt")
@@ -104,9 +150,14 @@ func ClientCert(name string) string {
 	return newCert
 }
 
-// PID returns the path to the pid file used by profile for scheduled stop
-func PID(profile string) string {
-	return path.Join(Profile(profile), "pid")
+// SchedulerWake returns the path to the file written to wake the schedule daemon up when schedules change
+func SchedulerWake() string {
+	return filepath.Join(MiniPath(), "scheduler.wake")
+}
+
+// SchedulerPID returns the path to the pid file of the schedule daemon, which runs the schedules of all profiles
+func SchedulerPID() string {
+	return filepath.Join(MiniPath(), "scheduler.pid")
 }
 
 // ClientKey returns client certificate path, used by kubeconfig
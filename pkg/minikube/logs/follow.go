@@ -0,0 +1,292 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+)
+
+// Components of the followed logs besides the pod names
+const (
+	KubeletComponent = "kubelet"
+	RuntimeComponent = "runtime"
+)
+
+// FollowOptions are the filters of the logs followed on all nodes
+type FollowOptions struct {
+	// Components are the followed components: kubelet, runtime (or the runtime name) and pod names, empty follows all of them
+	Components []string
+	// Grep only outputs the lines matching it
+	Grep *regexp.Regexp
+	// Since starts the logs at the given time, instead of the last Lines lines
+	Since time.Time
+	// Lines is the number of recent lines of every source to start with
+	Lines int
+	// JSON outputs each line as a JSON object
+	JSON bool
+}
+
+// NodeLogs is a node to follow the logs of
+type NodeLogs struct {
+	// Name is the machine name of the node
+	Name    string
+	Runtime cruntime.Manager
+	Runner  logRunner
+}
+
+// Source is a log followed on a node
+type Source struct {
+	Node string
+	// Name is the name of the log, like kubelet or "kube-proxy [id]"
+	Name string
+	// Component is the component the log belongs to, the runtime for its system log
+	Component string
+	Cmd       string
+
+	runner logRunner
+}
+
+// Line is a line of a followed log, in the format of the JSON output
+type Line struct {
+	Time   time.Time `json:"time"`
+	Node   string    `json:"node"`
+	Source string    `json:"source"`
+	Line   string    `json:"line"`
+}
+
+// FollowSources returns the logs followed on a node: kubelet, the container runtime and the containers of the important pods
+func FollowSources(n NodeLogs, bs bootstrapper.Bootstrapper, cfg config.ClusterConfig, opts FollowOptions) []Source {
+	lines := opts.Lines
+	if !opts.Since.IsZero() {
+		lines = 0
+	}
+	var srcs []Source
+
+	if wanted(opts, KubeletComponent, n.Runtime) {
+		cmd := bs.LogCommands(cfg, bootstrapper.LogOptions{Lines: lines, Follow: true})[KubeletComponent]
+		srcs = append(srcs, Source{Node: n.Name, Name: KubeletComponent, Component: KubeletComponent, Cmd: journalSince(cmd, opts.Since), runner: n.Runner})
+	}
+	if wanted(opts, RuntimeComponent, n.Runtime) {
+		cmd := n.Runtime.SystemLogCmd(lines) + " -f"
+		srcs = append(srcs, Source{Node: n.Name, Name: n.Runtime.Name(), Component: RuntimeComponent, Cmd: journalSince(cmd, opts.Since), runner: n.Runner})
+	}
+
+	return append(srcs, ContainerSources(n, cfg, opts, lines)...)
+}
+
+// ContainerSources returns the logs of the containers of the important pods on a node, starting with the given number of recent lines
+func ContainerSources(n NodeLogs, cfg config.ClusterConfig, opts FollowOptions, lines int) []Source {
+	var srcs []Source
+	pods := append(append([]string{}, importantPods...), enabledAddonPods(cfg)...)
+	for _, pod := range pods {
+		if !wanted(opts, pod, n.Runtime) {
+			continue
+		}
+		ids, err := n.Runtime.ListContainers(cruntime.ListContainersOptions{Name: pod})
+		if err != nil {
+			klog.Errorf("Failed to list containers for %q on %s: %v", pod, n.Name, err)
+			continue
+		}
+		for _, id := range ids {
+			cmd := containerSince(n.Runtime.ContainerLogCmd(id, lines, true), opts.Since)
+			srcs = append(srcs, Source{Node: n.Name, Name: fmt.Sprintf("%s [%s]", pod, truncateID(id)), Component: pod, Cmd: cmd, runner: n.Runner})
+		}
+	}
+	return srcs
+}
+
+// wanted returns if the logs of a component are followed
+func wanted(opts FollowOptions, component string, r cruntime.Manager) bool {
+	if len(opts.Components) == 0 {
+		return true
+	}
+	for _, c := range opts.Components {
+		if strings.EqualFold(c, component) {
+			return true
+		}
+		if component == RuntimeComponent && strings.EqualFold(c, r.Name()) {
+			return true
+		}
+	}
+	return false
+}
+
+// journalSince starts a journalctl command at the given time, the last -n flag wins so it overrides the line count
+func journalSince(cmd string, since time.Time) string {
+	if since.IsZero() {
+		return cmd
+	}
+	return fmt.Sprintf("%s -n all --since @%d", cmd, since.Unix())
+}
+
+// containerSince starts a docker or crictl logs command at the given time
+func containerSince(cmd string, since time.Time) string {
+	if since.IsZero() {
+		return cmd
+	}
+	return strings.Replace(cmd, " logs ", fmt.Sprintf(" logs --since %s ", since.UTC().Format(time.RFC3339)), 1)
+}
+
+func truncateID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// rescanInterval is how often the followed sources are listed again, to follow the containers created since, like restarted ones
+var rescanInterval = 10 * time.Second
+
+// FollowNodes follows the logs of all sources at once, prefixing every line with its node and source.
+// rescan, if not nil, lists the sources again periodically and the new ones are followed as well,
+// until none of the sources is followed anymore.
+func FollowNodes(srcs []Source, rescan func() []Source, opts FollowOptions, logOutput io.Writer) error {
+	if len(srcs) == 0 {
+		return fmt.Errorf("no logs found for the given components")
+	}
+
+	var mu sync.Mutex
+	done := make(chan error)
+	followed := map[string]bool{}
+	running := 0
+	follow := func(srcs []Source) int {
+		n := 0
+		for _, src := range srcs {
+			key := src.Node + "/" + src.Name
+			if followed[key] {
+				continue
+			}
+			followed[key] = true
+			running++
+			n++
+			go func(src Source) {
+				w := &lineWriter{src: src, opts: opts, out: logOutput, mu: &mu}
+				c := exec.Command("/bin/bash", "-c", src.Cmd)
+				c.Stdout = w
+				c.Stderr = w
+				_, err := src.runner.RunCmd(c)
+				w.flush()
+				if err != nil {
+					klog.Warningf("following %s on %s: %v", src.Name, src.Node, err)
+					err = errors.Wrapf(err, "%s on %s", src.Name, src.Node)
+				}
+				done <- err
+			}(src)
+		}
+		return n
+	}
+	follow(srcs)
+
+	ticker := time.NewTicker(rescanInterval)
+	defer ticker.Stop()
+	var errs []error
+	for running > 0 {
+		select {
+		case err := <-done:
+			running--
+			if err != nil {
+				errs = append(errs, err)
+			}
+			if running == 0 && rescan != nil {
+				follow(rescan())
+			}
+		case <-ticker.C:
+			if rescan != nil {
+				if n := follow(rescan()); n > 0 {
+					klog.Infof("following %d new sources", n)
+				}
+			}
+		}
+	}
+
+	// the follow only failed if none of the sources could be followed
+	if len(errs) < len(followed) {
+		return nil
+	}
+	return errs[0]
+}
+
+// lineWriter writes the complete lines of a followed log that match the filter
+type lineWriter struct {
+	src  Source
+	opts FollowOptions
+	out  io.Writer
+	mu   *sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf.Write(p)
+	for {
+		i := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		line := string(w.buf.Next(i + 1))
+		if err := w.writeLine(strings.TrimRight(line, "\r\n")); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// flush writes the last line when it does not end with a newline
+func (w *lineWriter) flush() {
+	if w.buf.Len() > 0 {
+		if err := w.writeLine(w.buf.String()); err != nil {
+			klog.Warningf("writing log line: %v", err)
+		}
+		w.buf.Reset()
+	}
+}
+
+func (w *lineWriter) writeLine(line string) error {
+	if w.opts.Grep != nil && !w.opts.Grep.MatchString(line) {
+		return nil
+	}
+	text := FormatLine(Line{Time: time.Now(), Node: w.src.Node, Source: w.src.Name, Line: line}, w.opts.JSON)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err := io.WriteString(w.out, text+"\n")
+	return err
+}
+
+// FormatLine formats a followed line as text prefixed with its node and source, or as JSON
+func FormatLine(l Line, asJSON bool) string {
+	if asJSON {
+		bs, err := json.Marshal(l)
+		if err == nil {
+			return string(bs)
+		}
+		klog.Warningf("marshalling log line: %v", err)
+	}
+	return fmt.Sprintf("[%s] %s: %s", l.Node, l.Source, l.Line)
+}
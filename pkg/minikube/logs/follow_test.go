@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/minikube/pkg/minikube/command"
+)
+
+// fakeLogRunner writes the output of a log command to its stdout, in two writes to split lines
+type fakeLogRunner struct {
+	output map[string]string
+}
+
+func (f fakeLogRunner) RunCmd(c *exec.Cmd) (*command.RunResult, error) {
+	o, ok := f.output[c.Args[2]]
+	if !ok {
+		return &command.RunResult{}, fmt.Errorf("unknown command %s", c.Args[2])
+	}
+	half := len(o) / 2
+	if _, err := c.Stdout.Write([]byte(o[:half])); err != nil {
+		return nil, err
+	}
+	if _, err := c.Stdout.Write([]byte(o[half:])); err != nil {
+		return nil, err
+	}
+	return &command.RunResult{}, nil
+}
+
+func TestFollowNodes(t *testing.T) {
+	r1 := fakeLogRunner{output: map[string]string{"kubelet": "started kubelet\nfailed to pull image\n", "kindnet": "handling node\nno route to host"}}
+	r2 := fakeLogRunner{output: map[string]string{"kubelet": "failed to mount volume\n"}}
+	srcs := []Source{
+		{Node: "minikube", Name: "kubelet", Cmd: "kubelet", runner: r1},
+		{Node: "minikube", Name: "kindnet [abc]", Cmd: "kindnet", runner: r1},
+		{Node: "minikube-m02", Name: "kubelet", Cmd: "kubelet", runner: r2},
+		{Node: "minikube-m02", Name: "kindnet [def]", Cmd: "missing", runner: r2},
+	}
+
+	var b bytes.Buffer
+	opts := FollowOptions{Grep: regexp.MustCompile("fail|route")}
+	if err := FollowNodes(srcs, nil, opts, &b); err != nil {
+		t.Fatalf("FollowNodes() error = %v", err)
+	}
+	got := strings.Split(strings.TrimSpace(b.String()), "\n")
+	sort.Strings(got)
+	expected := []string{
+		"[minikube-m02] kubelet: failed to mount volume",
+		"[minikube] kindnet [abc]: no route to host",
+		"[minikube] kubelet: failed to pull image",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("FollowNodes() output =\n%s\nexpected\n%s", strings.Join(got, "\n"), strings.Join(expected, "\n"))
+	}
+
+	if err := FollowNodes(srcs[3:], nil, opts, &b); err == nil {
+		t.Errorf("FollowNodes() of failing sources expected an error")
+	}
+}
+
+func TestFollowNodesRescan(t *testing.T) {
+	r := fakeLogRunner{output: map[string]string{"etcd-1": "first\n", "etcd-2": "restarted\n"}}
+	first := Source{Node: "minikube", Name: "etcd [1]", Cmd: "etcd-1", runner: r}
+	restarted := Source{Node: "minikube", Name: "etcd [2]", Cmd: "etcd-2", runner: r}
+	scans := 0
+	rescan := func() []Source {
+		scans++
+		// the exited container is still listed, and must not be followed again
+		return []Source{first, restarted}
+	}
+
+	var b bytes.Buffer
+	if err := FollowNodes([]Source{first}, rescan, FollowOptions{}, &b); err != nil {
+		t.Fatalf("FollowNodes() error = %v", err)
+	}
+	expected := "[minikube] etcd [1]: first\n[minikube] etcd [2]: restarted\n"
+	if b.String() != expected {
+		t.Errorf("FollowNodes() output =\n%s\nexpected\n%s", b.String(), expected)
+	}
+	if scans != 2 {
+		t.Errorf("expected 2 rescans once the sources ended, got %d", scans)
+	}
+}
+
+func TestFormatLineJSON(t *testing.T) {
+	l := Line{Time: time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC), Node: "minikube", Source: "kubelet", Line: "started"}
+	var got Line
+	if err := json.Unmarshal([]byte(FormatLine(l, true)), &got); err != nil {
+		t.Fatalf("invalid JSON line: %v", err)
+	}
+	if got != l {
+		t.Errorf("FormatLine() = %+v, expected %+v", got, l)
+	}
+}
+
+func TestSince(t *testing.T) {
+	since := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	if got, expected := journalSince("sudo journalctl -u kubelet -n 60 -f", since), "sudo journalctl -u kubelet -n 60 -f -n all --since @1717236000"; got != expected {
+		t.Errorf("journalSince() = %q, expected %q", got, expected)
+	}
+	if got, expected := containerSince("sudo /usr/bin/crictl logs --follow abc", since), "sudo /usr/bin/crictl logs --since 2024-06-01T10:00:00Z --follow abc"; got != expected {
+		t.Errorf("containerSince() = %q, expected %q", got, expected)
+	}
+	if got := containerSince("docker logs --follow abc", time.Time{}); got != "docker logs --follow abc" {
+		t.Errorf("containerSince() without a time = %q", got)
+	}
+}
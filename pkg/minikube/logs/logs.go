@@ -24,10 +24,10 @@ import (
 	"io"
 	"os"
 	"os/exec"
-	"regexp"
 	"sort"
 	"strings"
 
+	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/assets"
@@ -38,41 +38,10 @@ import (
 	"k8s.io/minikube/pkg/minikube/cruntime"
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/problem"
 	"k8s.io/minikube/pkg/minikube/style"
 )
 
-// rootCauses are regular expressions that match known failures
-var rootCauses = []string{
-	`^error: `,
-	`eviction manager: pods.* evicted`,
-	`unknown flag: --`,
-	`forbidden.*no providers available`,
-	`eviction manager:.*evicted`,
-	`tls: bad certificate`,
-	`kubelet.*no API client`,
-	`kubelet.*No api server`,
-	`STDIN.*127.0.0.1:8080`,
-	`failed to create listener`,
-	`address already in use`,
-	`unable to evict any pods`,
-	`eviction manager: unexpected error`,
-	`Resetting AnonymousAuth to false`,
-	`Unable to register node.*forbidden`,
-	`Failed to initialize CSINodeInfo.*forbidden`,
-	`Failed to admit pod`,
-	`failed to "StartContainer"`,
-	`Failed to start ContainerManager`,
-	`kubelet.*forbidden.*cannot \w+ resource`,
-	`leases.*forbidden.*cannot \w+ resource`,
-	`failed to start daemon`,
-}
-
-// rootCauseRe combines rootCauses into a single regex
-var rootCauseRe = regexp.MustCompile(strings.Join(rootCauses, "|"))
-
-// ignoreCauseRe is a regular expression that matches spurious errors to not surface
-var ignoreCauseRe = regexp.MustCompile("error: no objects passed to apply")
-
 // importantPods are a list of pods to retrieve logs for, in addition to the bootstrapper logs.
 var importantPods = []string{
 	"kube-apiserver",
@@ -112,14 +81,31 @@ func Follow(r cruntime.Manager, bs bootstrapper.Bootstrapper, cfg config.Cluster
 
 // IsProblem returns whether this line matches a known problem
 func IsProblem(line string) bool {
-	return rootCauseRe.MatchString(line) && !ignoreCauseRe.MatchString(line)
+	return problem.Default().Match("", line, semver.Version{}) != nil
 }
 
 // FindProblems finds possible root causes among the logs
 func FindProblems(r cruntime.Manager, bs bootstrapper.Bootstrapper, cfg config.ClusterConfig, cr logRunner) map[string][]string {
 	pMap := map[string][]string{}
+	for _, m := range DetectProblems(r, bs, cfg, cr) {
+		pMap[m.Source] = append(pMap[m.Source], m.Line)
+	}
+	return pMap
+}
+
+// DetectProblems matches the recent logs against the problem rules
+func DetectProblems(r cruntime.Manager, bs bootstrapper.Bootstrapper, cfg config.ClusterConfig, cr logRunner) []problem.Match {
+	engine := problem.Default()
+	version := problem.ParseKubernetesVersion(cfg.KubernetesConfig.KubernetesVersion)
+
+	var matches []problem.Match
 	cmds := logCommands(r, bs, cfg, lookBackwardsCount, false)
+	names := []string{}
 	for name := range cmds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
 		klog.Infof("Gathering logs for %s ...", name)
 		var b bytes.Buffer
 		c := exec.Command("/bin/bash", "-c", cmds[name])
@@ -130,37 +116,57 @@ func FindProblems(r cruntime.Manager, bs bootstrapper.Bootstrapper, cfg config.C
 			klog.Warningf("failed %s: command: %s %v output: %s", name, rr.Command(), err, rr.Output())
 			continue
 		}
+		component := problem.Component(name)
+		if name == r.Name() {
+			component = problem.RuntimeComponent
+		}
 		scanner := bufio.NewScanner(&b)
-		problems := []string{}
 		for scanner.Scan() {
 			l := scanner.Text()
-			if IsProblem(l) {
-				klog.Warningf("Found %s problem: %s", name, l)
-				problems = append(problems, l)
+			if rule := engine.Match(component, l, version); rule != nil {
+				klog.Warningf("Found %s problem %s: %s", name, rule.ID, l)
+				matches = append(matches, problem.Match{Rule: rule, Source: name, Line: l})
 			}
 		}
 		if err := scanner.Err(); err != nil {
 			klog.Warningf("failed to read output: %v", err)
 		}
-		if len(problems) > 0 {
-			pMap[name] = problems
-		}
 	}
-	return pMap
+	return matches
 }
 
-// OutputProblems outputs discovered problems.
-func OutputProblems(problems map[string][]string, maxLines int, logOutput *os.File) {
+// OutputMatches outputs the problems found by the rules, with the last lines of every problem and its remediation
+func OutputMatches(matches []problem.Match, maxLines int, logOutput *os.File) {
 	out.SetErrFile(logOutput)
 	defer out.SetErrFile(os.Stderr)
 
-	for name, lines := range problems {
-		out.FailureT("Problems detected in {{.name}}:", out.V{"name": name})
-		if len(lines) > maxLines {
-			lines = lines[len(lines)-maxLines:]
+	for _, group := range problem.Group(matches) {
+		rule := group[0].Rule
+		sources := []string{}
+		seen := map[string]bool{}
+		for _, m := range group {
+			if !seen[m.Source] {
+				seen[m.Source] = true
+				sources = append(sources, m.Source)
+			}
+		}
+		out.FailureT("Problem {{.id}} detected in {{.sources}}:", out.V{"id": rule.ID, "sources": strings.Join(sources, ", ")})
+		if len(group) > maxLines {
+			group = group[len(group)-maxLines:]
+		}
+		for _, m := range group {
+			out.ErrT(style.LogEntry, m.Line)
+		}
+
+		k := rule.Kind()
+		if k.Advice != "" {
+			out.ErrT(style.Tip, "Suggestion: {{.advice}}", out.V{"advice": k.Advice})
+		}
+		if k.URL != "" {
+			out.ErrT(style.Documentation, "Documentation: {{.url}}", out.V{"url": k.URL})
 		}
-		for _, l := range lines {
-			out.ErrT(style.LogEntry, l)
+		for _, u := range k.IssueURLs() {
+			out.ErrT(style.Issues, "Related issue: {{.url}}", out.V{"url": u})
 		}
 	}
 }
@@ -138,6 +138,14 @@ func recreateIfNeeded(api libmachine.API, cc *config.ClusterConfig, n *config.No
 		klog.Warningf("unexpected machine state, will restart: %v", serr)
 	}
 
+	if s == state.Paused && SupportsSuspend(h.DriverName, false) {
+		out.Step(style.Unpause, `Resuming suspended {{.driver_name}} "{{.cluster}}" {{.machine_type}} ...`, out.V{"driver_name": cc.Driver, "cluster": machineName, "machine_type": machineType})
+		if err := resumeHost(h); err != nil {
+			return h, errors.Wrap(err, "resume")
+		}
+		s = state.Running
+	}
+
 	if s == state.Running {
 		if !recreated {
 			register.Reg.SetStep(register.UpdatingDriver)
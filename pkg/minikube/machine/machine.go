@@ -27,6 +27,7 @@ import (
 	libprovision "github.com/docker/machine/libmachine/provision"
 	"github.com/pkg/errors"
 	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/drivers/kic/oci"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/vmpath"
@@ -146,6 +147,13 @@ func saveHost(api libmachine.API, h *host.Host, cfg *config.ClusterConfig, n *co
 		ip = "10.0.2.15"
 	}
 	n.IP = ip
+	if driver.IsKIC(h.Driver.DriverName()) && config.HasIPv6(*cfg) {
+		_, ipv6, err := oci.ContainerIPs(h.Driver.DriverName(), h.Name)
+		if err != nil {
+			return errors.Wrap(err, "container IPv6")
+		}
+		n.IPv6 = ipv6
+	}
 	return config.SaveNode(cfg, n)
 }
 
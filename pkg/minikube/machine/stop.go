@@ -50,6 +50,13 @@ func StopHost(api libmachine.API, machineName string) error {
 func stop(h *host.Host) error {
 	start := time.Now()
 
+	// a node suspended in memory is frozen, resume it so that it shuts down cleanly
+	if s, err := h.Driver.GetState(); err == nil && s == state.Paused && SupportsSuspend(h.DriverName, false) {
+		if err := resumeHost(h); err != nil {
+			klog.Warningf("failed to resume suspended host (will continue): %v", err)
+		}
+	}
+
 	if driver.IsVM(h.DriverName) {
 		if err := backup(*h, []string{"/etc/cni", "/etc/kubernetes"}); err != nil {
 			klog.Warningf("failed to complete vm config backup (will continue): %v", err)
@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"bytes"
+	"os/exec"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/docker/machine/libmachine/host"
+	"github.com/docker/machine/libmachine/state"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+	"k8s.io/minikube/pkg/minikube/driver"
+)
+
+// kvmDriverBinary is the kvm2 driver plugin, which also suspends and resumes its domains
+const kvmDriverBinary = "docker-machine-driver-kvm2"
+
+// ErrSuspendNotSupported is returned when the driver of a node is unable to suspend it
+var ErrSuspendNotSupported = errors.New("suspend is not supported by this driver")
+
+// SupportsSuspend returns whether the nodes of a driver can be suspended, in memory or to disk
+func SupportsSuspend(drv string, toDisk bool) bool {
+	switch {
+	case driver.IsKIC(drv):
+		// a paused container keeps its memory, there is no checkpoint to disk
+		return !toDisk
+	case drv == driver.KVM2:
+		return true
+	}
+	return false
+}
+
+// IsSuspended returns whether a host status, as returned by Status, is the one of a suspended node
+func IsSuspended(status string) bool {
+	return status == state.Paused.String() || status == state.Saved.String()
+}
+
+// SuspendHost freezes a whole node, unlike pausing which freezes its containers and stops its kubelet.
+// The node keeps its memory, or saves it to disk if toDisk is set.
+func SuspendHost(api libmachine.API, machineName string, toDisk bool) error {
+	h, err := LoadHost(api, machineName)
+	if err != nil {
+		return errors.Wrap(err, "load")
+	}
+	if !SupportsSuspend(h.DriverName, toDisk) {
+		return ErrSuspendNotSupported
+	}
+	s, err := h.Driver.GetState()
+	if err != nil {
+		return errors.Wrap(err, "state")
+	}
+	if s != state.Running {
+		return errors.Errorf("%s is not running: %s", machineName, s)
+	}
+
+	klog.Infof("suspending %s (to disk: %t)", machineName, toDisk)
+	if driver.IsKIC(h.DriverName) {
+		return oci.PauseContainer(h.DriverName, machineName)
+	}
+	return runKVMDriver(h, "suspend", toDisk)
+}
+
+// ResumeHost thaws a node frozen by SuspendHost, then fixes up its clock
+func ResumeHost(api libmachine.API, machineName string) error {
+	h, err := LoadHost(api, machineName)
+	if err != nil {
+		return errors.Wrap(err, "load")
+	}
+	s, err := h.Driver.GetState()
+	if err != nil {
+		return errors.Wrap(err, "state")
+	}
+	if s == state.Running {
+		klog.Infof("%s is already running", machineName)
+		return nil
+	}
+	if !IsSuspended(s.String()) {
+		return errors.Errorf("%s is not suspended: %s", machineName, s)
+	}
+	return resumeHost(h)
+}
+
+func resumeHost(h *host.Host) error {
+	klog.Infof("resuming %s", h.Name)
+	switch {
+	case driver.IsKIC(h.DriverName):
+		if err := oci.UnpauseContainer(h.DriverName, h.Name); err != nil {
+			return err
+		}
+	case h.DriverName == driver.KVM2:
+		// the kvm2 driver also reserves the DHCP lease again, as it may have expired meanwhile
+		if err := runKVMDriver(h, "resume", false); err != nil {
+			return err
+		}
+	default:
+		return ErrSuspendNotSupported
+	}
+
+	// the clock of a VM stood still while it was suspended, containers share the clock of the host
+	if err := ensureSyncedGuestClock(h, h.DriverName); err != nil {
+		return errors.Wrap(err, "syncing guest clock")
+	}
+	return nil
+}
+
+// runKVMDriver suspends or resumes a domain, the plugin protocol only carries the methods of the libmachine driver interface
+func runKVMDriver(h *host.Host, action string, toDisk bool) error {
+	args := []string{action}
+	if toDisk {
+		args = append(args, "--to-disk")
+	}
+	c := exec.Command(kvmDriverBinary, args...)
+	c.Stdin = bytes.NewReader(h.RawDriver)
+	if out, err := c.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "%s %s (the kvm2 driver may need to be updated): %s", kvmDriverBinary, action, out)
+	}
+	return nil
+}
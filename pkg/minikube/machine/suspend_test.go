@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	"github.com/docker/machine/libmachine/state"
+	"k8s.io/minikube/pkg/minikube/driver"
+)
+
+func TestSupportsSuspend(t *testing.T) {
+	tests := []struct {
+		driver string
+		toDisk bool
+		want   bool
+	}{
+		{driver.Docker, false, true},
+		{driver.Podman, false, true},
+		{driver.Docker, true, false},
+		{driver.KVM2, false, true},
+		{driver.KVM2, true, true},
+		{driver.VirtualBox, false, false},
+		{driver.None, false, false},
+	}
+	for _, tc := range tests {
+		if got := SupportsSuspend(tc.driver, tc.toDisk); got != tc.want {
+			t.Errorf("SupportsSuspend(%q, %t) = %t, want %t", tc.driver, tc.toDisk, got, tc.want)
+		}
+	}
+}
+
+func TestIsSuspended(t *testing.T) {
+	for st, want := range map[state.State]bool{
+		state.Paused:  true,
+		state.Saved:   true,
+		state.Running: false,
+		state.Stopped: false,
+	} {
+		if got := IsSuspended(st.String()); got != want {
+			t.Errorf("IsSuspended(%q) = %t, want %t", st, got, want)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics exposes the resource usage and state of the minikube profiles of a host as Prometheus metrics
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/docker/machine/libmachine/state"
+	"github.com/prometheus/client_golang/prometheus"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/kapi"
+	"k8s.io/minikube/pkg/minikube/cluster"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/journal"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/tunnel"
+)
+
+const namespace = "minikube"
+
+var (
+	profileLabels = []string{"profile"}
+	nodeLabels    = []string{"profile", "node"}
+
+	profileInfo   = desc("profile_info", "Information about the profile, always 1", "profile", "driver", "container_runtime", "kubernetes_version")
+	scrapeError   = desc("profile_scrape_error", "1 if the status of the profile could not be read", profileLabels...)
+	paused        = desc("profile_paused", "1 if the cluster is paused", profileLabels...)
+	tunnelRunning = desc("profile_tunnel_running", "1 if a minikube tunnel is running for the profile", profileLabels...)
+	sinceStart    = desc("profile_seconds_since_last_start", "Seconds since the cluster was last started", profileLabels...)
+	componentUp   = desc("node_component_up", "1 if the component of the node is running, for the host, kubelet and apiserver components", "profile", "node", "component")
+	cpuRatio      = desc("node_cpu_usage_ratio", "CPU usage of the node container, 1 is one full core", nodeLabels...)
+	cpuSeconds    = desc("node_cpu_seconds_total", "CPU time used by the node VM", nodeLabels...)
+	memUsage      = desc("node_memory_usage_bytes", "Memory used by the node container or VM", nodeLabels...)
+	memLimit      = desc("node_memory_limit_bytes", "Memory available to the node container or VM", nodeLabels...)
+	diskUsed      = desc("node_disk_used_bytes", "Disk space used on the node by /var", nodeLabels...)
+	diskSize      = desc("node_disk_size_bytes", "Disk space of the node for /var", nodeLabels...)
+	pods          = desc("node_pods", "Number of pods on the node by phase", "profile", "node", "phase")
+)
+
+func desc(name, help string, labels ...string) *prometheus.Desc {
+	return prometheus.NewDesc(prometheus.BuildFQName(namespace, "", name), help, labels, nil)
+}
+
+// Collector collects the metrics of the minikube profiles of the host on every scrape
+type Collector struct {
+	api libmachine.API
+	// profiles limits the collected profiles, all valid profiles are collected if empty
+	profiles []string
+	// mu serializes the scrapes, the machine API is not safe for concurrent use
+	mu sync.Mutex
+}
+
+// NewCollector returns a collector of the given profiles, or of all profiles if none are given
+func NewCollector(api libmachine.API, profiles ...string) *Collector {
+	return &Collector{api: api, profiles: profiles}
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{profileInfo, scrapeError, paused, tunnelRunning, sinceStart, componentUp, cpuRatio, cpuSeconds, memUsage, memLimit, diskUsed, diskSize, pods} {
+		ch <- d
+	}
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, cc := range c.configs() {
+		c.collectProfile(ch, cc)
+	}
+}
+
+// configs returns the configs of the collected profiles
+func (c *Collector) configs() []*config.ClusterConfig {
+	var ccs []*config.ClusterConfig
+	if len(c.profiles) > 0 {
+		for _, name := range c.profiles {
+			cc, err := config.Load(name)
+			if err != nil {
+				klog.Warningf("unable to load profile %s: %v", name, err)
+				continue
+			}
+			ccs = append(ccs, cc)
+		}
+		return ccs
+	}
+	valid, _, err := config.ListProfiles()
+	if err != nil {
+		klog.Warningf("unable to list profiles: %v", err)
+	}
+	for _, p := range valid {
+		ccs = append(ccs, p.Config)
+	}
+	return ccs
+}
+
+func (c *Collector) collectProfile(ch chan<- prometheus.Metric, cc *config.ClusterConfig) {
+	name := cc.Name
+	ch <- prometheus.MustNewConstMetric(profileInfo, prometheus.GaugeValue, 1, name, cc.Driver, cc.KubernetesConfig.ContainerRuntime, cc.KubernetesConfig.KubernetesVersion)
+	ch <- prometheus.MustNewConstMetric(tunnelRunning, prometheus.GaugeValue, boolValue(tunnel.ProfileTunnelRunning(name)), name)
+	if t, ok := lastStart(name); ok {
+		ch <- prometheus.MustNewConstMetric(sinceStart, prometheus.GaugeValue, time.Since(t).Seconds(), name)
+	}
+
+	statuses, err := cluster.GetStatus(c.api, cc)
+	if err != nil {
+		klog.Warningf("unable to get the status of %s: %v", name, err)
+		ch <- prometheus.MustNewConstMetric(scrapeError, prometheus.GaugeValue, 1, name)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(scrapeError, prometheus.GaugeValue, 0, name)
+	ch <- prometheus.MustNewConstMetric(paused, prometheus.GaugeValue, boolValue(len(statuses) > 0 && statuses[0].APIServer == state.Paused.String()), name)
+
+	var running []string
+	apiServerUp := false
+	for _, st := range statuses {
+		ch <- prometheus.MustNewConstMetric(componentUp, prometheus.GaugeValue, boolValue(st.Host == state.Running.String()), name, st.Name, "host")
+		ch <- prometheus.MustNewConstMetric(componentUp, prometheus.GaugeValue, boolValue(st.Kubelet == state.Running.String()), name, st.Name, "kubelet")
+		if !st.Worker {
+			up := st.APIServer == state.Running.String()
+			apiServerUp = apiServerUp || up
+			ch <- prometheus.MustNewConstMetric(componentUp, prometheus.GaugeValue, boolValue(up), name, st.Name, "apiserver")
+		}
+		if st.Host == state.Running.String() {
+			running = append(running, st.Name)
+		}
+	}
+	if len(running) == 0 {
+		return
+	}
+
+	stats, err := Machines(cc, running)
+	if err != nil {
+		klog.Warningf("unable to get the machine stats of %s: %v", name, err)
+	}
+	for node, st := range stats {
+		gauge(ch, cpuRatio, prometheus.GaugeValue, st.CPURatio, name, node)
+		gauge(ch, cpuSeconds, prometheus.CounterValue, st.CPUSeconds, name, node)
+		gauge(ch, memUsage, prometheus.GaugeValue, st.MemoryUsage, name, node)
+		gauge(ch, memLimit, prometheus.GaugeValue, st.MemoryLimit, name, node)
+	}
+
+	for _, node := range running {
+		c.collectDisk(ch, name, node)
+	}
+	if apiServerUp {
+		collectPods(ch, name)
+	}
+}
+
+// collectDisk collects the disk usage of /var on a node, where minikube keeps the images and volumes
+func (c *Collector) collectDisk(ch chan<- prometheus.Metric, profile, node string) {
+	h, err := machine.LoadHost(c.api, node)
+	if err != nil {
+		klog.Warningf("unable to load host %s: %v", node, err)
+		return
+	}
+	r, err := machine.CommandRunner(h)
+	if err != nil {
+		klog.Warningf("unable to get a runner for %s: %v", node, err)
+		return
+	}
+	size, used, err := DiskUsage(r)
+	if err != nil {
+		klog.Warningf("unable to get the disk usage of %s: %v", node, err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(diskSize, prometheus.GaugeValue, size, profile, node)
+	ch <- prometheus.MustNewConstMetric(diskUsed, prometheus.GaugeValue, used, profile, node)
+}
+
+// collectPods collects the number of pods of every node by phase
+func collectPods(ch chan<- prometheus.Metric, profile string) {
+	client, err := kapi.Client(profile)
+	if err != nil {
+		klog.Warningf("unable to get a client for %s: %v", profile, err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	list, err := client.CoreV1().Pods("").List(ctx, meta.ListOptions{})
+	if err != nil {
+		klog.Warningf("unable to list the pods of %s: %v", profile, err)
+		return
+	}
+	for key, count := range countPods(list.Items) {
+		ch <- prometheus.MustNewConstMetric(pods, prometheus.GaugeValue, float64(count), profile, key.node, key.phase)
+	}
+}
+
+type podKey struct {
+	node  string
+	phase string
+}
+
+// countPods counts the scheduled pods by node and phase
+func countPods(items []core.Pod) map[podKey]int {
+	counts := map[podKey]int{}
+	for _, p := range items {
+		if p.Spec.NodeName == "" {
+			continue
+		}
+		counts[podKey{node: p.Spec.NodeName, phase: string(p.Status.Phase)}]++
+	}
+	return counts
+}
+
+// lastStart returns the time the cluster was last started, from the event journal
+func lastStart(profile string) (time.Time, bool) {
+	events, err := journal.Read(profile, time.Time{})
+	if err != nil {
+		klog.Warningf("unable to read the journal of %s: %v", profile, err)
+		return time.Time{}, false
+	}
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].Kind == journal.ClusterStarted {
+			return events[i].Time, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func gauge(ch chan<- prometheus.Metric, d *prometheus.Desc, t prometheus.ValueType, v *float64, labels ...string) {
+	if v != nil {
+		ch <- prometheus.MustNewConstMetric(d, t, *v, labels...)
+	}
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	core "k8s.io/api/core/v1"
+)
+
+func TestParseContainerStats(t *testing.T) {
+	stats := parseContainerStats("minikube\t12.50%\t1.5GiB / 3.8GiB\nminikube-m02\t0.00%\t512MiB / 3.8GiB\ngarbage\n")
+	if len(stats) != 2 {
+		t.Fatalf("parseContainerStats() = %+v, expected 2 machines", stats)
+	}
+	st := stats["minikube"]
+	if st.CPURatio == nil || *st.CPURatio != 0.125 {
+		t.Errorf("CPURatio = %v, expected 0.125", st.CPURatio)
+	}
+	if st.MemoryUsage == nil || *st.MemoryUsage != 1.5*1024*1024*1024 {
+		t.Errorf("MemoryUsage = %v, expected 1.5GiB", st.MemoryUsage)
+	}
+	if st := stats["minikube-m02"]; st.MemoryUsage == nil || *st.MemoryUsage != 512*1024*1024 || st.MemoryLimit == nil {
+		t.Errorf("minikube-m02 stats = %+v", st)
+	}
+}
+
+func TestParseDomainStats(t *testing.T) {
+	st := parseDomainStats("Domain: 'minikube'\n  cpu.time=2500000000\n  cpu.user=1000000000\n  balloon.current=2097152\n  balloon.rss=1048576\n")
+	if st.CPUSeconds == nil || *st.CPUSeconds != 2.5 {
+		t.Errorf("CPUSeconds = %v, expected 2.5", st.CPUSeconds)
+	}
+	if st.MemoryUsage == nil || *st.MemoryUsage != 1024*1024*1024 || st.MemoryLimit == nil || *st.MemoryLimit != 2*1024*1024*1024 {
+		t.Errorf("memory = %v / %v, expected 1GiB / 2GiB", st.MemoryUsage, st.MemoryLimit)
+	}
+	if st.CPURatio != nil {
+		t.Errorf("CPURatio = %v, expected none for a VM", *st.CPURatio)
+	}
+}
+
+func TestParseDiskUsage(t *testing.T) {
+	size, used, ok := parseDiskUsage("     1B-blocks        Used\n 20956397568  5242880000\n")
+	if !ok || size != 20956397568 || used != 5242880000 {
+		t.Errorf("parseDiskUsage() = %v, %v, %v", size, used, ok)
+	}
+	if _, _, ok := parseDiskUsage("df: /var: No such file or directory"); ok {
+		t.Errorf("parseDiskUsage() of an error expected to fail")
+	}
+}
+
+func TestCountPods(t *testing.T) {
+	pod := func(node string, phase core.PodPhase) core.Pod {
+		return core.Pod{Spec: core.PodSpec{NodeName: node}, Status: core.PodStatus{Phase: phase}}
+	}
+	counts := countPods([]core.Pod{
+		pod("minikube", core.PodRunning),
+		pod("minikube", core.PodRunning),
+		pod("minikube-m02", core.PodPending),
+		pod("", core.PodPending),
+	})
+	if len(counts) != 2 || counts[podKey{"minikube", "Running"}] != 2 || counts[podKey{"minikube-m02", "Pending"}] != 1 {
+		t.Errorf("countPods() = %v", counts)
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/docker/go-units"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/driver"
+)
+
+// MachineStats is the resource usage of the container or VM of a node, as seen by the driver
+type MachineStats struct {
+	// CPURatio is the CPU usage of a container, 1 is one full core
+	CPURatio *float64
+	// CPUSeconds is the CPU time used by a VM
+	CPUSeconds *float64
+	// MemoryUsage and MemoryLimit are the used and available memory in bytes
+	MemoryUsage *float64
+	MemoryLimit *float64
+}
+
+// Machines returns the stats of the machines of a cluster by machine name, for the drivers that provide them
+func Machines(cc *config.ClusterConfig, names []string) (map[string]MachineStats, error) {
+	switch {
+	case driver.IsKIC(cc.Driver):
+		args := append([]string{"stats", "--no-stream", "--format", "{{.Name}}\t{{.CPUPerc}}\t{{.MemUsage}}"}, names...)
+		o, err := oci.PrefixCmd(exec.Command(cc.Driver, args...)).Output()
+		if err != nil {
+			return nil, fmt.Errorf("%s stats: %v", cc.Driver, err)
+		}
+		return parseContainerStats(string(o)), nil
+	case driver.IsKVM(cc.Driver):
+		stats := map[string]MachineStats{}
+		for _, name := range names {
+			o, err := exec.Command("virsh", "-c", cc.KVMQemuURI, "domstats", "--cpu-total", "--balloon", name).Output()
+			if err != nil {
+				return nil, fmt.Errorf("virsh domstats %s: %v", name, err)
+			}
+			stats[name] = parseDomainStats(string(o))
+		}
+		return stats, nil
+	}
+	return nil, nil
+}
+
+// DiskUsage returns the size and used bytes of /var on a node, where minikube keeps the images and volumes
+func DiskUsage(r command.Runner) (size, used float64, err error) {
+	rr, err := r.RunCmd(exec.Command("df", "-B1", "--output=size,used", "/var"))
+	if err != nil {
+		return 0, 0, err
+	}
+	size, used, ok := parseDiskUsage(rr.Stdout.String())
+	if !ok {
+		return 0, 0, fmt.Errorf("unable to parse the disk usage: %q", rr.Stdout.String())
+	}
+	return size, used, nil
+}
+
+// parseDiskUsage parses the output of df --output=size,used
+func parseDiskUsage(output string) (size, used float64, ok bool) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) < 2 {
+		return 0, 0, false
+	}
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(fields) != 2 {
+		return 0, 0, false
+	}
+	size, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	used, err = strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return size, used, true
+}
+
+// parseContainerStats parses the "name cpu% used / limit" lines of docker and podman stats
+func parseContainerStats(output string) map[string]MachineStats {
+	stats := map[string]MachineStats{}
+	s := bufio.NewScanner(strings.NewReader(output))
+	for s.Scan() {
+		fields := strings.Split(s.Text(), "\t")
+		if len(fields) != 3 {
+			continue
+		}
+		var st MachineStats
+		if v, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(fields[1]), "%"), 64); err == nil {
+			v /= 100
+			st.CPURatio = &v
+		} else {
+			klog.Warningf("unable to parse CPU usage %q: %v", fields[1], err)
+		}
+		if used, limit, ok := strings.Cut(fields[2], "/"); ok {
+			st.MemoryUsage = parseBytes(used)
+			st.MemoryLimit = parseBytes(limit)
+		}
+		stats[strings.TrimSpace(fields[0])] = st
+	}
+	return stats
+}
+
+// parseDomainStats parses the cpu.time and balloon lines of virsh domstats
+func parseDomainStats(output string) MachineStats {
+	values := map[string]float64{}
+	s := bufio.NewScanner(strings.NewReader(output))
+	for s.Scan() {
+		k, v, ok := strings.Cut(strings.TrimSpace(s.Text()), "=")
+		if !ok {
+			continue
+		}
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			values[k] = f
+		}
+	}
+	var st MachineStats
+	if v, ok := values["cpu.time"]; ok {
+		v /= 1e9
+		st.CPUSeconds = &v
+	}
+	// balloon values are in KiB
+	if v, ok := values["balloon.rss"]; ok {
+		v *= 1024
+		st.MemoryUsage = &v
+	}
+	if v, ok := values["balloon.current"]; ok {
+		v *= 1024
+		st.MemoryLimit = &v
+	}
+	return st
+}
+
+// parseBytes parses the human readable sizes of docker and podman, like 1.2GiB or 512MB
+func parseBytes(s string) *float64 {
+	s = strings.TrimSpace(s)
+	v, err := units.RAMInBytes(s)
+	if err != nil {
+		klog.Warningf("unable to parse size %q: %v", s, err)
+		return nil
+	}
+	f := float64(v)
+	return &f
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os/exec"
+	"path"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+)
+
+// kubeletPort is the port of the kubelet API
+const kubeletPort = 10250
+
+// Summary is the subset of the kubelet /stats/summary response used by minikube
+type Summary struct {
+	Node NodeSummary  `json:"node"`
+	Pods []PodSummary `json:"pods"`
+}
+
+// NodeSummary is the resource usage of a node, as seen by its kubelet
+type NodeSummary struct {
+	NodeName string     `json:"nodeName"`
+	CPU      *CPUStats  `json:"cpu,omitempty"`
+	Memory   *MemStats  `json:"memory,omitempty"`
+	Fs       *FsStats   `json:"fs,omitempty"`
+	Runtime  *RuntimeFs `json:"runtime,omitempty"`
+}
+
+// PodSummary is the resource usage of a pod, as seen by the kubelet of its node
+type PodSummary struct {
+	PodRef struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"podRef"`
+	CPU    *CPUStats `json:"cpu,omitempty"`
+	Memory *MemStats `json:"memory,omitempty"`
+}
+
+// CPUStats is the CPU usage of a node or a pod
+type CPUStats struct {
+	UsageNanoCores *uint64 `json:"usageNanoCores,omitempty"`
+}
+
+// MemStats is the memory usage of a node or a pod
+type MemStats struct {
+	AvailableBytes  *uint64 `json:"availableBytes,omitempty"`
+	WorkingSetBytes *uint64 `json:"workingSetBytes,omitempty"`
+}
+
+// FsStats is the usage of a filesystem
+type FsStats struct {
+	CapacityBytes *uint64 `json:"capacityBytes,omitempty"`
+	UsedBytes     *uint64 `json:"usedBytes,omitempty"`
+}
+
+// RuntimeFs is the usage of the filesystem of the container runtime images
+type RuntimeFs struct {
+	ImageFs *FsStats `json:"imageFs,omitempty"`
+}
+
+// KubeletSummary reads the /stats/summary endpoint of the kubelet listening on ip, through the runner of a control-plane node.
+// It authenticates with the client certificate the API server uses to talk to the kubelets, so it does not need metrics-server.
+func KubeletSummary(cp command.Runner, ip string) (*Summary, error) {
+	certs := vmpath.GuestKubernetesCertsDir
+	url := "https://" + net.JoinHostPort(ip, strconv.Itoa(kubeletPort)) + "/stats/summary"
+	rr, err := cp.RunCmd(exec.Command("sudo", "curl", "-sSfk", "--max-time", "10",
+		"--cert", path.Join(certs, "apiserver-kubelet-client.crt"),
+		"--key", path.Join(certs, "apiserver-kubelet-client.key"),
+		url))
+	if err != nil {
+		return nil, errors.Wrapf(err, "kubelet summary of %s", ip)
+	}
+	return parseSummary(rr.Stdout.Bytes())
+}
+
+func parseSummary(b []byte) (*Summary, error) {
+	var s Summary
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, errors.Wrap(err, "parsing kubelet summary")
+	}
+	return &s, nil
+}
+
+// PodUsage is the resource usage of a pod
+type PodUsage struct {
+	Namespace string
+	Name      string
+	Node      string
+	// CPUCores is the CPU usage, 1 is one full core
+	CPUCores float64
+	// MemoryBytes is the working set memory
+	MemoryBytes float64
+}
+
+// TopPods returns the n pods of the summaries using the most CPU, then memory
+func TopPods(summaries []*Summary, n int) []PodUsage {
+	var pods []PodUsage
+	for _, s := range summaries {
+		for _, p := range s.Pods {
+			pods = append(pods, PodUsage{
+				Namespace:   p.PodRef.Namespace,
+				Name:        p.PodRef.Name,
+				Node:        s.Node.NodeName,
+				CPUCores:    p.CPU.Cores(),
+				MemoryBytes: p.Memory.WorkingSet(),
+			})
+		}
+	}
+	sort.SliceStable(pods, func(i, j int) bool {
+		if pods[i].CPUCores != pods[j].CPUCores {
+			return pods[i].CPUCores > pods[j].CPUCores
+		}
+		if pods[i].MemoryBytes != pods[j].MemoryBytes {
+			return pods[i].MemoryBytes > pods[j].MemoryBytes
+		}
+		return fmt.Sprintf("%s/%s", pods[i].Namespace, pods[i].Name) < fmt.Sprintf("%s/%s", pods[j].Namespace, pods[j].Name)
+	})
+	if n >= 0 && len(pods) > n {
+		pods = pods[:n]
+	}
+	return pods
+}
+
+// Cores returns the CPU usage in cores, 0 if it is unknown
+func (c *CPUStats) Cores() float64 {
+	if c == nil || c.UsageNanoCores == nil {
+		return 0
+	}
+	return float64(*c.UsageNanoCores) / 1e9
+}
+
+// WorkingSet returns the working set memory in bytes, 0 if it is unknown
+func (m *MemStats) WorkingSet() float64 {
+	if m == nil || m.WorkingSetBytes == nil {
+		return 0
+	}
+	return float64(*m.WorkingSetBytes)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const summaryJSON = `{
+  "node": {
+    "nodeName": "minikube",
+    "cpu": {"time": "2024-10-01T12:00:00Z", "usageNanoCores": 250000000, "usageCoreNanoSeconds": 123456789000},
+    "memory": {"availableBytes": 6000000000, "workingSetBytes": 1000000000},
+    "fs": {"capacityBytes": 20000000000, "usedBytes": 5000000000}
+  },
+  "pods": [
+    {"podRef": {"name": "etcd-minikube", "namespace": "kube-system"}, "cpu": {"usageNanoCores": 30000000}, "memory": {"workingSetBytes": 50000000}},
+    {"podRef": {"name": "kube-apiserver-minikube", "namespace": "kube-system"}, "cpu": {"usageNanoCores": 80000000}, "memory": {"workingSetBytes": 300000000}},
+    {"podRef": {"name": "pending", "namespace": "default"}},
+    {"podRef": {"name": "coredns-1", "namespace": "kube-system"}, "cpu": {"usageNanoCores": 30000000}, "memory": {"workingSetBytes": 20000000}}
+  ]
+}`
+
+func TestParseSummary(t *testing.T) {
+	s, err := parseSummary([]byte(summaryJSON))
+	if err != nil {
+		t.Fatalf("parseSummary: %v", err)
+	}
+	if s.Node.NodeName != "minikube" || s.Node.CPU.Cores() != 0.25 || s.Node.Memory.WorkingSet() != 1e9 || *s.Node.Fs.CapacityBytes != 2e10 {
+		t.Errorf("unexpected node summary: %+v", s.Node)
+	}
+	if _, err := parseSummary([]byte("curl: (7) Failed to connect")); err == nil {
+		t.Errorf("parseSummary() of an error expected to fail")
+	}
+}
+
+func TestTopPods(t *testing.T) {
+	s, err := parseSummary([]byte(summaryJSON))
+	if err != nil {
+		t.Fatalf("parseSummary: %v", err)
+	}
+
+	got := TopPods([]*Summary{s}, 3)
+	want := []PodUsage{
+		{Namespace: "kube-system", Name: "kube-apiserver-minikube", Node: "minikube", CPUCores: 0.08, MemoryBytes: 3e8},
+		{Namespace: "kube-system", Name: "etcd-minikube", Node: "minikube", CPUCores: 0.03, MemoryBytes: 5e7},
+		{Namespace: "kube-system", Name: "coredns-1", Node: "minikube", CPUCores: 0.03, MemoryBytes: 2e7},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("TopPods() mismatch (-want +got):\n%s", diff)
+	}
+	if got := TopPods([]*Summary{s}, -1); len(got) != 4 {
+		t.Errorf("TopPods(-1) returned %d pods, want all 4", len(got))
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/docker/machine/libmachine/state"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/cluster"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/machine"
+)
+
+// NodeUsage is the state and resource usage of a node
+type NodeUsage struct {
+	Status *cluster.Status
+	// Machine is the usage of the node container or VM, as seen by the driver
+	Machine MachineStats
+	// DiskSize and DiskUsed are the size and used bytes of /var
+	DiskSize *float64
+	DiskUsed *float64
+	// Kubelet is the usage of the node as seen by its kubelet, nil if the kubelet is not running
+	Kubelet *NodeSummary
+}
+
+// Usage is a snapshot of the state and resource usage of a cluster
+type Usage struct {
+	Time   time.Time
+	Paused bool
+	Nodes  []NodeUsage
+	// Pods are the pods using the most resources
+	Pods []PodUsage
+}
+
+// CollectUsage returns the state and resource usage of the nodes of a cluster, and of its topPods pods using the most resources.
+// Unavailable values are left empty rather than failing the whole snapshot.
+func CollectUsage(api libmachine.API, cc *config.ClusterConfig, topPods int) (*Usage, error) {
+	statuses, err := cluster.GetStatus(api, cc)
+	if err != nil {
+		return nil, errors.Wrap(err, "status")
+	}
+	u := &Usage{Time: time.Now(), Paused: len(statuses) > 0 && statuses[0].APIServer == state.Paused.String()}
+
+	var running []string
+	for _, st := range statuses {
+		if st.Host == state.Running.String() {
+			running = append(running, st.Name)
+		}
+	}
+	stats, err := Machines(cc, running)
+	if err != nil {
+		klog.Warningf("unable to get the machine stats of %s: %v", cc.Name, err)
+	}
+
+	cp := controlPlaneRunner(api, cc)
+	var summaries []*Summary
+	for i, st := range statuses {
+		nu := NodeUsage{Status: st, Machine: stats[st.Name]}
+		if st.Host == state.Running.String() {
+			nu.DiskSize, nu.DiskUsed = nodeDisk(api, st.Name)
+		}
+		if cp != nil && st.Kubelet == state.Running.String() && i < len(cc.Nodes) {
+			s, err := KubeletSummary(cp, cc.Nodes[i].IP)
+			if err != nil {
+				klog.Warningf("unable to get the kubelet summary of %s: %v", st.Name, err)
+			} else {
+				nu.Kubelet = &s.Node
+				summaries = append(summaries, s)
+			}
+		}
+		u.Nodes = append(u.Nodes, nu)
+	}
+	u.Pods = TopPods(summaries, topPods)
+	return u, nil
+}
+
+// controlPlaneRunner returns the runner of the first control-plane node, or nil if it is not available
+func controlPlaneRunner(api libmachine.API, cc *config.ClusterConfig) command.Runner {
+	cp, err := config.ControlPlane(*cc)
+	if err != nil {
+		klog.Warningf("unable to find the control-plane node of %s: %v", cc.Name, err)
+		return nil
+	}
+	h, err := machine.LoadHost(api, config.MachineName(*cc, cp))
+	if err != nil {
+		klog.Warningf("unable to load the control-plane host of %s: %v", cc.Name, err)
+		return nil
+	}
+	r, err := machine.CommandRunner(h)
+	if err != nil {
+		klog.Warningf("unable to get a runner for the control-plane of %s: %v", cc.Name, err)
+		return nil
+	}
+	return r
+}
+
+// nodeDisk returns the size and used bytes of /var on a node, or nil if they are not available
+func nodeDisk(api libmachine.API, name string) (*float64, *float64) {
+	h, err := machine.LoadHost(api, name)
+	if err != nil {
+		klog.Warningf("unable to load host %s: %v", name, err)
+		return nil, nil
+	}
+	r, err := machine.CommandRunner(h)
+	if err != nil {
+		klog.Warningf("unable to get a runner for %s: %v", name, err)
+		return nil, nil
+	}
+	size, used, err := DiskUsage(r)
+	if err != nil {
+		klog.Warningf("unable to get the disk usage of %s: %v", name, err)
+		return nil, nil
+	}
+	return &size, &used
+}
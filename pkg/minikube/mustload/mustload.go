@@ -123,6 +123,15 @@ func running(name string, first bool) []ClusterController {
 			continue
 		}
 
+		if machine.IsSuspended(status) {
+			if last {
+				out.Styled(style.Shrug, `The control-plane node {{.name}} host is suspended`, out.V{"name": machineName})
+				exitTip("resume", name, reason.ExGuestNotRunning)
+			}
+			out.WarningT(`The control-plane node {{.name}} host is suspended (will try others)`, out.V{"name": machineName})
+			continue
+		}
+
 		if status != state.Running.String() {
 			if last {
 				out.Styled(style.Shrug, `The control-plane node {{.name}} host is not running: state={{.state}}`, out.V{"name": machineName, "state": status})
@@ -0,0 +1,292 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package netchaos injects network faults between the nodes of a cluster,
+// with tc netem for latency, jitter and loss, tc htb for bandwidth limits and iptables for partitions.
+package netchaos
+
+import (
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+const (
+	// chain is the iptables chain holding the partition rules, jumped to from OUTPUT and FORWARD
+	chain = "MINIKUBE-CHAOS"
+	// unlimitedRate is the rate of the htb classes without a bandwidth limit
+	unlimitedRate = "10gbit"
+	// firstClass is the minor number of the htb class of the first rule, class 1 is the default class
+	firstClass = 0x10
+)
+
+// step is a command run on a node, optional steps are allowed to fail, like removing rules that do not exist
+type step struct {
+	args     []string
+	optional bool
+}
+
+// Apply replaces the network faults of a node with the rules sent from it.
+// peers maps the machine name of every node to the destinations of its traffic: its IP addresses and pod CIDRs.
+func Apply(r command.Runner, node string, rules []config.NetworkChaosRule, peers map[string][]string) error {
+	var own []config.NetworkChaosRule
+	for _, rule := range rules {
+		if rule.From == node {
+			own = append(own, rule)
+		}
+	}
+	if len(own) == 0 {
+		return Clear(r)
+	}
+
+	iface, err := routeInterface(r, own, peers)
+	if err != nil {
+		return err
+	}
+	// the route to the peers may have moved to another interface since the faults were last applied
+	shaped, err := shapedInterfaces(r)
+	if err != nil {
+		return err
+	}
+	steps, err := applySteps(iface, shaped, own, peers)
+	if err != nil {
+		return err
+	}
+	return run(r, steps)
+}
+
+// Clear removes all network faults from a node
+func Clear(r command.Runner) error {
+	ifaces, err := shapedInterfaces(r)
+	if err != nil {
+		return err
+	}
+	return run(r, clearSteps(ifaces...))
+}
+
+// clearSteps removes the tc qdiscs of the interfaces and the partition chains, leaving the node as configured by minikube
+func clearSteps(ifaces ...string) []step {
+	var steps []step
+	for _, iface := range ifaces {
+		steps = append(steps, step{args: []string{"tc", "qdisc", "del", "dev", iface, "root"}, optional: true})
+	}
+	for _, ipt := range []string{"iptables", "ip6tables"} {
+		for _, parent := range []string{"OUTPUT", "FORWARD"} {
+			steps = append(steps, step{args: []string{ipt, "-D", parent, "-j", chain}, optional: true})
+		}
+		steps = append(steps,
+			step{args: []string{ipt, "-F", chain}, optional: true},
+			step{args: []string{ipt, "-X", chain}, optional: true})
+	}
+	return steps
+}
+
+// applySteps returns the steps that replace the faults on iface with the given rules,
+// removing the faults of the shaped interfaces first
+func applySteps(iface string, shaped []string, rules []config.NetworkChaosRule, peers map[string][]string) ([]step, error) {
+	ifaces := []string{iface}
+	for _, s := range shaped {
+		if s != iface {
+			ifaces = append(ifaces, s)
+		}
+	}
+	steps := clearSteps(ifaces...)
+
+	var limited, partitioned []config.NetworkChaosRule
+	for _, r := range rules {
+		if r.Partition {
+			partitioned = append(partitioned, r)
+		} else {
+			limited = append(limited, r)
+		}
+	}
+
+	if len(limited) > 0 {
+		steps = append(steps,
+			step{args: []string{"tc", "qdisc", "add", "dev", iface, "root", "handle", "1:", "htb", "default", "1"}},
+			step{args: []string{"tc", "class", "add", "dev", iface, "parent", "1:", "classid", "1:1", "htb", "rate", unlimitedRate}})
+	}
+	for i, r := range limited {
+		dsts, ok := peers[r.To]
+		if !ok || len(dsts) == 0 {
+			return nil, fmt.Errorf("no addresses found for node %s", r.To)
+		}
+		class := fmt.Sprintf("%x", firstClass+i)
+		rate := r.Rate
+		if rate == "" {
+			rate = unlimitedRate
+		}
+		steps = append(steps, step{args: []string{"tc", "class", "add", "dev", iface, "parent", "1:", "classid", "1:" + class, "htb", "rate", rate, "ceil", rate}})
+		if netem := netemArgs(r); len(netem) > 0 {
+			args := []string{"tc", "qdisc", "add", "dev", iface, "parent", "1:" + class, "handle", class + ":", "netem"}
+			steps = append(steps, step{args: append(args, netem...)})
+		}
+		for _, dst := range dsts {
+			// a filter priority holds a single protocol, so each IP family has its own
+			protocol, match, prio := "ip", "ip", "1"
+			if isIPv6(dst) {
+				protocol, match, prio = "ipv6", "ip6", "2"
+			}
+			steps = append(steps, step{args: []string{"tc", "filter", "add", "dev", iface, "parent", "1:", "protocol", protocol, "prio", prio, "u32", "match", match, "dst", dst, "flowid", "1:" + class}})
+		}
+	}
+
+	// the chains are only created for the IP families of the partitioned destinations
+	chains := map[string]bool{}
+	var drops []step
+	for _, r := range partitioned {
+		dsts, ok := peers[r.To]
+		if !ok || len(dsts) == 0 {
+			return nil, fmt.Errorf("no addresses found for node %s", r.To)
+		}
+		for _, dst := range dsts {
+			ipt := "iptables"
+			if isIPv6(dst) {
+				ipt = "ip6tables"
+			}
+			chains[ipt] = true
+			drops = append(drops, step{args: []string{ipt, "-A", chain, "-d", dst, "-j", "DROP"}})
+		}
+	}
+	for _, ipt := range []string{"iptables", "ip6tables"} {
+		if !chains[ipt] {
+			continue
+		}
+		steps = append(steps, step{args: []string{ipt, "-N", chain}})
+		for _, parent := range []string{"OUTPUT", "FORWARD"} {
+			steps = append(steps, step{args: []string{ipt, "-I", parent, "-j", chain}})
+		}
+	}
+	return append(steps, drops...), nil
+}
+
+// netemArgs returns the netem options of the delay and loss of a rule
+func netemArgs(r config.NetworkChaosRule) []string {
+	var args []string
+	if r.Latency != "" {
+		args = append(args, "delay", r.Latency)
+		if r.Jitter != "" {
+			args = append(args, r.Jitter, "distribution", "normal")
+		}
+	}
+	if r.Loss != "" {
+		args = append(args, "loss", percent(r.Loss))
+	}
+	return args
+}
+
+// routeInterface returns the interface the node uses to reach the destinations of its rules
+func routeInterface(r command.Runner, rules []config.NetworkChaosRule, peers map[string][]string) (string, error) {
+	for _, rule := range rules {
+		for _, dst := range peers[rule.To] {
+			ip, _, err := net.ParseCIDR(dst)
+			if err != nil {
+				continue
+			}
+			rr, err := r.RunCmd(exec.Command("ip", "-o", "route", "get", ip.String()))
+			if err != nil {
+				return "", errors.Wrapf(err, "route to %s", ip)
+			}
+			if iface := parseRouteDevice(rr.Stdout.String()); iface != "" {
+				return iface, nil
+			}
+		}
+	}
+	return defaultInterface(r)
+}
+
+// defaultInterface returns the interface of the default route of the node
+func defaultInterface(r command.Runner) (string, error) {
+	rr, err := r.RunCmd(exec.Command("ip", "-o", "route", "show", "default"))
+	if err != nil {
+		return "", errors.Wrap(err, "default route")
+	}
+	iface := parseRouteDevice(rr.Stdout.String())
+	if iface == "" {
+		return "", fmt.Errorf("no default route interface found in %q", rr.Stdout.String())
+	}
+	return iface, nil
+}
+
+// shapedInterfaces returns the interfaces of the node with the root htb qdisc added by Apply
+func shapedInterfaces(r command.Runner) ([]string, error) {
+	rr, err := r.RunCmd(exec.Command("tc", "qdisc", "show"))
+	if err != nil {
+		return nil, errors.Wrap(err, "tc qdisc show")
+	}
+	return parseShapedInterfaces(rr.Stdout.String()), nil
+}
+
+// parseShapedInterfaces returns the devices with a root htb qdisc of handle 1: in the output of tc qdisc show,
+// like "qdisc htb 1: dev eth0 root refcnt 2 r2q 10 default 0x1 direct_packets_stat 0"
+func parseShapedInterfaces(qdiscs string) []string {
+	var ifaces []string
+	for _, line := range strings.Split(qdiscs, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 6 || fields[0] != "qdisc" || fields[1] != "htb" || fields[2] != "1:" || fields[3] != "dev" || fields[5] != "root" {
+			continue
+		}
+		ifaces = append(ifaces, fields[4])
+	}
+	return ifaces
+}
+
+// parseRouteDevice returns the device of a route printed by ip, like "192.168.49.3 dev eth0 src 192.168.49.2 uid 0"
+func parseRouteDevice(route string) string {
+	fields := strings.Fields(route)
+	for i := 0; i < len(fields)-1; i++ {
+		if fields[i] == "dev" {
+			return fields[i+1]
+		}
+	}
+	return ""
+}
+
+func run(r command.Runner, steps []step) error {
+	for _, s := range steps {
+		rr, err := r.RunCmd(exec.Command("sudo", s.args...))
+		if err != nil {
+			if s.optional {
+				klog.Infof("ignoring failure of %s: %v", strings.Join(s.args, " "), err)
+				continue
+			}
+			return errors.Wrapf(err, "%s: %s", strings.Join(s.args, " "), rr.Output())
+		}
+	}
+	return nil
+}
+
+// Destinations returns the destinations of the traffic to a node: its addresses as host routes and its pod CIDRs
+func Destinations(n config.Node, podCIDRs []string) []string {
+	var dsts []string
+	if n.IP != "" {
+		dsts = append(dsts, n.IP+"/32")
+	}
+	if n.IPv6 != "" {
+		dsts = append(dsts, n.IPv6+"/128")
+	}
+	return append(dsts, podCIDRs...)
+}
+
+func isIPv6(cidr string) bool {
+	return strings.Contains(cidr, ":")
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netchaos
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestApplySteps(t *testing.T) {
+	peers := map[string][]string{
+		"minikube-m02": Destinations(config.Node{IP: "192.168.49.3", IPv6: "fd00::3"}, []string{"10.244.1.0/24"}),
+		"minikube-m03": Destinations(config.Node{IP: "192.168.49.4"}, nil),
+	}
+	rules := []config.NetworkChaosRule{
+		{From: "minikube", To: "minikube-m02", Latency: "100ms", Jitter: "10ms", Loss: "1", Rate: "1mbit"},
+		{From: "minikube", To: "minikube-m03", Partition: true},
+	}
+	steps, err := applySteps("eth0", []string{"eth0", "eth1"}, rules, peers)
+	if err != nil {
+		t.Fatalf("applySteps() error = %v", err)
+	}
+	var got, cleared []string
+	for _, s := range steps {
+		if !s.optional {
+			got = append(got, strings.Join(s.args, " "))
+		} else if s.args[0] == "tc" {
+			cleared = append(cleared, strings.Join(s.args, " "))
+		}
+	}
+	expectedCleared := []string{
+		"tc qdisc del dev eth0 root",
+		"tc qdisc del dev eth1 root",
+	}
+	if strings.Join(cleared, "\n") != strings.Join(expectedCleared, "\n") {
+		t.Errorf("applySteps() cleared\n%s\nexpected\n%s", strings.Join(cleared, "\n"), strings.Join(expectedCleared, "\n"))
+	}
+	expected := []string{
+		"tc qdisc add dev eth0 root handle 1: htb default 1",
+		"tc class add dev eth0 parent 1: classid 1:1 htb rate 10gbit",
+		"tc class add dev eth0 parent 1: classid 1:10 htb rate 1mbit ceil 1mbit",
+		"tc qdisc add dev eth0 parent 1:10 handle 10: netem delay 100ms 10ms distribution normal loss 1%",
+		"tc filter add dev eth0 parent 1: protocol ip prio 1 u32 match ip dst 192.168.49.3/32 flowid 1:10",
+		"tc filter add dev eth0 parent 1: protocol ipv6 prio 2 u32 match ip6 dst fd00::3/128 flowid 1:10",
+		"tc filter add dev eth0 parent 1: protocol ip prio 1 u32 match ip dst 10.244.1.0/24 flowid 1:10",
+		"iptables -N MINIKUBE-CHAOS",
+		"iptables -I OUTPUT -j MINIKUBE-CHAOS",
+		"iptables -I FORWARD -j MINIKUBE-CHAOS",
+		"iptables -A MINIKUBE-CHAOS -d 192.168.49.4/32 -j DROP",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("applySteps() =\n%s\nexpected\n%s", strings.Join(got, "\n"), strings.Join(expected, "\n"))
+	}
+
+	if _, err := applySteps("eth0", nil, []config.NetworkChaosRule{{From: "minikube", To: "unknown", Latency: "1ms"}}, peers); err == nil {
+		t.Errorf("applySteps() for an unknown node expected an error")
+	}
+}
+
+func TestParseShapedInterfaces(t *testing.T) {
+	qdiscs := `qdisc noqueue 0: dev lo root refcnt 2
+qdisc htb 1: dev eth1 root refcnt 2 r2q 10 default 0x1 direct_packets_stat 0 direct_qlen 1000
+qdisc netem 10: dev eth1 parent 1:10 limit 1000 delay 100ms
+qdisc noqueue 0: dev eth0 root refcnt 2
+`
+	if got := parseShapedInterfaces(qdiscs); !reflect.DeepEqual(got, []string{"eth1"}) {
+		t.Errorf("parseShapedInterfaces() = %v, expected [eth1]", got)
+	}
+}
+
+func TestParseRouteDevice(t *testing.T) {
+	if got := parseRouteDevice("192.168.49.3 dev eth0 src 192.168.49.2 uid 0 \n    cache "); got != "eth0" {
+		t.Errorf("parseRouteDevice() = %q, expected eth0", got)
+	}
+	if got := parseRouteDevice("unreachable"); got != "" {
+		t.Errorf("parseRouteDevice() = %q, expected no device", got)
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netchaos
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+// rateRegexp matches the bandwidth units understood by tc
+var rateRegexp = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?([kmgt]?(bit|bps)|[KMGT]i?bit|[KMGT]i?bps)$`)
+
+// Validate checks that the values of a rule can be used by tc
+func Validate(r config.NetworkChaosRule) error {
+	if r.From == "" || r.To == "" {
+		return fmt.Errorf("a rule needs a source and a destination node")
+	}
+	if r.From == r.To {
+		return fmt.Errorf("the source and destination node of a rule must be different, got %s", r.From)
+	}
+	for name, d := range map[string]string{"latency": r.Latency, "jitter": r.Jitter} {
+		if d == "" {
+			continue
+		}
+		if v, err := time.ParseDuration(d); err != nil || v < 0 {
+			return fmt.Errorf("invalid %s %q, must be a duration like 100ms", name, d)
+		}
+	}
+	if r.Jitter != "" && r.Latency == "" {
+		return fmt.Errorf("jitter requires a latency")
+	}
+	if r.Loss != "" {
+		v, err := strconv.ParseFloat(strings.TrimSuffix(r.Loss, "%"), 64)
+		if err != nil || v < 0 || v > 100 {
+			return fmt.Errorf("invalid loss %q, must be a percentage like 1%%", r.Loss)
+		}
+	}
+	if r.Rate != "" && !rateRegexp.MatchString(r.Rate) {
+		return fmt.Errorf("invalid rate %q, must be a bandwidth like 1mbit", r.Rate)
+	}
+	if !r.Partition && r.Latency == "" && r.Loss == "" && r.Rate == "" {
+		return fmt.Errorf("a rule needs at least one of latency, loss, rate or partition")
+	}
+	return nil
+}
+
+// Describe returns a short human readable description of the faults of a rule
+func Describe(r config.NetworkChaosRule) string {
+	if r.Partition {
+		return "partitioned"
+	}
+	var faults []string
+	if r.Latency != "" {
+		l := "latency " + r.Latency
+		if r.Jitter != "" {
+			l += "±" + r.Jitter
+		}
+		faults = append(faults, l)
+	}
+	if r.Loss != "" {
+		faults = append(faults, "loss "+percent(r.Loss))
+	}
+	if r.Rate != "" {
+		faults = append(faults, "rate "+r.Rate)
+	}
+	return strings.Join(faults, ", ")
+}
+
+// Merge adds a rule to rules, the faults of a rule for the same pair of nodes are combined with the new ones
+func Merge(rules []config.NetworkChaosRule, r config.NetworkChaosRule) []config.NetworkChaosRule {
+	for i, e := range rules {
+		if e.From != r.From || e.To != r.To {
+			continue
+		}
+		if r.Latency != "" {
+			e.Latency, e.Jitter = r.Latency, r.Jitter
+		}
+		if r.Loss != "" {
+			e.Loss = r.Loss
+		}
+		if r.Rate != "" {
+			e.Rate = r.Rate
+		}
+		e.Partition = e.Partition || r.Partition
+		e.Scenario = r.Scenario
+		rules[i] = e
+		return rules
+	}
+	return append(rules, r)
+}
+
+// Nodes returns the machine names of the nodes that have rules, the senders of the traffic
+func Nodes(rules []config.NetworkChaosRule) map[string]bool {
+	nodes := map[string]bool{}
+	for _, r := range rules {
+		nodes[r.From] = true
+	}
+	return nodes
+}
+
+// Scenario is a named set of network faults between the nodes of a cluster
+type Scenario struct {
+	Name        string
+	Description string
+	// Rules returns the rules of the scenario for the machine names of the cluster nodes, the primary control-plane first
+	Rules func(nodes []string) []config.NetworkChaosRule
+}
+
+// Scenarios are the named scenarios of `minikube network chaos scenario`
+var Scenarios = []Scenario{
+	{
+		Name:        "wan",
+		Description: "100ms±20ms latency between all nodes, like nodes in different regions",
+		Rules: func(nodes []string) []config.NetworkChaosRule {
+			return allPairs(nodes, config.NetworkChaosRule{Latency: "100ms", Jitter: "20ms"})
+		},
+	},
+	{
+		Name:        "flaky",
+		Description: "5% packet loss and 10ms latency between all nodes",
+		Rules: func(nodes []string) []config.NetworkChaosRule {
+			return allPairs(nodes, config.NetworkChaosRule{Latency: "10ms", Loss: "5%"})
+		},
+	},
+	{
+		Name:        "slow-link",
+		Description: "1mbit bandwidth limit between all nodes",
+		Rules: func(nodes []string) []config.NetworkChaosRule {
+			return allPairs(nodes, config.NetworkChaosRule{Rate: "1mbit"})
+		},
+	},
+	{
+		Name:        "isolate-primary",
+		Description: "partition the primary control-plane node from all other nodes",
+		Rules: func(nodes []string) []config.NetworkChaosRule {
+			if len(nodes) == 0 {
+				return nil
+			}
+			return between(nodes[:1], nodes[1:], config.NetworkChaosRule{Partition: true})
+		},
+	},
+	{
+		Name:        "split-brain",
+		Description: "partition the first half of the nodes from the second half",
+		Rules: func(nodes []string) []config.NetworkChaosRule {
+			half := (len(nodes) + 1) / 2
+			return between(nodes[:half], nodes[half:], config.NetworkChaosRule{Partition: true})
+		},
+	},
+}
+
+// FindScenario returns the scenario with the given name, or nil
+func FindScenario(name string) *Scenario {
+	for i := range Scenarios {
+		if Scenarios[i].Name == name {
+			return &Scenarios[i]
+		}
+	}
+	return nil
+}
+
+// allPairs returns the faults of r in both directions between all nodes
+func allPairs(nodes []string, r config.NetworkChaosRule) []config.NetworkChaosRule {
+	var rules []config.NetworkChaosRule
+	for _, from := range nodes {
+		for _, to := range nodes {
+			if from == to {
+				continue
+			}
+			r.From, r.To = from, to
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// between returns the faults of r in both directions between the nodes of two groups
+func between(a, b []string, r config.NetworkChaosRule) []config.NetworkChaosRule {
+	var rules []config.NetworkChaosRule
+	for _, x := range a {
+		for _, y := range b {
+			r.From, r.To = x, y
+			rules = append(rules, r)
+			r.From, r.To = y, x
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+func percent(loss string) string {
+	return strings.TrimSuffix(loss, "%") + "%"
+}
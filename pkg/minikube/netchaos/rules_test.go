@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netchaos
+
+import (
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    config.NetworkChaosRule
+		wantErr bool
+	}{
+		{"latency", config.NetworkChaosRule{From: "a", To: "b", Latency: "100ms", Jitter: "10ms"}, false},
+		{"loss and rate", config.NetworkChaosRule{From: "a", To: "b", Loss: "2.5%", Rate: "512kbit"}, false},
+		{"partition", config.NetworkChaosRule{From: "a", To: "b", Partition: true}, false},
+		{"no fault", config.NetworkChaosRule{From: "a", To: "b"}, true},
+		{"same node", config.NetworkChaosRule{From: "a", To: "a", Latency: "1ms"}, true},
+		{"jitter without latency", config.NetworkChaosRule{From: "a", To: "b", Jitter: "10ms"}, true},
+		{"invalid latency", config.NetworkChaosRule{From: "a", To: "b", Latency: "fast"}, true},
+		{"invalid loss", config.NetworkChaosRule{From: "a", To: "b", Loss: "150%"}, true},
+		{"invalid rate", config.NetworkChaosRule{From: "a", To: "b", Rate: "fast"}, true},
+	}
+	for _, tc := range tests {
+		if err := Validate(tc.rule); (err != nil) != tc.wantErr {
+			t.Errorf("Validate(%s) error = %v, wantErr %v", tc.name, err, tc.wantErr)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	rules := Merge(nil, config.NetworkChaosRule{From: "a", To: "b", Latency: "100ms"})
+	rules = Merge(rules, config.NetworkChaosRule{From: "a", To: "b", Loss: "1%"})
+	rules = Merge(rules, config.NetworkChaosRule{From: "b", To: "a", Partition: true})
+	if len(rules) != 2 {
+		t.Fatalf("Merge() = %v, expected 2 rules", rules)
+	}
+	if got := Describe(rules[0]); got != "latency 100ms, loss 1%" {
+		t.Errorf("Describe() = %q, expected the merged faults", got)
+	}
+	if got := Describe(rules[1]); got != "partitioned" {
+		t.Errorf("Describe() = %q, expected partitioned", got)
+	}
+}
+
+func TestScenarios(t *testing.T) {
+	nodes := []string{"minikube", "minikube-m02", "minikube-m03"}
+	tests := []struct {
+		name  string
+		rules int
+	}{
+		{"wan", 6},
+		{"flaky", 6},
+		{"slow-link", 6},
+		{"isolate-primary", 4},
+		{"split-brain", 4},
+	}
+	for _, tc := range tests {
+		s := FindScenario(tc.name)
+		if s == nil {
+			t.Fatalf("scenario %s not found", tc.name)
+		}
+		rules := s.Rules(nodes)
+		if len(rules) != tc.rules {
+			t.Errorf("scenario %s has %d rules, expected %d", tc.name, len(rules), tc.rules)
+		}
+		for _, r := range rules {
+			if err := Validate(r); err != nil {
+				t.Errorf("scenario %s has an invalid rule %+v: %v", tc.name, r, err)
+			}
+		}
+	}
+	if FindScenario("meteor") != nil {
+		t.Errorf("FindScenario of an unknown scenario expected nil")
+	}
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/kapi"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/sysinit"
+)
+
+// drainedAnnotation marks the nodes cordoned by a drain, so that they are uncordoned when they start again
+const drainedAnnotation = "minikube.k8s.io/drained"
+
+// drainPollInterval is how often an eviction blocked by a PodDisruptionBudget is retried, and evicted pods are checked
+var drainPollInterval = 2 * time.Second
+
+// ErrNoController is the error of the pods which are not evicted because they have no controller to recreate them on another node.
+// Deleting them would lose them, while they run again when their node starts.
+var ErrNoController = errors.New("the pod has no controller to recreate it, it is kept to run again when the node starts")
+
+// Eviction is the result of the eviction of a pod while draining a node
+type Eviction struct {
+	Node      string
+	Namespace string
+	Pod       string
+	Duration  time.Duration
+	// Err is nil if the pod was evicted and terminated before the timeout
+	Err error
+}
+
+// DrainNodes cordons the nodes, then evicts their pods, respecting PodDisruptionBudgets, until timeout.
+// All the nodes are cordoned first, so that the evicted pods are not rescheduled on a node about to be drained.
+// Pods which could not be evicted in time are reported with an error, they are killed when their node stops.
+// Pods without a controller are not evicted, they are reported with ErrNoController.
+func DrainNodes(cc config.ClusterConfig, nodes []config.Node, timeout time.Duration) ([]Eviction, error) {
+	client, err := kapi.Client(cc.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, "kubernetes client")
+	}
+	var names []string
+	for _, n := range nodes {
+		names = append(names, config.MachineName(cc, n))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return drain(ctx, client, names)
+}
+
+func drain(ctx context.Context, client kubernetes.Interface, names []string) ([]Eviction, error) {
+	for _, name := range names {
+		if err := cordon(ctx, client, name, true); err != nil {
+			return nil, errors.Wrapf(err, "cordon %s", name)
+		}
+	}
+
+	var pods []core.Pod
+	var kept []Eviction
+	for _, name := range names {
+		list, err := client.CoreV1().Pods("").List(ctx, meta.ListOptions{FieldSelector: "spec.nodeName=" + name})
+		if err != nil {
+			return nil, errors.Wrapf(err, "list pods of %s", name)
+		}
+		for _, p := range list.Items {
+			if p.Spec.NodeName != name || !evictable(p) {
+				continue
+			}
+			if meta.GetControllerOf(&p) == nil {
+				kept = append(kept, Eviction{Node: name, Namespace: p.Namespace, Pod: p.Name, Err: ErrNoController})
+				continue
+			}
+			pods = append(pods, p)
+		}
+	}
+
+	evictions := make([]Eviction, len(pods))
+	var wg sync.WaitGroup
+	for i, p := range pods {
+		wg.Add(1)
+		go func(i int, p core.Pod) {
+			defer wg.Done()
+			start := time.Now()
+			err := evict(ctx, client, p)
+			evictions[i] = Eviction{Node: p.Spec.NodeName, Namespace: p.Namespace, Pod: p.Name, Duration: time.Since(start), Err: err}
+		}(i, p)
+	}
+	wg.Wait()
+	return append(evictions, kept...), nil
+}
+
+// evictable returns whether a pod is evicted by a drain, like kubectl drain --ignore-daemonsets does
+func evictable(p core.Pod) bool {
+	// mirror pods of static pods, like the control plane, are stopped by the kubelet
+	if _, ok := p.Annotations[core.MirrorPodAnnotationKey]; ok {
+		return false
+	}
+	// pods of daemon sets would be recreated on the node right away
+	for _, o := range p.OwnerReferences {
+		if o.Kind == "DaemonSet" {
+			return false
+		}
+	}
+	return p.Status.Phase != core.PodSucceeded && p.Status.Phase != core.PodFailed
+}
+
+// evict evicts a pod through the eviction API, which respects PodDisruptionBudgets, and waits for it to terminate
+func evict(ctx context.Context, client kubernetes.Interface, p core.Pod) error {
+	eviction := &policy.Eviction{ObjectMeta: meta.ObjectMeta{Name: p.Name, Namespace: p.Namespace}}
+	for {
+		err := client.PolicyV1().Evictions(p.Namespace).Evict(ctx, eviction)
+		if err == nil || apierr.IsNotFound(err) {
+			break
+		}
+		if !apierr.IsTooManyRequests(err) {
+			return errors.Wrap(err, "evict")
+		}
+		// the eviction would violate a PodDisruptionBudget, retry until another replica is ready or the timeout
+		klog.Infof("eviction of %s/%s is blocked: %v", p.Namespace, p.Name, err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out, the eviction is blocked by a PodDisruptionBudget: %v", err)
+		case <-time.After(drainPollInterval):
+		}
+	}
+
+	for {
+		cur, err := client.CoreV1().Pods(p.Namespace).Get(ctx, p.Name, meta.GetOptions{})
+		if apierr.IsNotFound(err) || (err == nil && cur.UID != p.UID) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for the pod to terminate")
+		case <-time.After(drainPollInterval):
+		}
+	}
+}
+
+// cordon marks a node as unschedulable, or schedulable again, and records that it was cordoned by a drain
+func cordon(ctx context.Context, client kubernetes.Interface, name string, unschedulable bool) error {
+	annotation := `"true"`
+	if !unschedulable {
+		annotation = "null"
+	}
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%s}},"spec":{"unschedulable":%t}}`, drainedAnnotation, annotation, unschedulable)
+	_, err := client.CoreV1().Nodes().Patch(ctx, name, types.StrategicMergePatchType, []byte(patch), meta.PatchOptions{})
+	return err
+}
+
+// UncordonDrained makes the nodes which were cordoned by a drain schedulable again, once they are started
+func UncordonDrained(cc config.ClusterConfig, nodes []config.Node) error {
+	client, err := kapi.Client(cc.Name)
+	if err != nil {
+		return errors.Wrap(err, "kubernetes client")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	for _, n := range nodes {
+		name := config.MachineName(cc, n)
+		node, err := client.CoreV1().Nodes().Get(ctx, name, meta.GetOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "get node %s", name)
+		}
+		if _, ok := node.Annotations[drainedAnnotation]; !ok {
+			continue
+		}
+		klog.Infof("uncordoning node %s, it was drained when it was stopped", name)
+		if err := cordon(ctx, client, name, false); err != nil {
+			return errors.Wrapf(err, "uncordon %s", name)
+		}
+	}
+	return nil
+}
+
+// StopKubelet stops the kubelet of a node before it stops, so that it does not restart the pods evicted by a drain
+func StopKubelet(api libmachine.API, machineName string) error {
+	h, err := machine.LoadHost(api, machineName)
+	if err != nil {
+		return errors.Wrap(err, "load host")
+	}
+	r, err := machine.CommandRunner(h)
+	if err != nil {
+		return errors.Wrap(err, "command runner")
+	}
+	return sysinit.New(r).Stop("kubelet")
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func pod(name, node string, mutate func(*core.Pod)) *core.Pod {
+	controller := true
+	p := &core.Pod{
+		ObjectMeta: meta.ObjectMeta{Name: name, Namespace: "default", UID: types.UID("uid-" + name),
+			OwnerReferences: []meta.OwnerReference{{Kind: "ReplicaSet", Name: name, Controller: &controller}}},
+		Spec:   core.PodSpec{NodeName: node},
+		Status: core.PodStatus{Phase: core.PodRunning},
+	}
+	if mutate != nil {
+		mutate(p)
+	}
+	return p
+}
+
+func TestDrain(t *testing.T) {
+	defer func(d time.Duration) { drainPollInterval = d }(drainPollInterval)
+	drainPollInterval = 10 * time.Millisecond
+
+	client := fake.NewSimpleClientset(
+		&core.Node{ObjectMeta: meta.ObjectMeta{Name: "m01"}},
+		pod("web", "m01", nil),
+		pod("db", "m01", nil),
+		pod("other", "m02", nil),
+		pod("ds", "m01", func(p *core.Pod) {
+			p.OwnerReferences = []meta.OwnerReference{{Kind: "DaemonSet", Name: "ds"}}
+		}),
+		pod("static", "m01", func(p *core.Pod) {
+			p.Annotations = map[string]string{core.MirrorPodAnnotationKey: "x"}
+		}),
+		pod("done", "m01", func(p *core.Pod) { p.Status.Phase = core.PodSucceeded }),
+		pod("bare", "m01", func(p *core.Pod) { p.OwnerReferences = nil }),
+	)
+	var evicted []string
+	client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		ev := action.(k8stesting.CreateAction).GetObject().(*policy.Eviction)
+		evicted = append(evicted, ev.Name)
+		if ev.Name == "db" {
+			return true, nil, apierr.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 1)
+		}
+		return true, nil, client.Tracker().Delete(core.SchemeGroupVersion.WithResource("pods"), ev.Namespace, ev.Name)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	evictions, err := drain(ctx, client, []string{"m01"})
+	if err != nil {
+		t.Fatalf("drain: %v", err)
+	}
+
+	results := map[string]error{}
+	for _, e := range evictions {
+		if e.Node != "m01" {
+			t.Errorf("pod %s evicted from %s, expected m01", e.Pod, e.Node)
+		}
+		results[e.Pod] = e.Err
+	}
+	if len(results) != 3 {
+		t.Fatalf("evictions = %+v, expected web, db and bare only", evictions)
+	}
+	if err := results["bare"]; !errors.Is(err, ErrNoController) {
+		t.Errorf("bare error = %v, expected it to be kept as it has no controller", err)
+	}
+	if strings.Contains(strings.Join(evicted, ","), "bare") {
+		t.Error("bare was evicted although it has no controller")
+	}
+	if err := results["web"]; err != nil {
+		t.Errorf("web was not evicted: %v", err)
+	}
+	if err := results["db"]; err == nil || !strings.Contains(err.Error(), "PodDisruptionBudget") {
+		t.Errorf("db error = %v, expected a timeout blocked by a PodDisruptionBudget", err)
+	}
+	// the blocked eviction is retried until the timeout
+	if n := strings.Count(strings.Join(evicted, ","), "db"); n < 2 {
+		t.Errorf("eviction of db was tried %d times, expected retries", n)
+	}
+
+	n, err := client.CoreV1().Nodes().Get(context.Background(), "m01", meta.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !n.Spec.Unschedulable || n.Annotations[drainedAnnotation] != "true" {
+		t.Errorf("node was not cordoned by the drain: %+v", n)
+	}
+
+	if err := cordon(context.Background(), client, "m01", false); err != nil {
+		t.Fatalf("uncordon: %v", err)
+	}
+	n, err = client.CoreV1().Nodes().Get(context.Background(), "m01", meta.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := n.Annotations[drainedAnnotation]; ok || n.Spec.Unschedulable {
+		t.Errorf("node was not uncordoned: %+v", n)
+	}
+}
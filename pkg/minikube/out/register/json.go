@@ -46,6 +46,12 @@ func PrintDownloadProgress(artifact, progress string) {
 	printAsCloudEvent(s, s.data)
 }
 
+// PrintEviction prints an Eviction type in JSON format
+func PrintEviction(node, namespace, pod, duration, err string) {
+	e := NewEviction(node, namespace, pod, duration, err)
+	printAndRecordCloudEvent(e, e.data)
+}
+
 // PrintError prints an Error type in JSON format
 func PrintError(err string) {
 	e := NewError(err)
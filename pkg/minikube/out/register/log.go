@@ -86,6 +86,34 @@ func NewDownloadProgress(artifact, progress string) *DownloadProgress {
 	}}
 }
 
+// Eviction will be used to notify the user of the eviction of a pod while draining a node
+type Eviction struct {
+	data map[string]string
+}
+
+// Type returns the cloud events compatible type of this struct
+func (s *Eviction) Type() string {
+	return "io.k8s.sigs.minikube.eviction"
+}
+
+// NewEviction returns a new eviction type, err is empty if the pod was evicted
+func NewEviction(node, namespace, pod, duration, err string) *Eviction {
+	evicted := "true"
+	if err != "" {
+		evicted = "false"
+	}
+	return &Eviction{data: map[string]string{
+		"totalsteps":  Reg.totalSteps(),
+		"currentstep": Reg.currentStep(),
+		"node":        node,
+		"namespace":   namespace,
+		"pod":         pod,
+		"evicted":     evicted,
+		"duration":    duration,
+		"error":       strings.TrimSpace(err),
+	}}
+}
+
 // Warning will be used to notify the user of warnings
 type Warning struct {
 	data map[string]string
@@ -53,6 +53,7 @@ const (
 	Purging  RegStep = "Puring home dir"
 
 	Stopping  RegStep = "Stopping"
+	Draining  RegStep = "Draining"
 	PowerOff  RegStep = "PowerOff"
 	Pausing   RegStep = "Pausing"
 	Unpausing RegStep = "Unpausing"
@@ -99,10 +100,10 @@ func init() {
 				Done,
 			},
 
-			Stopping:  {Stopping, PowerOff, Done},
+			Stopping:  {Stopping, Draining, PowerOff, Done},
 			Pausing:   {Pausing, Done},
 			Unpausing: {Unpausing, Done},
-			Deleting:  {Deleting, Stopping, Done, Purging},
+			Deleting:  {Deleting, Draining, Stopping, Done, Purging},
 		},
 	}
 }
@@ -0,0 +1,223 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package portforward
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/kapi"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/service"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+const (
+	// endpointCheckInterval defines how frequently the endpoint of an active forward is checked
+	endpointCheckInterval = 2 * time.Second
+	// maxBackoff is the longest time to wait between reconnect attempts
+	maxBackoff = 30 * time.Second
+)
+
+// Manager runs the port forwards of a profile and reconnects them when their endpoints change.
+// Like the tunnel manager, it runs until its context is cancelled.
+type Manager struct {
+	profile    string
+	restConfig *rest.Config
+	resolver   *resolver
+	state      *stateWriter
+}
+
+// NewManager creates a new Manager for a profile
+func NewManager(profile string) (*Manager, error) {
+	restConfig, err := kapi.ClientConfig(profile)
+	if err != nil {
+		return nil, errors.Wrap(err, "client config")
+	}
+	coreClient, err := service.K8s.GetCoreClient(profile)
+	if err != nil {
+		return nil, errors.Wrap(err, "core client")
+	}
+	client, err := kapi.Client(profile)
+	if err != nil {
+		return nil, errors.Wrap(err, "client")
+	}
+	return &Manager{
+		profile:    profile,
+		restConfig: restConfig,
+		resolver: &resolver{
+			core: coreClient,
+			apps: client.AppsV1(),
+		},
+		state: newStateWriter(profile),
+	}, nil
+}
+
+// StartForwards starts the given port forwards, done is signaled once all of them are stopped
+func (mgr *Manager) StartForwards(ctx context.Context, forwards []config.PortForward) (done chan bool) {
+	done = make(chan bool, 1)
+	var wg sync.WaitGroup
+	for _, pf := range forwards {
+		wg.Add(1)
+		go func(pf config.PortForward) {
+			defer wg.Done()
+			mgr.run(ctx, pf)
+		}(pf)
+	}
+	go func() {
+		wg.Wait()
+		mgr.state.remove()
+		done <- true
+	}()
+	return done
+}
+
+// run keeps a single port forward connected until ctx is cancelled
+func (mgr *Manager) run(ctx context.Context, pf config.PortForward) {
+	backoff := time.Second
+	current := ""
+	for {
+		pod, ports, err := mgr.resolver.resolve(pf, current)
+		if err != nil {
+			klog.Infof("port forward %s: %v", pf.Name, err)
+			mgr.state.update(pf.Name, func(s *Status) {
+				if s.State != Reconnecting {
+					s.State = Waiting
+				}
+				s.Error = err.Error()
+			})
+		} else {
+			current = pod.Name
+			err = mgr.forward(ctx, pf, pod.Namespace, pod.Name, ports)
+			if ctx.Err() != nil {
+				return
+			}
+			klog.Infof("port forward %s to pod %s ended: %v", pf.Name, pod.Name, err)
+			out.Step(style.Waiting, "Reconnecting port forward {{.name}} ...", out.V{"name": pf.Name})
+			mgr.state.update(pf.Name, func(s *Status) {
+				s.State = Reconnecting
+				s.Reconnects++
+				if err != nil {
+					s.Error = err.Error()
+				}
+			})
+			backoff = time.Second
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if err != nil {
+			backoff *= 2
+			if backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+		}
+	}
+}
+
+// forward connects to a pod and blocks until ctx is cancelled, the connection is lost, or the pod is no longer the endpoint to use
+func (mgr *Manager) forward(ctx context.Context, pf config.PortForward, namespace, podName string, ports []string) error {
+	req := mgr.resolver.core.RESTClient().Post().Resource("pods").Namespace(namespace).Name(podName).SubResource("portforward")
+	transport, upgrader, err := spdy.RoundTripperFor(mgr.restConfig)
+	if err != nil {
+		return errors.Wrap(err, "round tripper")
+	}
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+
+	address := pf.Address
+	if address == "" {
+		address = "127.0.0.1"
+	}
+	stop := make(chan struct{})
+	ready := make(chan struct{})
+	fw, err := portforward.NewOnAddresses(dialer, []string{address}, ports, stop, ready, io.Discard, &klogWriter{name: pf.Name})
+	if err != nil {
+		return errors.Wrap(err, "creating port forward")
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- fw.ForwardPorts()
+	}()
+
+	ticker := time.NewTicker(endpointCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			close(stop)
+			<-errCh
+			return nil
+		case err := <-errCh:
+			if err == nil {
+				err = errors.New("connection closed")
+			}
+			return err
+		case <-ready:
+			ready = nil
+			forwarded, err := fw.GetPorts()
+			if err != nil {
+				klog.Warningf("unable to get forwarded ports: %v", err)
+			}
+			var pairs []string
+			for _, p := range forwarded {
+				pairs = append(pairs, fmt.Sprintf("%d:%d", p.Local, p.Remote))
+			}
+			out.Step(style.Running, "Forwarding {{.name}} to pod {{.pod}}: {{.ports}}", out.V{"name": pf.Name, "pod": podName, "ports": strings.Join(pairs, ", ")})
+			mgr.state.update(pf.Name, func(s *Status) {
+				s.State = Active
+				s.Pod = podName
+				s.Ports = pairs
+				s.Error = ""
+			})
+		case <-ticker.C:
+			pod, _, err := mgr.resolver.resolve(pf, podName)
+			if err != nil || pod.Name != podName {
+				close(stop)
+				<-errCh
+				if err != nil {
+					return errors.Wrap(err, "endpoint check")
+				}
+				return fmt.Errorf("endpoint changed from %s to %s", podName, pod.Name)
+			}
+		}
+	}
+}
+
+// klogWriter sends the error output of the portforward package to the log
+type klogWriter struct {
+	name string
+}
+
+func (w *klogWriter) Write(p []byte) (int, error) {
+	klog.Warningf("port forward %s: %s", w.name, strings.TrimSpace(string(p)))
+	return len(p), nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package portforward
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/tunnel"
+	"k8s.io/minikube/pkg/util/lock"
+)
+
+// States of a port forward
+const (
+	// Stopped means no `minikube port-forward` process is running the forward
+	Stopped = "Stopped"
+	// Waiting means there is no ready endpoint to forward to yet
+	Waiting = "Waiting"
+	// Active means the forward is connected
+	Active = "Active"
+	// Reconnecting means the connection was lost or the endpoint changed
+	Reconnecting = "Reconnecting"
+)
+
+// Status is the state of a port forward, as reported by the running `minikube port-forward` process
+type Status struct {
+	State      string
+	Pod        string   `json:",omitempty"`
+	Ports      []string `json:",omitempty"` // LOCAL:REMOTE pairs actually being forwarded
+	Error      string   `json:",omitempty"`
+	Reconnects int
+	Since      time.Time
+}
+
+// state is persisted in the profile directory so that other minikube processes can report it
+type state struct {
+	Pid      int
+	Forwards map[string]Status
+}
+
+// StatePath returns the path of the port forward state file of a profile
+func StatePath(profile string) string {
+	return filepath.Join(localpath.Profile(profile), "port-forwards.json")
+}
+
+// ReadStatus returns the status of all port forwards of a profile, forwards not run by a live process are absent
+func ReadStatus(profile string) (map[string]Status, error) {
+	data, err := os.ReadFile(StatePath(profile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]Status{}, nil
+		}
+		return nil, errors.Wrap(err, "reading port forward state")
+	}
+	var s state
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, errors.Wrap(err, "parsing port forward state")
+	}
+	running, err := tunnel.IsRunning(s.Pid)
+	if err != nil {
+		klog.Warningf("unable to check if port forward process %d is running: %v", s.Pid, err)
+	}
+	if !running || s.Forwards == nil {
+		return map[string]Status{}, nil
+	}
+	return s.Forwards, nil
+}
+
+// stateWriter records status updates of the forwards run by this process
+type stateWriter struct {
+	mu   sync.Mutex
+	path string
+	s    state
+}
+
+func newStateWriter(profile string) *stateWriter {
+	return &stateWriter{
+		path: StatePath(profile),
+		s: state{
+			Pid:      os.Getpid(),
+			Forwards: map[string]Status{},
+		},
+	}
+}
+
+func (w *stateWriter) update(name string, f func(*Status)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	st := w.s.Forwards[name]
+	prev := st.State
+	f(&st)
+	if st.State != prev {
+		st.Since = time.Now()
+	}
+	w.s.Forwards[name] = st
+	data, err := json.Marshal(w.s)
+	if err != nil {
+		klog.Errorf("unable to marshal port forward state: %v", err)
+		return
+	}
+	if err := lock.WriteFile(w.path, data, 0644); err != nil {
+		klog.Errorf("unable to write port forward state: %v", err)
+	}
+}
+
+func (w *stateWriter) remove() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := os.Remove(w.path); err != nil && !os.IsNotExist(err) {
+		klog.Warningf("unable to remove port forward state: %v", err)
+	}
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package portforward
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	typed_apps "k8s.io/client-go/kubernetes/typed/apps/v1"
+	typed_core "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+const (
+	// KindPod forwards to a pod by name, or to any pod matching a selector
+	KindPod = "pod"
+	// KindDeployment forwards to a pod of a deployment
+	KindDeployment = "deployment"
+	// KindService forwards to a pod backing a service, ports are service ports
+	KindService = "service"
+)
+
+var kindAliases = map[string]string{
+	"po":          KindPod,
+	"pod":         KindPod,
+	"pods":        KindPod,
+	"deploy":      KindDeployment,
+	"deployment":  KindDeployment,
+	"deployments": KindDeployment,
+	"svc":         KindService,
+	"service":     KindService,
+	"services":    KindService,
+}
+
+// ParseTarget parses a target in the TYPE/NAME form used by kubectl, a bare NAME is a pod
+func ParseTarget(target string) (kind, name string, err error) {
+	parts := strings.Split(target, "/")
+	switch len(parts) {
+	case 1:
+		kind, name = KindPod, parts[0]
+	case 2:
+		k, ok := kindAliases[strings.ToLower(parts[0])]
+		if !ok {
+			return "", "", fmt.Errorf("unsupported target type %q, must be one of pod, deployment or service", parts[0])
+		}
+		kind, name = k, parts[1]
+	default:
+		return "", "", fmt.Errorf("invalid target %q, must be TYPE/NAME", target)
+	}
+	if name == "" {
+		return "", "", fmt.Errorf("invalid target %q, name is missing", target)
+	}
+	return kind, name, nil
+}
+
+// port is a parsed [LOCAL:]REMOTE port pair, a local port of 0 means a random port
+type port struct {
+	local  int
+	remote int
+}
+
+// ParsePorts validates port definitions formatted as [LOCAL:]REMOTE
+func ParsePorts(ports []string) error {
+	if len(ports) == 0 {
+		return errors.New("at least one port is required")
+	}
+	_, err := parsePorts(ports)
+	return err
+}
+
+func parsePorts(ports []string) ([]port, error) {
+	var parsed []port
+	for _, p := range ports {
+		local, remote, hasLocal := strings.Cut(p, ":")
+		if !hasLocal {
+			local, remote = "", p
+		}
+		r, err := strconv.Atoi(remote)
+		if err != nil || r <= 0 || r > 65535 {
+			return nil, fmt.Errorf("invalid remote port in %q", p)
+		}
+		// like kubectl, REMOTE uses the same local port and :REMOTE a random one
+		l := r
+		if hasLocal {
+			l = 0
+		}
+		if local != "" {
+			l, err = strconv.Atoi(local)
+			if err != nil || l < 0 || l > 65535 {
+				return nil, fmt.Errorf("invalid local port in %q", p)
+			}
+		}
+		parsed = append(parsed, port{local: l, remote: r})
+	}
+	return parsed, nil
+}
+
+// resolver finds the pod a port forward should currently connect to
+type resolver struct {
+	core typed_core.CoreV1Interface
+	apps typed_apps.AppsV1Interface
+}
+
+// resolve returns the pod to forward to and the ports translated to container ports, formatted for the portforward package.
+// The current pod is kept as long as it is still a valid endpoint, to avoid needless reconnects.
+func (r *resolver) resolve(pf config.PortForward, current string) (*core.Pod, []string, error) {
+	ctx := context.Background()
+	ports, err := parsePorts(pf.Ports)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var selector string
+	var svc *core.Service
+	switch pf.Kind {
+	case KindPod, "":
+		if pf.Target != "" {
+			pod, err := r.core.Pods(pf.Namespace).Get(ctx, pf.Target, meta.GetOptions{})
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "getting pod %s", pf.Target)
+			}
+			if !podReady(pod) {
+				return nil, nil, fmt.Errorf("pod %s is not ready", pod.Name)
+			}
+			return pod, forwardPorts(ports, nil, pod), nil
+		}
+		selector = pf.Selector
+	case KindDeployment:
+		d, err := r.apps.Deployments(pf.Namespace).Get(ctx, pf.Target, meta.GetOptions{})
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "getting deployment %s", pf.Target)
+		}
+		s, err := meta.LabelSelectorAsSelector(d.Spec.Selector)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "deployment %s selector", pf.Target)
+		}
+		selector = s.String()
+	case KindService:
+		svc, err = r.core.Services(pf.Namespace).Get(ctx, pf.Target, meta.GetOptions{})
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "getting service %s", pf.Target)
+		}
+		if len(svc.Spec.Selector) == 0 {
+			return nil, nil, fmt.Errorf("service %s has no selector", pf.Target)
+		}
+		selector = labels.SelectorFromSet(svc.Spec.Selector).String()
+	default:
+		return nil, nil, fmt.Errorf("unsupported target type %q", pf.Kind)
+	}
+
+	pods, err := r.core.Pods(pf.Namespace).List(ctx, meta.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "listing pods for %q", selector)
+	}
+	pod := pickPod(pods.Items, current)
+	if pod == nil {
+		return nil, nil, fmt.Errorf("no ready pods found for %q", selector)
+	}
+	return pod, forwardPorts(ports, svc, pod), nil
+}
+
+// pickPod returns the current pod if it is still ready, otherwise the first ready pod
+func pickPod(pods []core.Pod, current string) *core.Pod {
+	var ready []*core.Pod
+	for i := range pods {
+		if !podReady(&pods[i]) {
+			continue
+		}
+		if pods[i].Name == current {
+			return &pods[i]
+		}
+		ready = append(ready, &pods[i])
+	}
+	if len(ready) == 0 {
+		return nil
+	}
+	sort.Slice(ready, func(i, j int) bool { return ready[i].Name < ready[j].Name })
+	return ready[0]
+}
+
+func podReady(pod *core.Pod) bool {
+	if pod.DeletionTimestamp != nil || pod.Status.Phase != core.PodRunning {
+		return false
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == core.PodReady {
+			return c.Status == core.ConditionTrue
+		}
+	}
+	return false
+}
+
+// forwardPorts translates the requested ports into LOCAL:CONTAINER pairs,
+// mapping service ports to their target ports when forwarding to a service
+func forwardPorts(ports []port, svc *core.Service, pod *core.Pod) []string {
+	var result []string
+	for _, p := range ports {
+		remote := p.remote
+		if svc != nil {
+			remote = serviceTargetPort(svc, pod, p.remote)
+		}
+		result = append(result, fmt.Sprintf("%d:%d", p.local, remote))
+	}
+	return result
+}
+
+func serviceTargetPort(svc *core.Service, pod *core.Pod, svcPort int) int {
+	for _, sp := range svc.Spec.Ports {
+		if int(sp.Port) != svcPort {
+			continue
+		}
+		switch {
+		case sp.TargetPort.Type == intstr.Int && sp.TargetPort.IntVal != 0:
+			return int(sp.TargetPort.IntVal)
+		case sp.TargetPort.Type == intstr.String && sp.TargetPort.StrVal != "":
+			for _, c := range pod.Spec.Containers {
+				for _, cp := range c.Ports {
+					if cp.Name == sp.TargetPort.StrVal {
+						return int(cp.ContainerPort)
+					}
+				}
+			}
+		}
+		return svcPort
+	}
+	return svcPort
+}
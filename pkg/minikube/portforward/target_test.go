@@ -0,0 +1,173 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package portforward
+
+import (
+	"reflect"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		target  string
+		kind    string
+		name    string
+		wantErr bool
+	}{
+		{target: "web-0", kind: KindPod, name: "web-0"},
+		{target: "deploy/web", kind: KindDeployment, name: "web"},
+		{target: "svc/web", kind: KindService, name: "web"},
+		{target: "statefulset/web", wantErr: true},
+		{target: "pod/", wantErr: true},
+		{target: "a/b/c", wantErr: true},
+	}
+	for _, tc := range tests {
+		kind, name, err := ParseTarget(tc.target)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("ParseTarget(%q) error = %v, wantErr %v", tc.target, err, tc.wantErr)
+			continue
+		}
+		if kind != tc.kind || name != tc.name {
+			t.Errorf("ParseTarget(%q) = %s, %s, expected %s, %s", tc.target, kind, name, tc.kind, tc.name)
+		}
+	}
+}
+
+func TestParsePorts(t *testing.T) {
+	got, err := parsePorts([]string{"8080:80", "5432", ":9090"})
+	if err != nil {
+		t.Fatalf("parsePorts() error = %v", err)
+	}
+	expected := []port{{local: 8080, remote: 80}, {local: 5432, remote: 5432}, {local: 0, remote: 9090}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("parsePorts() = %v, expected %v", got, expected)
+	}
+
+	for _, invalid := range [][]string{{}, {"http"}, {"80:"}, {"x:80"}, {"70000"}} {
+		if err := ParsePorts(invalid); err == nil {
+			t.Errorf("ParsePorts(%v) expected an error", invalid)
+		}
+	}
+}
+
+func readyPod(name string, labels map[string]string, ready bool) *core.Pod {
+	status := core.ConditionFalse
+	if ready {
+		status = core.ConditionTrue
+	}
+	return &core.Pod{
+		ObjectMeta: meta.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+		Spec: core.PodSpec{Containers: []core.Container{{
+			Name:  "app",
+			Ports: []core.ContainerPort{{Name: "http", ContainerPort: 8080}},
+		}}},
+		Status: core.PodStatus{
+			Phase:      core.PodRunning,
+			Conditions: []core.PodCondition{{Type: core.PodReady, Status: status}},
+		},
+	}
+}
+
+func TestResolve(t *testing.T) {
+	labels := map[string]string{"app": "web"}
+	client := fake.NewSimpleClientset(
+		readyPod("web-a", labels, false),
+		readyPod("web-b", labels, true),
+		readyPod("web-c", labels, true),
+		&apps.Deployment{
+			ObjectMeta: meta.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       apps.DeploymentSpec{Selector: &meta.LabelSelector{MatchLabels: labels}},
+		},
+		&core.Service{
+			ObjectMeta: meta.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec: core.ServiceSpec{
+				Selector: labels,
+				Ports:    []core.ServicePort{{Port: 80, TargetPort: intstr.FromString("http")}},
+			},
+		},
+	)
+	r := &resolver{core: client.CoreV1(), apps: client.AppsV1()}
+
+	tests := []struct {
+		name      string
+		pf        config.PortForward
+		current   string
+		wantPod   string
+		wantPorts []string
+		wantErr   bool
+	}{
+		{
+			name:      "pod by name",
+			pf:        config.PortForward{Namespace: "default", Kind: KindPod, Target: "web-c", Ports: []string{"9000:8080"}},
+			wantPod:   "web-c",
+			wantPorts: []string{"9000:8080"},
+		},
+		{
+			name:    "pod not ready",
+			pf:      config.PortForward{Namespace: "default", Kind: KindPod, Target: "web-a", Ports: []string{"8080"}},
+			wantErr: true,
+		},
+		{
+			name:      "selector picks first ready pod",
+			pf:        config.PortForward{Namespace: "default", Kind: KindPod, Selector: "app=web", Ports: []string{"8080"}},
+			wantPod:   "web-b",
+			wantPorts: []string{"8080:8080"},
+		},
+		{
+			name:      "deployment keeps the current pod",
+			pf:        config.PortForward{Namespace: "default", Kind: KindDeployment, Target: "web", Ports: []string{"8080"}},
+			current:   "web-c",
+			wantPod:   "web-c",
+			wantPorts: []string{"8080:8080"},
+		},
+		{
+			name:      "service port is mapped to the named target port",
+			pf:        config.PortForward{Namespace: "default", Kind: KindService, Target: "web", Ports: []string{"8000:80"}},
+			wantPod:   "web-b",
+			wantPorts: []string{"8000:8080"},
+		},
+		{
+			name:    "no matching pods",
+			pf:      config.PortForward{Namespace: "default", Kind: KindPod, Selector: "app=db", Ports: []string{"5432"}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pod, ports, err := r.resolve(tc.pf, tc.current)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("resolve() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if pod.Name != tc.wantPod {
+				t.Errorf("resolve() pod = %s, expected %s", pod.Name, tc.wantPod)
+			}
+			if !reflect.DeepEqual(ports, tc.wantPorts) {
+				t.Errorf("resolve() ports = %v, expected %v", ports, tc.wantPorts)
+			}
+		})
+	}
+}
@@ -0,0 +1,302 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package problem detects known problems in the logs and node state of a cluster with declarative rules,
+// each rule maps a problem to a reason.Kind with advice and documentation links.
+package problem
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/reason"
+)
+
+// Components matched by rules besides the log names
+const (
+	// NodeComponent is the state of the Kubernetes nodes, their unwanted conditions
+	NodeComponent = "node"
+	// RuntimeComponent is the system log of the container runtime, whatever its name
+	RuntimeComponent = "runtime"
+)
+
+// Rule is a declarative problem rule, as found in the rule files
+type Rule struct {
+	// ID is the ID of the reason.Kind of the problem, a kind of the reason catalog or a new one
+	ID string `yaml:"id"`
+	// Regexp is the regular expression matching the log lines of the problem
+	Regexp string `yaml:"regexp"`
+	// Ignore is a regular expression of lines that look like the problem but are not
+	Ignore string `yaml:"ignore,omitempty"`
+	// Component limits the rule to a log, like kubelet, runtime or a pod name, or to the node state. Empty matches all logs
+	Component string `yaml:"component,omitempty"`
+	// MinKubernetesVersion and MaxKubernetesVersion limit the rule to a range of Kubernetes versions, inclusive
+	MinKubernetesVersion string `yaml:"minKubernetesVersion,omitempty"`
+	MaxKubernetesVersion string `yaml:"maxKubernetesVersion,omitempty"`
+	// Advice is actionable text that the user should follow, instead of the advice of the kind
+	Advice string `yaml:"advice,omitempty"`
+	// URL is a reference URL for more information, instead of the URL of the kind
+	URL string `yaml:"url,omitempty"`
+	// Issues are the related GitHub issues, instead of the issues of the kind
+	Issues []int `yaml:"issues,omitempty"`
+
+	re       *regexp.Regexp
+	ignore   *regexp.Regexp
+	min, max *semver.Version
+}
+
+// Kind returns the reason of the rule: the kind of its ID in the reason catalog, with the advice, URL and issues of the rule when set.
+// Kinds unknown to minikube, defined by the rule files, are Kubernetes problems.
+func (r *Rule) Kind() reason.Kind {
+	k, ok := reason.Lookup(r.ID)
+	if !ok {
+		k = reason.Kind{ID: r.ID, ExitCode: reason.ExControlPlaneError}
+	}
+	if r.Advice != "" {
+		k.Advice = r.Advice
+	}
+	if r.URL != "" {
+		k.URL = r.URL
+	}
+	if len(r.Issues) > 0 {
+		k.Issues = r.Issues
+	}
+	return k
+}
+
+// compile parses the regular expressions and versions of the rule
+func (r *Rule) compile() error {
+	if r.ID == "" {
+		return fmt.Errorf("rule has no id")
+	}
+	if r.Regexp == "" {
+		return fmt.Errorf("rule %s has no regexp", r.ID)
+	}
+	var err error
+	if r.re, err = regexp.Compile(r.Regexp); err != nil {
+		return errors.Wrapf(err, "rule %s regexp", r.ID)
+	}
+	if r.Ignore != "" {
+		if r.ignore, err = regexp.Compile(r.Ignore); err != nil {
+			return errors.Wrapf(err, "rule %s ignore", r.ID)
+		}
+	}
+	if r.min, err = parseVersion(r.MinKubernetesVersion); err != nil {
+		return errors.Wrapf(err, "rule %s minKubernetesVersion", r.ID)
+	}
+	if r.max, err = parseVersion(r.MaxKubernetesVersion); err != nil {
+		return errors.Wrapf(err, "rule %s maxKubernetesVersion", r.ID)
+	}
+	return nil
+}
+
+func parseVersion(v string) (*semver.Version, error) {
+	if v == "" {
+		return nil, nil
+	}
+	sv, err := semver.ParseTolerant(v)
+	if err != nil {
+		return nil, err
+	}
+	return &sv, nil
+}
+
+// matches returns if the rule matches a line of a component for a Kubernetes version, a zero version matches all ranges
+func (r *Rule) matches(component, line string, version semver.Version) bool {
+	if r.Component != "" && !strings.EqualFold(r.Component, component) {
+		return false
+	}
+	if !version.Equals(semver.Version{}) {
+		if r.min != nil && version.LT(*r.min) {
+			return false
+		}
+		if r.max != nil && version.GT(*r.max) {
+			return false
+		}
+	}
+	return r.re.MatchString(line) && (r.ignore == nil || !r.ignore.MatchString(line))
+}
+
+// Match is a problem found by a rule
+type Match struct {
+	Rule *Rule
+	// Source is the log the problem was found in, like "kube-proxy [id]"
+	Source string
+	Line   string
+}
+
+// Engine matches log lines and node state against rules, in order
+type Engine struct {
+	rules []*Rule
+}
+
+// NewEngine returns an engine with the given rules
+func NewEngine(rules []Rule) (*Engine, error) {
+	e := &Engine{}
+	for i := range rules {
+		r := rules[i]
+		if err := r.compile(); err != nil {
+			return nil, err
+		}
+		e.rules = append(e.rules, &r)
+	}
+	return e, nil
+}
+
+var (
+	defaultOnce   sync.Once
+	defaultEngine *Engine
+)
+
+// Default returns the engine of the user and built-in rules, loaded once
+func Default() *Engine {
+	defaultOnce.Do(func() {
+		defaultEngine = Load()
+	})
+	return defaultEngine
+}
+
+// Load returns an engine with the rules of the files in the rules directory, followed by the built-in rules.
+// Invalid rule files are skipped with a warning.
+func Load() *Engine {
+	e, err := NewEngine(builtinRules)
+	if err != nil {
+		// built-in rules are tested, this is a programming error
+		panic(err)
+	}
+	rules, err := LoadDir(localpath.ProblemRules())
+	if err != nil {
+		klog.Warningf("loading problem rules: %v", err)
+	}
+	e.rules = append(rules.rules, e.rules...)
+	return e
+}
+
+// LoadDir returns an engine with the rules of the *.yaml, *.yml and *.json files of a directory, sorted by name.
+// A missing directory has no rules, the rules of invalid files are skipped and reported as error.
+func LoadDir(dir string) (*Engine, error) {
+	e := &Engine{}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return e, nil
+	}
+	if err != nil {
+		return e, err
+	}
+	names := []string{}
+	for _, de := range entries {
+		switch filepath.Ext(de.Name()) {
+		case ".yaml", ".yml", ".json":
+			names = append(names, de.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var errs []string
+	for _, name := range names {
+		fe, err := LoadFile(filepath.Join(dir, name))
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		e.rules = append(e.rules, fe.rules...)
+	}
+	if len(errs) > 0 {
+		return e, fmt.Errorf("invalid rule files: %s", strings.Join(errs, "; "))
+	}
+	return e, nil
+}
+
+// ruleFile is the format of a rule file
+type ruleFile struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// LoadFile returns an engine with the rules of a YAML or JSON rule file
+func LoadFile(path string) (*Engine, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f ruleFile
+	if err := yaml.UnmarshalStrict(b, &f); err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", path)
+	}
+	e, err := NewEngine(f.Rules)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", path)
+	}
+	return e, nil
+}
+
+// Rules returns the number of rules of the engine
+func (e *Engine) Rules() int {
+	return len(e.rules)
+}
+
+// Match returns the first rule matching a line of a component, or nil
+func (e *Engine) Match(component, line string, version semver.Version) *Rule {
+	for _, r := range e.rules {
+		if r.matches(component, line, version) {
+			return r
+		}
+	}
+	return nil
+}
+
+// ParseKubernetesVersion parses the Kubernetes version of a cluster for Match, invalid versions match all rules
+func ParseKubernetesVersion(v string) semver.Version {
+	sv, err := semver.ParseTolerant(v)
+	if err != nil {
+		klog.Warningf("unable to parse Kubernetes version %q: %v", v, err)
+		return semver.Version{}
+	}
+	return sv
+}
+
+// Component returns the component of a log name: the log name without its container id, like "kube-proxy" for "kube-proxy [id]"
+func Component(source string) string {
+	if i := strings.Index(source, " ["); i > 0 {
+		return source[:i]
+	}
+	return source
+}
+
+// Group returns the matches grouped by rule, in the order the rules were first matched
+func Group(matches []Match) [][]Match {
+	var groups [][]Match
+	index := map[*Rule]int{}
+	for _, m := range matches {
+		i, ok := index[m.Rule]
+		if !ok {
+			i = len(groups)
+			index[m.Rule] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], m)
+	}
+	return groups
+}
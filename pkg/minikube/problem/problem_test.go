@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package problem
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/minikube/pkg/minikube/reason"
+)
+
+func TestBuiltinRules(t *testing.T) {
+	e, err := NewEngine(builtinRules)
+	if err != nil {
+		t.Fatalf("invalid built-in rules: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		component string
+		line      string
+		version   string
+		expected  string
+	}{
+		{"network plugin removed", "kubelet", "unknown flag: --network-plugin", "v1.28.0", "K8S_NETWORK_PLUGIN_REMOVED"},
+		{"network plugin before removal", "kubelet", "unknown flag: --network-plugin", "v1.23.0", "K8S_UNKNOWN_FLAG"},
+		{"network plugin other component", "kube-apiserver", "unknown flag: --network-plugin", "v1.28.0", "K8S_UNKNOWN_FLAG"},
+		{"eviction", "kubelet", "eviction manager: pods kube-proxy-kfs8p evicted", "v1.28.0", "RSRC_POD_EVICTION"},
+		{"ignored", "kubectl", "error: no objects passed to apply", "v1.28.0", ""},
+		{"memory pressure", NodeComponent, `node has unwanted condition "MemoryPressure" : Reason "KubeletHasInsufficientMemory" Message: ""`, "v1.28.0", "RSRC_NODE_PRESSURE"},
+		{"node rules only match nodes", "kubelet", `"MemoryPressure"`, "v1.28.0", ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ""
+			if r := e.Match(tc.component, tc.line, ParseKubernetesVersion(tc.version)); r != nil {
+				got = r.ID
+			}
+			if got != tc.expected {
+				t.Errorf("Match(%q, %q, %s) = %q, expected %q", tc.component, tc.line, tc.version, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestBuiltinRuleKinds(t *testing.T) {
+	for _, r := range builtinRules {
+		if _, ok := reason.Lookup(r.ID); !ok {
+			t.Errorf("built-in rule %s has no kind in the reason catalog", r.ID)
+		}
+		if r.Advice != "" || r.URL != "" || len(r.Issues) > 0 {
+			t.Errorf("built-in rule %s overrides the advice of its kind", r.ID)
+		}
+		if k := r.Kind(); k.ExitCode == 0 {
+			t.Errorf("kind of built-in rule %s has no exit code", r.ID)
+		}
+	}
+	if k := (&Rule{ID: reason.KubeletContainerManager.ID}).Kind(); k.Advice != reason.KubeletContainerManager.Advice || len(k.Issues) != 1 {
+		t.Errorf("Kind() = %+v, expected the kind of the catalog", k)
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"cni.yaml": `rules:
+- id: MY_CNI_ROUTE
+  regexp: no route to host
+  component: kindnet
+  maxKubernetesVersion: 1.30.0
+  advice: restart the CNI pods
+  issues: [123]
+`,
+		"broken.json": `{"rules": [{"id": "BROKEN", "regexp": "("}]}`,
+		"notes.txt":   "not a rule file",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	e, err := LoadDir(dir)
+	if err == nil {
+		t.Errorf("LoadDir() expected an error for the invalid rule file")
+	}
+	if e.Rules() != 1 {
+		t.Fatalf("LoadDir() loaded %d rules, expected 1", e.Rules())
+	}
+	r := e.Match("kindnet", "dial tcp 10.244.1.2:80: no route to host", ParseKubernetesVersion("v1.29.1"))
+	if r == nil || r.ID != "MY_CNI_ROUTE" {
+		t.Fatalf("expected the rule to match, got %+v", r)
+	}
+	if k := r.Kind(); k.Advice != "restart the CNI pods" || len(k.IssueURLs()) != 1 || k.ExitCode != reason.ExControlPlaneError {
+		t.Errorf("Kind() = %+v", k)
+	}
+	if r := e.Match("kindnet", "no route to host", ParseKubernetesVersion("v1.31.0")); r != nil {
+		t.Errorf("expected no match above the max version, got %s", r.ID)
+	}
+	if r := e.Match("kindnet", "no route to host", semver.Version{}); r == nil {
+		t.Errorf("expected a match for an unknown version")
+	}
+
+	if e, err := LoadDir(filepath.Join(dir, "missing")); err != nil || e.Rules() != 0 {
+		t.Errorf("LoadDir() of a missing directory = %d rules, %v", e.Rules(), err)
+	}
+}
+
+func TestGroup(t *testing.T) {
+	a, b := &Rule{ID: "A"}, &Rule{ID: "B"}
+	groups := Group([]Match{{Rule: a, Line: "1"}, {Rule: b, Line: "2"}, {Rule: a, Line: "3"}})
+	if len(groups) != 2 || len(groups[0]) != 2 || groups[0][1].Line != "3" || groups[1][0].Rule != b {
+		t.Errorf("Group() = %+v", groups)
+	}
+	if got := Component("kube-proxy [0123456789ab]"); got != "kube-proxy" {
+		t.Errorf("Component() = %q", got)
+	}
+}
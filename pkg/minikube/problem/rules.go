@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package problem
+
+import "k8s.io/minikube/pkg/minikube/reason"
+
+// builtinRules are the problems known to minikube, the first matching rule wins so specific rules come first.
+// Their advice, documentation and issues are the ones of their kind in the reason catalog.
+var builtinRules = []Rule{
+	{
+		ID:                   reason.KubernetesNetworkPluginRemoved.ID,
+		Regexp:               `unknown flag: --(network-plugin|cni-bin-dir|cni-conf-dir)`,
+		Component:            "kubelet",
+		MinKubernetesVersion: "1.24.0",
+	},
+	{
+		ID:     reason.KubernetesUnknownFlag.ID,
+		Regexp: `unknown flag: --`,
+	},
+	{
+		ID:     reason.RsrcPodEviction.ID,
+		Regexp: `eviction manager: pods.* evicted|eviction manager:.*evicted|unable to evict any pods|eviction manager: unexpected error`,
+	},
+	{
+		ID:     reason.KubernetesNoProviders.ID,
+		Regexp: `forbidden.*no providers available`,
+	},
+	{
+		ID:     reason.KubernetesBadCertificate.ID,
+		Regexp: `tls: bad certificate`,
+	},
+	{
+		ID:     reason.KubeletNoAPIServer.ID,
+		Regexp: `kubelet.*no API client|kubelet.*No api server|STDIN.*127.0.0.1:8080`,
+	},
+	{
+		ID:     reason.GuestPortInUse.ID,
+		Regexp: `failed to create listener|address already in use`,
+	},
+	{
+		ID:     reason.KubernetesAnonymousAuth.ID,
+		Regexp: `Resetting AnonymousAuth to false`,
+	},
+	{
+		ID:     reason.KubeletForbidden.ID,
+		Regexp: `Unable to register node.*forbidden|Failed to initialize CSINodeInfo.*forbidden|kubelet.*forbidden.*cannot \w+ resource|leases.*forbidden.*cannot \w+ resource`,
+	},
+	{
+		ID:     reason.KubernetesPodAdmission.ID,
+		Regexp: `Failed to admit pod`,
+	},
+	{
+		ID:     reason.KubernetesContainerStart.ID,
+		Regexp: `failed to "StartContainer"`,
+	},
+	{
+		ID:     reason.KubeletContainerManager.ID,
+		Regexp: `Failed to start ContainerManager`,
+	},
+	{
+		ID:     reason.RuntimeDaemonStart.ID,
+		Regexp: `failed to start daemon`,
+	},
+	{
+		ID:     reason.KubernetesError.ID,
+		Regexp: `^error: `,
+		Ignore: `error: no objects passed to apply`,
+	},
+	{
+		ID:        reason.RsrcNodePressure.ID,
+		Regexp:    `"(MemoryPressure|DiskPressure|PIDPressure)"`,
+		Component: NodeComponent,
+	},
+	{
+		ID:        reason.KubernetesNodeNetworkUnavailable.ID,
+		Regexp:    `"NetworkUnavailable"`,
+		Component: NodeComponent,
+	},
+}
@@ -977,12 +977,7 @@ var guestIssues = []match{
 		GOOS:   []string{"linux"},
 	},
 	{
-		Kind: Kind{
-			ID:       "GUEST_PORT_IN_USE",
-			ExitCode: ExGuestConflict,
-			Advice:   "kubeadm detected a TCP port conflict with another process: probably another local Kubernetes installation. Run lsof -p<port> to find the process and kill it",
-			Issues:   []int{5484},
-		},
+		Kind:   GuestPortInUse,
 		Regexp: re(`ERROR Port-.*is in use`),
 		GOOS:   []string{"linux"},
 	},
@@ -50,6 +50,41 @@ func knownIssues() []match {
 	return ps
 }
 
+// problems are the kinds of the problems found in the logs and node state of a running cluster
+var problems = []Kind{
+	KubernetesNetworkPluginRemoved,
+	KubernetesUnknownFlag,
+	KubernetesNoProviders,
+	KubernetesBadCertificate,
+	KubernetesAnonymousAuth,
+	KubernetesPodAdmission,
+	KubernetesContainerStart,
+	KubernetesError,
+	KubernetesNodeNetworkUnavailable,
+	KubeletNoAPIServer,
+	KubeletForbidden,
+	KubeletContainerManager,
+	RsrcPodEviction,
+	RsrcNodePressure,
+	RuntimeDaemonStart,
+	GuestPortInUse,
+}
+
+// Lookup returns the kind of a problem or known issue by its ID
+func Lookup(id string) (Kind, bool) {
+	for _, k := range problems {
+		if k.ID == id {
+			return k, true
+		}
+	}
+	for _, ki := range knownIssues() {
+		if ki.ID == id {
+			return ki.Kind, true
+		}
+	}
+	return Kind{}, false
+}
+
 // MatchKnownIssue returns a known issue from an error on an OS
 func MatchKnownIssue(r Kind, err error, goos string) *Kind {
 	// The kind passed in has specified that it should not be rematched
@@ -89,6 +89,8 @@ var (
 	InternalAddonEnablePaused = Kind{ID: "MK_ADDON_ENABLE_PAUSED", ExitCode: ExProgramConflict}
 	// minikube could not disable an addon on a paused cluster
 	InternalAddonDisablePaused = Kind{ID: "MK_ADDON_DISABLE_PAUSED", ExitCode: ExProgramConflict}
+	// minikube could not install or uninstall an addon plugin
+	InternalAddonInstall = Kind{ID: "MK_ADDON_INSTALL", ExitCode: ExProgramError}
 
 	// minikube failed to update internal configuration, such as the cached images config map
 	InternalAddConfig = Kind{ID: "MK_ADD_CONFIG", ExitCode: ExProgramError}
@@ -288,6 +290,12 @@ var (
 	HostPurge = Kind{ID: "HOST_PURGE", ExitCode: ExHostError}
 	// minikube failed to persist profile config
 	HostSaveProfile = Kind{ID: "HOST_SAVE_PROFILE", ExitCode: ExHostConfig}
+	// minikube failed to read or export the audit log
+	HostAuditLog = Kind{ID: "HOST_AUDIT_LOG", ExitCode: ExHostConfig}
+	// minikube failed to write the bug report
+	HostBugReport = Kind{ID: "HOST_BUGREPORT", ExitCode: ExHostError}
+	// minikube failed to serve the metrics of the profiles
+	HostMetricsServe = Kind{ID: "HOST_METRICS_SERVE", ExitCode: ExHostConflict}
 	// Host doesn't support 9p
 	HostUnsupported = Kind{ID: "HOST_UNSUPPORTED", ExitCode: ExHostUnsupported}
 
@@ -366,6 +374,8 @@ var (
 	}
 	// minkube failed to update a mount
 	GuestMountConflict = Kind{ID: "GUEST_MOUNT_CONFLICT", ExitCode: ExGuestConflict}
+	// minikube failed to inject or clear network faults between cluster nodes
+	GuestNetworkChaos = Kind{ID: "GUEST_NETWORK_CHAOS", ExitCode: ExGuestError}
 	// minikube failed to add a node to the cluster
 	GuestNodeAdd = Kind{ID: "GUEST_NODE_ADD", ExitCode: ExGuestError}
 	// minikube failed to remove a node from the cluster
@@ -384,12 +394,16 @@ var (
 	GuestProvision = Kind{ID: "GUEST_PROVISION", ExitCode: ExGuestError}
 	// docker container exited prematurely during provisioning
 	GuestProvisionContainerExited = Kind{ID: "GUEST_PROVISION_CONTAINER_EXITED", ExitCode: ExGuestError}
+	// minikube failed to resume a suspended node
+	GuestResume = Kind{ID: "GUEST_RESUME", ExitCode: ExGuestError}
 	// minikube failed to start a node with current driver
 	GuestStart = Kind{ID: "GUEST_START", ExitCode: ExGuestError}
 	// minikube failed to get docker machine status
 	GuestStatus = Kind{ID: "GUEST_STATUS", ExitCode: ExGuestError}
 	// stopping the cluster process timed out
 	GuestStopTimeout = Kind{ID: "GUEST_STOP_TIMEOUT", ExitCode: ExGuestTimeout}
+	// minikube failed to suspend a node
+	GuestSuspend = Kind{ID: "GUEST_SUSPEND", ExitCode: ExGuestError}
 	// minikube failed to unpause the cluster process
 	GuestUnpause = Kind{ID: "GUEST_UNPAUSE", ExitCode: ExGuestError}
 	// minikube failed to check if Kubernetes containers are paused
@@ -505,6 +519,110 @@ var (
 		Style: style.SeeNoEvil,
 	}
 
+	// the dockershim network plugin flags were passed to a kubelet that no longer supports them
+	KubernetesNetworkPluginRemoved = Kind{
+		ID:       "K8S_NETWORK_PLUGIN_REMOVED",
+		ExitCode: ExControlPlaneUnsupported,
+		Advice:   translate.T("The dockershim network plugin flags were removed from the kubelet in Kubernetes v1.24, remove the kubelet.network-plugin, kubelet.cni-bin-dir and kubelet.cni-conf-dir --extra-config values"),
+		URL:      "https://kubernetes.io/blog/2022/02/17/dockershim-faq/",
+	}
+	// a Kubernetes component was started with a flag it does not support
+	KubernetesUnknownFlag = Kind{
+		ID:       "K8S_UNKNOWN_FLAG",
+		ExitCode: ExControlPlaneConfig,
+		Advice:   translate.T("A component was started with a flag it does not support in this Kubernetes version, check the --extra-config values passed to minikube start"),
+		URL:      "https://minikube.sigs.k8s.io/docs/handbook/config/#modifying-kubernetes-defaults",
+		Issues:   []int{3655},
+	}
+	// the API server rejected a pod because no admission provider could validate it
+	KubernetesNoProviders = Kind{
+		ID:       "K8S_NO_PROVIDERS",
+		ExitCode: ExControlPlaneConfig,
+		Advice:   translate.T("The API server rejected a pod because no admission provider could validate it, check the --extra-config admission values passed to minikube start"),
+		Issues:   []int{3818},
+	}
+	// a Kubernetes component uses a certificate the cluster does not trust
+	KubernetesBadCertificate = Kind{
+		ID:       "K8S_BAD_CERTIFICATE",
+		ExitCode: ExControlPlaneConfig,
+		Advice:   translate.T("A component uses a certificate the cluster does not trust, recreate the certificates with 'minikube delete' followed by 'minikube start'"),
+		Issues:   []int{4251},
+	}
+	// anonymous authentication was disabled because of the AlwaysAllow authorizer
+	KubernetesAnonymousAuth = Kind{
+		ID:       "K8S_ANONYMOUS_AUTH",
+		ExitCode: ExControlPlaneConfig,
+		Advice:   translate.T("Anonymous authentication is not allowed with the AlwaysAllow authorizer, choose a different --extra-config=apiserver.authorization-mode"),
+	}
+	// the node conditions prevent the kubelet from admitting pods
+	KubernetesPodAdmission = Kind{
+		ID:       "K8S_POD_ADMISSION",
+		ExitCode: ExControlPlaneUnavailable,
+		Advice:   translate.T("The node conditions prevent pods from being scheduled, check them with 'kubectl describe nodes' and free up node resources"),
+		Issues:   []int{7073},
+	}
+	// a container keeps failing to start
+	KubernetesContainerStart = Kind{
+		ID:       "K8S_CONTAINER_START",
+		ExitCode: ExControlPlaneError,
+		Advice:   translate.T("A container keeps failing to start, check its logs with 'minikube logs --follow --component <pod name>'"),
+	}
+	// a Kubernetes component logged an error
+	KubernetesError = Kind{ID: "K8S_ERROR", ExitCode: ExControlPlaneError}
+	// the network of a node is not configured
+	KubernetesNodeNetworkUnavailable = Kind{
+		ID:       "K8S_NODE_NETWORK_UNAVAILABLE",
+		ExitCode: ExControlPlaneUnavailable,
+		Advice:   translate.T("The network of the node is not configured, check the CNI logs with 'minikube logs --follow --component kindnet'"),
+	}
+	// the kubelet has no API server to connect to
+	KubeletNoAPIServer = Kind{
+		ID:       "K8S_KUBELET_NO_APISERVER",
+		ExitCode: ExControlPlaneUnavailable,
+		Advice:   translate.T("The kubelet has no API server to connect to, check the API server logs with 'minikube logs --follow --component kube-apiserver'"),
+	}
+	// the kubelet is not authorized by the API server
+	KubeletForbidden = Kind{
+		ID:       "K8S_KUBELET_FORBIDDEN",
+		ExitCode: ExControlPlaneConfig,
+		Advice:   translate.T("The kubelet is not authorized by the API server, its credentials may not match the cluster. Recreate the cluster with 'minikube delete' followed by 'minikube start'"),
+	}
+	// the kubelet could not set up its cgroups
+	KubeletContainerManager = Kind{
+		ID:       "K8S_KUBELET_CONTAINER_MANAGER",
+		ExitCode: ExControlPlaneConfig,
+		Advice:   translate.T("The kubelet could not set up its cgroups, try passing --extra-config=kubelet.cgroup-driver=systemd to minikube start"),
+		URL:      "https://minikube.sigs.k8s.io/docs/handbook/config/#modifying-kubernetes-defaults",
+		Issues:   []int{4172},
+	}
+	// the node is running out of memory or disk space and evicts pods
+	RsrcPodEviction = Kind{
+		ID:       "RSRC_POD_EVICTION",
+		ExitCode: ExResourceError,
+		Advice:   translate.T("The node is running out of memory or disk space and evicts pods, free up space with 'minikube ssh -- docker system prune', or recreate the cluster with larger --memory and --disk-size values"),
+		Issues:   []int{3611, 5355},
+	}
+	// the node has a memory, disk or PID pressure condition
+	RsrcNodePressure = Kind{
+		ID:       "RSRC_NODE_PRESSURE",
+		ExitCode: ExResourceError,
+		Advice:   translate.T("The node is running out of resources, free up memory and disk space or recreate the cluster with larger --memory and --disk-size values"),
+		URL:      "https://kubernetes.io/docs/concepts/scheduling-eviction/node-pressure-eviction/",
+	}
+	// the container runtime daemon failed to start
+	RuntimeDaemonStart = Kind{
+		ID:       "RT_DAEMON_START",
+		ExitCode: ExRuntimeNotRunning,
+		Advice:   translate.T("The container runtime failed to start, check its logs with 'minikube logs --follow --component runtime'"),
+	}
+	// a port needed by Kubernetes is used by another process
+	GuestPortInUse = Kind{
+		ID:       "GUEST_PORT_IN_USE",
+		ExitCode: ExGuestConflict,
+		Advice:   translate.T("A port needed by Kubernetes is used by another process: probably another local Kubernetes installation. Run lsof -p<port> to find the process and kill it"),
+		Issues:   []int{5484},
+	}
+
 	NotFoundCriDockerd = Kind{
 		ID:       "NOT_FOUND_CRI_DOCKERD",
 		ExitCode: ExProgramNotFound,
@@ -90,6 +90,8 @@ func configure(cc config.ClusterConfig, n config.Node) (interface{}, error) {
 		Network:           cc.Network,
 		Subnet:            cc.Subnet,
 		StaticIP:          cc.StaticIP,
+		IPv6:              config.HasIPv6(cc),
+		IPv6Subnet:        cc.IPv6Subnet,
 		ListenAddress:     cc.ListenAddress,
 		GPUs:              cc.GPUs,
 	}), nil
@@ -97,6 +97,8 @@ func configure(cc config.ClusterConfig, n config.Node) (interface{}, error) {
 		ExtraArgs:         extraArgs,
 		ListenAddress:     cc.ListenAddress,
 		Subnet:            cc.Subnet,
+		IPv6:              config.HasIPv6(cc),
+		IPv6Subnet:        cc.IPv6Subnet,
 	}), nil
 }
 
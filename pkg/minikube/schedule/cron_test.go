@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronErrors(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"0 19 * *",
+		"0 19 * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * * Funday",
+		"@sometimes",
+	} {
+		if _, err := ParseCron(spec); err == nil {
+			t.Errorf("ParseCron(%q) expected an error", spec)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	loc := time.UTC
+	// Monday
+	monday := time.Date(2024, 6, 3, 12, 0, 30, 0, loc)
+	tests := []struct {
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		{"0 19 * * Mon-Fri", monday, time.Date(2024, 6, 3, 19, 0, 0, 0, loc)},
+		// friday evening to monday morning
+		{"30 8 * * 1-5", time.Date(2024, 6, 7, 19, 0, 0, 0, loc), time.Date(2024, 6, 10, 8, 30, 0, 0, loc)},
+		// strictly after the given time
+		{"0 19 * * *", time.Date(2024, 6, 3, 19, 0, 0, 0, loc), time.Date(2024, 6, 4, 19, 0, 0, 0, loc)},
+		{"*/15 * * * *", monday, time.Date(2024, 6, 3, 12, 15, 0, 0, loc)},
+		{"10-50/20 * * * *", monday, time.Date(2024, 6, 3, 12, 10, 0, 0, loc)},
+		{"@daily", monday, time.Date(2024, 6, 4, 0, 0, 0, 0, loc)},
+		{"@hourly", monday, time.Date(2024, 6, 3, 13, 0, 0, 0, loc)},
+		{"0 0 1 jan *", monday, time.Date(2025, 1, 1, 0, 0, 0, 0, loc)},
+		// 7 is sunday too
+		{"0 9 * * 7", monday, time.Date(2024, 6, 9, 9, 0, 0, 0, loc)},
+		// with both restricted, either the day of month or the day of week matches
+		{"0 0 15 * Wed", monday, time.Date(2024, 6, 5, 0, 0, 0, 0, loc)},
+		{"0 0 29 2 *", monday, time.Date(2028, 2, 29, 0, 0, 0, 0, loc)},
+		{"0 0 30 2 *", monday, time.Time{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.spec, func(t *testing.T) {
+			c, err := ParseCron(tc.spec)
+			if err != nil {
+				t.Fatalf("ParseCron(%q): %v", tc.spec, err)
+			}
+			if got := c.Next(tc.from); !got.Equal(tc.want) {
+				t.Errorf("Next(%s) = %s, want %s", tc.from, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCronNextDST(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data not available: %v", err)
+	}
+	c, err := ParseCron("30 8 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the clocks go forward on the night of March 31st 2024, the run stays at 08:30 local time
+	got := c.Next(time.Date(2024, 3, 30, 9, 0, 0, 0, loc))
+	if want := time.Date(2024, 3, 31, 8, 30, 0, 0, loc); !got.Equal(want) {
+		t.Errorf("Next = %s, want %s", got, want)
+	}
+}
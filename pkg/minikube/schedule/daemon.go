@@ -0,0 +1,324 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/docker/machine/libmachine/state"
+	"github.com/mitchellh/go-ps"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/journal"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/machine"
+)
+
+// checkInterval is the longest the daemon sleeps, so that it measures the activity of idle schedules regularly
+var checkInterval = time.Minute
+
+// wakeInterval is how often the sleeping daemon checks whether it was woken up by EnsureDaemon
+var wakeInterval = time.Second
+
+// DaemonPID returns the pid of the running schedule daemon, and false if it is not running
+func DaemonPID() (int, bool) {
+	bs, err := os.ReadFile(localpath.SchedulerPID())
+	if err != nil {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(bs)))
+	if err != nil {
+		klog.Warningf("invalid pid in %s: %v", localpath.SchedulerPID(), err)
+		return 0, false
+	}
+	p, err := ps.FindProcess(pid)
+	if err != nil || p == nil {
+		return 0, false
+	}
+	// the pid may have been reused by another process since the daemon exited
+	if !strings.Contains(p.Executable(), "minikube") {
+		return 0, false
+	}
+	return pid, true
+}
+
+// EnsureDaemon starts the schedule daemon in the background, or wakes it up if it is already running,
+// so that it runs the schedules added since it went to sleep on time
+func EnsureDaemon() error {
+	if pid, ok := DaemonPID(); ok {
+		klog.Infof("schedule daemon is already running with pid %d, waking it up", pid)
+		return wake()
+	}
+	logPath := localpath.SchedulerLog()
+	if err := os.MkdirAll(filepath.Dir(logPath), 0o755); err != nil {
+		return errors.Wrap(err, "creating log directory")
+	}
+	logFile, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return errors.Wrapf(err, "opening %s", logPath)
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command(os.Args[0], "schedule", "daemon")
+	cmd.Env = append(os.Environ(), constants.IsMinikubeChildProcess+"=true")
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	detach(cmd)
+	if err := cmd.Start(); err != nil {
+		return errors.Wrap(err, "starting schedule daemon")
+	}
+	klog.Infof("started schedule daemon with pid %d, logging to %s", cmd.Process.Pid, logPath)
+	return writeDaemonPID(cmd.Process.Pid)
+}
+
+// StopDaemon kills the schedule daemon, if it is running
+func StopDaemon() error {
+	pid, ok := DaemonPID()
+	if !ok {
+		return nil
+	}
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return errors.Wrapf(err, "finding process %d", pid)
+	}
+	klog.Infof("killing schedule daemon with pid %d", pid)
+	if err := p.Kill(); err != nil {
+		return errors.Wrapf(err, "killing %d", pid)
+	}
+	return os.Remove(localpath.SchedulerPID())
+}
+
+func writeDaemonPID(pid int) error {
+	return os.WriteFile(localpath.SchedulerPID(), []byte(strconv.Itoa(pid)), 0o600)
+}
+
+// RunDaemon runs the schedules of all profiles, until none is left
+func RunDaemon() error {
+	if pid, ok := DaemonPID(); ok && pid != os.Getpid() {
+		klog.Infof("schedule daemon is already running with pid %d, exiting", pid)
+		return nil
+	}
+	if err := writeDaemonPID(os.Getpid()); err != nil {
+		return errors.Wrap(err, "writing pid file")
+	}
+	defer func() {
+		if pid, ok := DaemonPID(); ok && pid == os.Getpid() {
+			if err := os.Remove(localpath.SchedulerPID()); err != nil {
+				klog.Warningf("unable to remove %s: %v", localpath.SchedulerPID(), err)
+			}
+		}
+	}()
+
+	api, err := machine.NewAPIClient()
+	if err != nil {
+		return errors.Wrap(err, "api client")
+	}
+	defer api.Close()
+
+	last := time.Now()
+	for {
+		// read before the schedules, so that a wake up while they are run is not missed
+		woken := wakeToken()
+		profiles, err := profileSchedules()
+		if err != nil {
+			return errors.Wrap(err, "listing profiles")
+		}
+		if len(profiles) == 0 {
+			klog.Infof("no schedules left, exiting")
+			return nil
+		}
+
+		now := time.Now()
+		var due []Run
+		for _, cc := range profiles {
+			due = append(due, Due(cc.Name, cc.Schedules, last, now)...)
+			due = append(due, idleDue(api, cc, now)...)
+		}
+		sort.SliceStable(due, func(i, j int) bool { return due[i].Time.Before(due[j].Time) })
+		for _, r := range due {
+			runScheduled(r)
+		}
+		// runs which came due while operations were running are picked up by the next iteration
+		last = now
+
+		sleep(sleepUntilNext(profiles, time.Now()), woken)
+	}
+}
+
+// wake wakes the running daemon up, by writing a new token to its wake file
+func wake() error {
+	return os.WriteFile(localpath.SchedulerWake(), []byte(strconv.FormatInt(time.Now().UnixNano(), 10)), 0o600)
+}
+
+// wakeToken returns the token of the wake file, which changes whenever the daemon is woken up
+func wakeToken() string {
+	bs, err := os.ReadFile(localpath.SchedulerWake())
+	if err != nil {
+		return ""
+	}
+	return string(bs)
+}
+
+// sleep sleeps for d, or until the wake token differs from woken
+func sleep(d time.Duration, woken string) {
+	deadline := time.Now().Add(d)
+	for {
+		left := time.Until(deadline)
+		if left <= 0 {
+			return
+		}
+		if left > wakeInterval {
+			left = wakeInterval
+		}
+		time.Sleep(left)
+		if wakeToken() != woken {
+			klog.Infof("woken up, the schedules changed")
+			return
+		}
+	}
+}
+
+// profileSchedules returns the profiles which have schedules
+func profileSchedules() ([]*config.ClusterConfig, error) {
+	profiles, err := config.ListValidProfiles()
+	if err != nil {
+		return nil, err
+	}
+	var scheduled []*config.ClusterConfig
+	for _, p := range profiles {
+		if p.Config != nil && len(p.Config.Schedules) > 0 {
+			scheduled = append(scheduled, p.Config)
+		}
+	}
+	return scheduled, nil
+}
+
+// idleDue measures the client activity of a running profile with idle schedules, and returns those which are due
+func idleDue(api libmachine.API, cc *config.ClusterConfig, now time.Time) []Run {
+	var idle []config.Schedule
+	for _, s := range cc.Schedules {
+		if s.Idle != 0 {
+			idle = append(idle, s)
+		}
+	}
+	if len(idle) == 0 {
+		return nil
+	}
+	cp, err := config.ControlPlane(*cc)
+	if err != nil {
+		klog.Warningf("unable to get the control plane of %s: %v", cc.Name, err)
+		return nil
+	}
+	if st, err := machine.Status(api, config.MachineName(*cc, cp)); err != nil || st != state.Running.String() {
+		return nil
+	}
+	st, err := measureActivity(api, cc, now)
+	if err != nil {
+		klog.Warningf("unable to measure the activity of %s: %v", cc.Name, err)
+		return nil
+	}
+
+	var due []Run
+	for _, s := range idle {
+		if d := st.Idle(now); d >= s.Idle {
+			due = append(due, Run{Profile: cc.Name, Schedule: s, Time: now, Reason: fmt.Sprintf("idle for %s", d.Round(time.Minute))})
+		}
+	}
+	return due
+}
+
+// sleepUntilNext returns how long to sleep until the next run, at most checkInterval
+func sleepUntilNext(profiles []*config.ClusterConfig, now time.Time) time.Duration {
+	d := checkInterval
+	for _, cc := range profiles {
+		for _, r := range NextRuns(cc.Name, cc.Schedules, now) {
+			if until := r.Time.Sub(now); until < d {
+				d = until
+			}
+		}
+	}
+	if d < time.Second {
+		d = time.Second
+	}
+	return d
+}
+
+// commands returns the minikube commands which run a scheduled operation
+func commands(op string) []string {
+	// restart does not take a snapshot of the cluster, it is a plain stop and start
+	if op == "restart" {
+		return []string{"stop", "start"}
+	}
+	return []string{op}
+}
+
+// runScheduled runs a scheduled operation on its profile and records the result in the journal of the profile.
+// The operation is run as the "schedule:<name>" user, which the audit log records.
+func runScheduled(r Run) {
+	data := map[string]string{"schedule": r.Schedule.Name, "operation": r.Schedule.Operation, "cron": r.Schedule.Cron}
+	what := fmt.Sprintf("scheduled %s (%s)", r.Schedule.Operation, r.Schedule.Name)
+	if r.Reason != "" {
+		data["reason"] = r.Reason
+		what = fmt.Sprintf("%s, %s,", what, r.Reason)
+	}
+	// a one-off schedule is removed before it runs, so that it runs at most once even if the operation fails
+	if r.Schedule.At != 0 {
+		if err := removeSchedule(r.Profile, r.Schedule.Name); err != nil {
+			klog.Errorf("unable to remove one-off schedule %q of profile %s: %v", r.Schedule.Name, r.Profile, err)
+			journal.Record(r.Profile, journal.ScheduleFailed, fmt.Sprintf("%s failed: %v", what, err), data)
+			return
+		}
+	}
+	for _, c := range commands(r.Schedule.Operation) {
+		klog.Infof("running scheduled %s of profile %s (schedule %q)", c, r.Profile, r.Schedule.Name)
+		cmd := exec.Command(os.Args[0], c, "--profile", r.Profile, "--user", "schedule:"+r.Schedule.Name)
+		output, err := cmd.CombinedOutput()
+		klog.Infof("output of scheduled %s of profile %s:\n%s", c, r.Profile, output)
+		if err != nil {
+			klog.Errorf("scheduled %s of profile %s failed: %v", c, r.Profile, err)
+			journal.Record(r.Profile, journal.ScheduleFailed, fmt.Sprintf("%s failed: %v", what, err), data)
+			return
+		}
+	}
+	journal.Record(r.Profile, journal.ScheduleRan, fmt.Sprintf("%s ran", what), data)
+}
+
+// removeSchedule removes a schedule from the config of a profile
+func removeSchedule(profile, name string) error {
+	cc, err := config.Load(profile)
+	if err != nil {
+		return errors.Wrapf(err, "loading profile %s", profile)
+	}
+	var kept []config.Schedule
+	for _, s := range cc.Schedules {
+		if s.Name != name {
+			kept = append(kept, s)
+		}
+	}
+	cc.Schedules = kept
+	return config.SaveProfile(profile, cc)
+}
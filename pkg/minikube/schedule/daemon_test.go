@@ -0,0 +1,52 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+func TestSleepWakesUp(t *testing.T) {
+	t.Setenv(localpath.MinikubeHome, t.TempDir())
+	defer func(d time.Duration) { wakeInterval = d }(wakeInterval)
+	wakeInterval = 10 * time.Millisecond
+
+	start := time.Now()
+	sleep(50*time.Millisecond, wakeToken())
+	if d := time.Since(start); d < 50*time.Millisecond {
+		t.Errorf("sleep returned after %s, expected 50ms", d)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		sleep(time.Minute, wakeToken())
+		close(done)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	if err := wake(); err != nil {
+		t.Fatalf("wake: %v", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("sleep was not woken up")
+	}
+}
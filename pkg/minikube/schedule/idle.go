@@ -0,0 +1,174 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/autopause"
+	"k8s.io/minikube/pkg/minikube/cluster"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/machine"
+)
+
+// apiClientsComment marks the iptables rule counting the packets the clients of the host send to the API server,
+// on new and established connections, so that clients which stay connected count as activity too
+const apiClientsComment = "minikube-api-traffic"
+
+// minIdle is the shortest idle duration of a schedule, the activity is only measured every checkInterval
+var minIdle = 5 * time.Minute
+
+// IdleState is the client activity of the API server of a profile, as last measured by the schedule daemon
+type IdleState struct {
+	// LastActivity is when a client of the host last sent traffic to the API server, or when the measurement began
+	LastActivity time.Time
+	// Packets is the count of packets of the clients of the host, at the last measurement
+	Packets uint64
+	// Measured is when the activity was last measured
+	Measured time.Time
+}
+
+// Idle returns how long the API server had no client activity at now
+func (s IdleState) Idle(now time.Time) time.Duration {
+	return now.Sub(s.LastActivity)
+}
+
+// Current returns whether the state is still measured, which it is not once the profile stopped or the daemon exited
+func (s IdleState) Current(now time.Time) bool {
+	return now.Sub(s.Measured) <= 3*checkInterval
+}
+
+// ReadIdleState returns the idle state of a profile, and false if it was never measured
+func ReadIdleState(profile string) (IdleState, bool) {
+	var s IdleState
+	bs, err := os.ReadFile(localpath.IdleState(profile))
+	if err != nil {
+		return s, false
+	}
+	if err := json.Unmarshal(bs, &s); err != nil {
+		return s, false
+	}
+	return s, true
+}
+
+func writeIdleState(profile string, s IdleState) error {
+	bs, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(localpath.IdleState(profile), bs, 0o644)
+}
+
+// measureActivity measures the client activity of the API server of a running profile, and saves its idle state.
+// Clients of the host connect to the API server from the IP of the host as seen from the node, which tells them
+// apart from the kubelets, controllers and pods. The packets of their new and established connections are counted
+// by an iptables rule on the control plane, so that long-lived clients like "kubectl get -w", port forwards and
+// dashboards keep the cluster active as long as they are connected, through their keepalives.
+// Requests proxied by the auto-pause addon are taken from its state.
+func measureActivity(api libmachine.API, cc *config.ClusterConfig, now time.Time) (IdleState, error) {
+	cp, err := config.ControlPlane(*cc)
+	if err != nil {
+		return IdleState{}, err
+	}
+	h, err := machine.LoadHost(api, config.MachineName(*cc, cp))
+	if err != nil {
+		return IdleState{}, errors.Wrap(err, "load host")
+	}
+	r, err := machine.CommandRunner(h)
+	if err != nil {
+		return IdleState{}, errors.Wrap(err, "command runner")
+	}
+	hostIP, err := cluster.HostIP(h, cc.Name)
+	if err != nil {
+		return IdleState{}, errors.Wrap(err, "host ip")
+	}
+	port := cp.Port
+	if port == 0 {
+		port = constants.APIServerPort
+	}
+
+	n, found, err := apiClientPackets(r, hostIP)
+	if err != nil {
+		return IdleState{}, err
+	}
+	if !found {
+		if err := addAPIClientsCounter(r, hostIP, port); err != nil {
+			return IdleState{}, err
+		}
+	}
+
+	prev, ok := ReadIdleState(cc.Name)
+	s := IdleState{LastActivity: prev.LastActivity, Packets: n, Measured: now}
+	// start over when the profile (re)started or the daemon was not running, the counter is reset when the node restarts
+	if !ok || !found || !prev.Current(now) || n != prev.Packets {
+		s.LastActivity = now
+	}
+	if as, err := autopause.ReadState(r); err == nil && as.LastActivity.After(s.LastActivity) {
+		s.LastActivity = as.LastActivity
+	}
+	return s, writeIdleState(cc.Name, s)
+}
+
+// apiClientPackets returns the count of packets of the host to the API server, and false if they are not counted yet
+func apiClientPackets(r command.Runner, hostIP net.IP) (uint64, bool, error) {
+	rr, err := r.RunCmd(exec.Command("sudo", "iptables-save", "-c", "-t", "filter"))
+	if err != nil {
+		return 0, false, errors.Wrap(err, "iptables-save")
+	}
+	n, found := parseAPIClientPackets(rr.Stdout.String(), hostIP)
+	return n, found, nil
+}
+
+// parseAPIClientPackets returns the packet count of the counting rule of the host, like
+// "[12:720] -A INPUT -s 192.168.49.1/32 -p tcp -m tcp --dport 8443 -m conntrack --ctstate NEW,ESTABLISHED -m comment --comment minikube-api-traffic"
+func parseAPIClientPackets(iptablesSave string, hostIP net.IP) (uint64, bool) {
+	for _, line := range strings.Split(iptablesSave, "\n") {
+		if !strings.HasPrefix(line, "[") || !strings.HasSuffix(line, "--comment "+apiClientsComment) || !strings.Contains(line, " -s "+hostIP.String()+"/") {
+			continue
+		}
+		counters, _, _ := strings.Cut(strings.TrimPrefix(line, "["), "]")
+		packets, _, _ := strings.Cut(counters, ":")
+		n, err := strconv.ParseUint(packets, 10, 64)
+		if err != nil {
+			continue
+		}
+		return n, true
+	}
+	return 0, false
+}
+
+// addAPIClientsCounter adds a rule without target, which only counts the packets of the connections of the host to the API server
+func addAPIClientsCounter(r command.Runner, hostIP net.IP, port int) error {
+	c := exec.Command("sudo", "iptables", "-w", "-I", "INPUT", "-s", hostIP.String(), "-p", "tcp", "--dport", fmt.Sprint(port),
+		"-m", "conntrack", "--ctstate", "NEW,ESTABLISHED", "-m", "comment", "--comment", apiClientsComment)
+	if _, err := r.RunCmd(c); err != nil {
+		return errors.Wrap(err, "adding iptables rule")
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestParseAPIClientPackets(t *testing.T) {
+	save := `# Generated by iptables-save v1.8.4
+*filter
+:INPUT ACCEPT [2065:1310528]
+[3:180] -A INPUT -s 192.168.58.1/32 -p tcp -m tcp --dport 8443 -m conntrack --ctstate NEW,ESTABLISHED -m comment --comment minikube-api-traffic
+[5:300] -A INPUT -s 192.168.49.1/32 -p tcp -m tcp --dport 8443 -m conntrack --ctstate NEW -m comment --comment minikube-api-clients
+[12:720] -A INPUT -s 192.168.49.1/32 -p tcp -m tcp --dport 8443 -m conntrack --ctstate NEW,ESTABLISHED -m comment --comment minikube-api-traffic
+[1992:1292080] -A INPUT -m comment --comment "kubernetes health check service ports" -j KUBE-NODEPORTS
+COMMIT
+`
+	n, found := parseAPIClientPackets(save, net.ParseIP("192.168.49.1"))
+	if !found || n != 12 {
+		t.Errorf("parseAPIClientPackets() = %d, %v, expected 12, true", n, found)
+	}
+	if _, found := parseAPIClientPackets(save, net.ParseIP("192.168.39.1")); found {
+		t.Error("parseAPIClientPackets() found a rule for another host IP")
+	}
+}
+
+func TestIdleState(t *testing.T) {
+	now := time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC)
+	s := IdleState{LastActivity: now.Add(-90 * time.Minute), Measured: now.Add(-time.Minute)}
+	if got := s.Idle(now); got != 90*time.Minute {
+		t.Errorf("Idle() = %s, expected 1h30m0s", got)
+	}
+	if !s.Current(now) {
+		t.Error("a state measured a minute ago is not current")
+	}
+	if s.Current(now.Add(time.Hour)) {
+		t.Error("a state measured an hour ago is current")
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+// Operations are the operations that can be scheduled on a profile
+var Operations = []string{"start", "stop", "pause", "unpause", "restart"}
+
+// Validate checks that a schedule has a supported operation and a valid cron expression, or idle duration
+func Validate(s config.Schedule) error {
+	if !validOperation(s.Operation) {
+		return fmt.Errorf("unsupported operation %q, must be one of %s", s.Operation, strings.Join(Operations, ", "))
+	}
+	if s.At != 0 {
+		if s.Cron != "" || s.Idle != 0 {
+			return fmt.Errorf("a one-off schedule runs at its time only, not at the times of a cron expression or when idle")
+		}
+		return nil
+	}
+	if s.Idle != 0 {
+		if s.Cron != "" {
+			return fmt.Errorf("a schedule runs either at the times of a cron expression or when idle, not both")
+		}
+		if s.Operation != "stop" {
+			return fmt.Errorf("only stop can be run when idle, not %s", s.Operation)
+		}
+		if s.Idle < minIdle {
+			return fmt.Errorf("idle duration %s is too short, the minimum is %s", s.Idle, minIdle)
+		}
+		return nil
+	}
+	_, err := ParseCron(s.Cron)
+	return err
+}
+
+func validOperation(op string) bool {
+	for _, o := range Operations {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// Run is a scheduled run of an operation on a profile
+type Run struct {
+	Profile  string
+	Schedule config.Schedule
+	Time     time.Time
+	// Reason is why a run which does not follow a cron expression is due, like "idle for 4h0m0s"
+	Reason string
+}
+
+// NextRuns returns the next run of each schedule of a profile after t, earliest first.
+// Schedules with an invalid cron expression or which never match, and one-off schedules due by t, are skipped.
+func NextRuns(profile string, schedules []config.Schedule, t time.Time) []Run {
+	var runs []Run
+	for _, s := range schedules {
+		if s.At != 0 {
+			if at := time.Unix(s.At, 0); at.After(t) {
+				runs = append(runs, Run{Profile: profile, Schedule: s, Time: at})
+			}
+			continue
+		}
+		c, err := ParseCron(s.Cron)
+		if err != nil {
+			continue
+		}
+		next := c.Next(t)
+		if next.IsZero() {
+			continue
+		}
+		runs = append(runs, Run{Profile: profile, Schedule: s, Time: next})
+	}
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].Time.Before(runs[j].Time) })
+	return runs
+}
+
+// NextRun returns the earliest run of the schedules of a cluster after t, and false if there is none
+func NextRun(cc *config.ClusterConfig, t time.Time) (Run, bool) {
+	if cc == nil {
+		return Run{}, false
+	}
+	runs := NextRuns(cc.Name, cc.Schedules, t)
+	if len(runs) == 0 {
+		return Run{}, false
+	}
+	return runs[0], true
+}
+
+// Due returns the runs of the schedules of a profile which were due in (since, until].
+// One-off schedules due by until are always returned, so that they run late rather than never when the daemon was not running.
+func Due(profile string, schedules []config.Schedule, since, until time.Time) []Run {
+	var runs []Run
+	for _, s := range schedules {
+		if at := time.Unix(s.At, 0); s.At != 0 && !at.After(since) {
+			runs = append(runs, Run{Profile: profile, Schedule: s, Time: at})
+		}
+	}
+	for _, r := range NextRuns(profile, schedules, since) {
+		if !r.Time.After(until) {
+			runs = append(runs, r)
+		}
+	}
+	return runs
+}
+
+// CancelStops removes the one-off stops from the schedules of a cluster, and returns whether there were any
+func CancelStops(cc *config.ClusterConfig) bool {
+	var kept []config.Schedule
+	for _, s := range cc.Schedules {
+		if s.At == 0 || s.Operation != "stop" {
+			kept = append(kept, s)
+		}
+	}
+	cancelled := len(kept) != len(cc.Schedules)
+	cc.Schedules = kept
+	return cancelled
+}
+
+// ClearOverdue removes the one-off schedules of a cluster which were due by t, like a stop which was missed while the schedule daemon was not running
+func ClearOverdue(cc *config.ClusterConfig, t time.Time) {
+	var kept []config.Schedule
+	for _, s := range cc.Schedules {
+		if s.At == 0 || time.Unix(s.At, 0).After(t) {
+			kept = append(kept, s)
+		}
+	}
+	cc.Schedules = kept
+}
+
+// Describe returns a human readable description of a run, like "stop at Mon 19:00 (in 2h3m)"
+func (r Run) Describe(now time.Time) string {
+	return fmt.Sprintf("%s at %s (in %s)", r.Schedule.Operation, r.Time.Format("Mon 15:04"), r.Time.Sub(now).Round(time.Minute))
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		s       config.Schedule
+		wantErr bool
+	}{
+		{config.Schedule{Operation: "stop", Cron: "0 19 * * Mon-Fri"}, false},
+		{config.Schedule{Operation: "restart", Cron: "@weekly"}, false},
+		{config.Schedule{Operation: "delete", Cron: "@weekly"}, true},
+		{config.Schedule{Operation: "start", Cron: "8:30"}, true},
+		{config.Schedule{Operation: "stop", Idle: 4 * time.Hour}, false},
+		{config.Schedule{Operation: "start", Idle: 4 * time.Hour}, true},
+		{config.Schedule{Operation: "stop", Idle: time.Minute}, true},
+		{config.Schedule{Operation: "stop", Cron: "@daily", Idle: 4 * time.Hour}, true},
+		{config.Schedule{Operation: "stop", At: 1717398000}, false},
+		{config.Schedule{Operation: "stop", Cron: "@daily", At: 1717398000}, true},
+	}
+	for _, tc := range tests {
+		if err := Validate(tc.s); (err != nil) != tc.wantErr {
+			t.Errorf("Validate(%+v) = %v, wantErr %v", tc.s, err, tc.wantErr)
+		}
+	}
+}
+
+func TestNextRunAndDue(t *testing.T) {
+	cc := &config.ClusterConfig{
+		Name: "p1",
+		Schedules: []config.Schedule{
+			{Name: "evening", Operation: "stop", Cron: "0 19 * * Mon-Fri"},
+			{Name: "morning", Operation: "start", Cron: "30 8 * * Mon-Fri"},
+			{Name: "broken", Operation: "stop", Cron: "not a cron"},
+			{Name: "idle", Operation: "stop", Idle: 4 * time.Hour},
+		},
+	}
+	// Monday at 07:00
+	now := time.Date(2024, 6, 3, 7, 0, 0, 0, time.UTC)
+
+	r, ok := NextRun(cc, now)
+	if !ok {
+		t.Fatal("NextRun found no run")
+	}
+	if r.Schedule.Name != "morning" || !r.Time.Equal(time.Date(2024, 6, 3, 8, 30, 0, 0, time.UTC)) {
+		t.Errorf("NextRun = %s at %s, want morning at 08:30", r.Schedule.Name, r.Time)
+	}
+	if got, want := r.Describe(now), "start at Mon 08:30 (in 1h30m0s)"; got != want {
+		t.Errorf("Describe = %q, want %q", got, want)
+	}
+
+	if _, ok := NextRun(&config.ClusterConfig{Name: "p2"}, now); ok {
+		t.Error("NextRun of a cluster without schedules found a run")
+	}
+
+	due := Due(cc.Name, cc.Schedules, now, now.Add(12*time.Hour))
+	if len(due) != 2 || due[0].Schedule.Name != "morning" || due[1].Schedule.Name != "evening" {
+		t.Errorf("Due = %+v, want morning then evening", due)
+	}
+	if due := Due(cc.Name, cc.Schedules, now, now.Add(time.Hour)); len(due) != 0 {
+		t.Errorf("Due = %+v, want none", due)
+	}
+}
+
+func TestOneOff(t *testing.T) {
+	// Monday at 07:00
+	now := time.Date(2024, 6, 3, 7, 0, 0, 0, time.UTC)
+	cc := &config.ClusterConfig{
+		Name: "p1",
+		Schedules: []config.Schedule{
+			{Name: "evening", Operation: "stop", Cron: "0 19 * * Mon-Fri"},
+			{Name: "once-stop-1", Operation: "stop", At: now.Add(5 * time.Minute).Unix()},
+			{Name: "missed", Operation: "stop", At: now.Add(-time.Hour).Unix()},
+		},
+	}
+
+	r, ok := NextRun(cc, now)
+	if !ok || r.Schedule.Name != "once-stop-1" {
+		t.Errorf("NextRun = %+v, want once-stop-1", r)
+	}
+	if at, ok := config.ScheduledStop(*cc); !ok || !at.Equal(now.Add(-time.Hour)) {
+		t.Errorf("ScheduledStop = %s, want %s", at, now.Add(-time.Hour))
+	}
+
+	// a one-off schedule missed while the daemon was not running is due late
+	due := Due(cc.Name, cc.Schedules, now, now.Add(time.Minute))
+	if len(due) != 1 || due[0].Schedule.Name != "missed" {
+		t.Errorf("Due = %+v, want missed", due)
+	}
+	due = Due(cc.Name, cc.Schedules, now, now.Add(10*time.Minute))
+	if len(due) != 2 || due[0].Schedule.Name != "missed" || due[1].Schedule.Name != "once-stop-1" {
+		t.Errorf("Due = %+v, want missed then once-stop-1", due)
+	}
+
+	ClearOverdue(cc, now)
+	if len(cc.Schedules) != 2 {
+		t.Errorf("ClearOverdue left %+v, want evening and once-stop-1", cc.Schedules)
+	}
+	if !CancelStops(cc) || len(cc.Schedules) != 1 || cc.Schedules[0].Name != "evening" {
+		t.Errorf("CancelStops left %+v, want evening", cc.Schedules)
+	}
+	if CancelStops(cc) {
+		t.Error("CancelStops cancelled a stop twice")
+	}
+}
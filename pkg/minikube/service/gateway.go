@@ -0,0 +1,220 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/minikube/pkg/minikube/tunnel"
+)
+
+// GatewayURL represents the URLs of a route attached to a gateway for one of its hostnames
+type GatewayURL struct {
+	Namespace string
+	Route     string
+	Hostname  string
+	Address   string
+	URLs      []string
+}
+
+// GatewayURLs represents a list of GatewayURL
+type GatewayURLs []GatewayURL
+
+// GatewayNotFoundError error type handles 'gateway not found' scenarios
+type GatewayNotFoundError struct {
+	Err error
+}
+
+// Error method for GatewayNotFoundError type
+func (t GatewayNotFoundError) Error() string {
+	return "Gateway not found: " + t.Err.Error()
+}
+
+// GatewayNoAddressError is returned when the gateway has no address assigned yet
+type GatewayNoAddressError struct {
+	Gateway string
+}
+
+// Error method for GatewayNoAddressError type
+func (t GatewayNoAddressError) Error() string {
+	return fmt.Sprintf("gateway %s has no address assigned", t.Gateway)
+}
+
+// gatewayListener is the part of a Gateway listener needed to build URLs
+type gatewayListener struct {
+	name     string
+	hostname string
+	port     int64
+	protocol string
+}
+
+// GetGatewayURLs returns the URLs of all HTTPRoutes attached to a gateway, one entry per route hostname
+func GetGatewayURLs(client dynamic.Interface, namespace, name string) (GatewayURLs, error) {
+	gw, err := client.Resource(tunnel.GatewayGVR).Namespace(namespace).Get(context.Background(), name, meta.GetOptions{})
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			return nil, &GatewayNotFoundError{Err: err}
+		}
+		return nil, errors.Wrap(err, "getting gateway")
+	}
+
+	addresses := tunnel.GatewayAddresses(*gw)
+	if len(addresses) == 0 {
+		return nil, &GatewayNoAddressError{Gateway: name}
+	}
+
+	routes, err := client.Resource(tunnel.HTTPRouteGVR).Namespace("").List(context.Background(), meta.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing httproutes")
+	}
+
+	return gatewayURLs(*gw, addresses[0], routes.Items), nil
+}
+
+// PrintGatewayList prints the URLs of a gateway as a table which has
+// "Namespace", "Route", "Hostname", "Address" and "URL" columns to a writer
+func PrintGatewayList(writer io.Writer, urls GatewayURLs) {
+	table := tablewriter.NewWriter(writer)
+	table.SetHeader([]string{"Namespace", "Route", "Hostname", "Address", "URL"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+	table.SetCenterSeparator("|")
+	for _, u := range urls {
+		table.Append([]string{u.Namespace, u.Route, u.Hostname, u.Address, strings.Join(u.URLs, "\n")})
+	}
+	table.Render()
+}
+
+func gatewayURLs(gw unstructured.Unstructured, address string, routes []unstructured.Unstructured) GatewayURLs {
+	listeners := gatewayListeners(gw)
+
+	var urls GatewayURLs
+	for _, route := range routes {
+		attached := attachedListeners(gw, route, listeners)
+		if len(attached) == 0 {
+			continue
+		}
+
+		hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+		byHostname := map[string][]string{}
+		var order []string
+		for _, l := range attached {
+			hosts := hostnames
+			if len(hosts) == 0 && l.hostname != "" {
+				hosts = []string{l.hostname}
+			}
+			if len(hosts) == 0 {
+				hosts = []string{address}
+			}
+			for _, h := range hosts {
+				if _, ok := byHostname[h]; !ok {
+					order = append(order, h)
+				}
+				byHostname[h] = append(byHostname[h], listenerURL(l, h))
+			}
+		}
+
+		for _, h := range order {
+			urls = append(urls, GatewayURL{
+				Namespace: route.GetNamespace(),
+				Route:     route.GetName(),
+				Hostname:  h,
+				Address:   address,
+				URLs:      byHostname[h],
+			})
+		}
+	}
+	return urls
+}
+
+func gatewayListeners(gw unstructured.Unstructured) []gatewayListener {
+	items, _, _ := unstructured.NestedSlice(gw.Object, "spec", "listeners")
+	var listeners []gatewayListener
+	for _, i := range items {
+		l, ok := i.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		listener := gatewayListener{}
+		listener.name, _, _ = unstructured.NestedString(l, "name")
+		listener.hostname, _, _ = unstructured.NestedString(l, "hostname")
+		listener.port, _, _ = unstructured.NestedInt64(l, "port")
+		listener.protocol, _, _ = unstructured.NestedString(l, "protocol")
+		if listener.protocol != "HTTP" && listener.protocol != "HTTPS" {
+			continue
+		}
+		listeners = append(listeners, listener)
+	}
+	return listeners
+}
+
+// attachedListeners returns the listeners of the gateway the route is attached to through its parentRefs
+func attachedListeners(gw, route unstructured.Unstructured, listeners []gatewayListener) []gatewayListener {
+	parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	var attached []gatewayListener
+	for _, p := range parentRefs {
+		ref, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if kind, found, _ := unstructured.NestedString(ref, "kind"); found && kind != "Gateway" {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(ref, "name")
+		ns, found, _ := unstructured.NestedString(ref, "namespace")
+		if !found {
+			ns = route.GetNamespace()
+		}
+		if name != gw.GetName() || ns != gw.GetNamespace() {
+			continue
+		}
+		sectionName, _, _ := unstructured.NestedString(ref, "sectionName")
+		port, _, _ := unstructured.NestedInt64(ref, "port")
+		for _, l := range listeners {
+			if sectionName != "" && sectionName != l.name {
+				continue
+			}
+			if port != 0 && port != l.port {
+				continue
+			}
+			attached = append(attached, l)
+		}
+	}
+	return attached
+}
+
+func listenerURL(l gatewayListener, hostname string) string {
+	scheme := strings.ToLower(l.protocol)
+	host := hostname
+	if ip := net.ParseIP(hostname); ip != nil && ip.To4() == nil {
+		host = "[" + hostname + "]"
+	}
+	if (scheme == "http" && l.port == 80) || (scheme == "https" && l.port == 443) {
+		return fmt.Sprintf("%s://%s", scheme, host)
+	}
+	return fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(hostname, strconv.FormatInt(l.port, 10)))
+}
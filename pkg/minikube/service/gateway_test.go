@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGatewayURLs(t *testing.T) {
+	gw := unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "gw", "namespace": "infra"},
+		"spec": map[string]interface{}{
+			"listeners": []interface{}{
+				map[string]interface{}{"name": "http", "port": int64(80), "protocol": "HTTP"},
+				map[string]interface{}{"name": "alt", "port": int64(8080), "protocol": "HTTP", "hostname": "alt.example.com"},
+				map[string]interface{}{"name": "tcp", "port": int64(9000), "protocol": "TCP"},
+			},
+		},
+	}}
+
+	route := func(name, ns string, hostnames []interface{}, parentRef map[string]interface{}) unstructured.Unstructured {
+		spec := map[string]interface{}{"parentRefs": []interface{}{parentRef}}
+		if hostnames != nil {
+			spec["hostnames"] = hostnames
+		}
+		return unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": name, "namespace": ns},
+			"spec":     spec,
+		}}
+	}
+
+	routes := []unstructured.Unstructured{
+		route("web", "apps", []interface{}{"web.example.com"}, map[string]interface{}{"name": "gw", "namespace": "infra", "sectionName": "http"}),
+		route("alt", "apps", nil, map[string]interface{}{"name": "gw", "namespace": "infra", "port": int64(8080)}),
+		route("local", "infra", nil, map[string]interface{}{"name": "gw", "sectionName": "http"}),
+		route("other", "apps", []interface{}{"other.example.com"}, map[string]interface{}{"name": "other-gw", "namespace": "infra"}),
+		route("wrongns", "apps", []interface{}{"wrong.example.com"}, map[string]interface{}{"name": "gw"}),
+	}
+
+	got := gatewayURLs(gw, "127.0.0.1", routes)
+	expected := GatewayURLs{
+		{Namespace: "apps", Route: "web", Hostname: "web.example.com", Address: "127.0.0.1", URLs: []string{"http://web.example.com"}},
+		{Namespace: "apps", Route: "alt", Hostname: "alt.example.com", Address: "127.0.0.1", URLs: []string{"http://alt.example.com:8080"}},
+		{Namespace: "infra", Route: "local", Hostname: "127.0.0.1", Address: "127.0.0.1", URLs: []string{"http://127.0.0.1"}},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("gatewayURLs() = %+v, expected %+v", got, expected)
+	}
+}
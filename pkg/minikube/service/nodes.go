@@ -0,0 +1,192 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"text/template"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// IPv4 is the IPv4 family of a node endpoint
+	IPv4 = "IPv4"
+	// IPv6 is the IPv6 family of a node endpoint
+	IPv6 = "IPv6"
+)
+
+// NodeEndpoint is a NodePort URL of a service on a single node address
+type NodeEndpoint struct {
+	Node     string
+	IP       string
+	IPFamily string
+	PortName string
+	URL      string
+	// HasPods is true if a running pod backing the service is scheduled on the node
+	HasPods bool
+}
+
+// ParseIPFamily validates an --ip-family value, returning the canonical family name or "" for all families
+func ParseIPFamily(family string) (string, error) {
+	switch strings.ToLower(family) {
+	case "":
+		return "", nil
+	case "ipv4", "4":
+		return IPv4, nil
+	case "ipv6", "6":
+		return IPv6, nil
+	}
+	return "", fmt.Errorf("invalid IP family %q, must be one of ipv4 or ipv6", family)
+}
+
+// AddNodeEndpoints sets the Endpoints of every service URL to its NodePort URLs on every node address,
+// keeping only the endpoints of node (all nodes if empty) and family (all families if empty)
+func AddNodeEndpoints(cname string, urls URLs, t *template.Template, node, family string) (URLs, error) {
+	client, err := K8s.GetCoreClient(cname)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := client.Nodes().List(context.Background(), meta.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing nodes")
+	}
+
+	for i := range urls {
+		svc, err := client.Services(urls[i].Namespace).Get(context.Background(), urls[i].Name, meta.GetOptions{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "service '%s' could not be found running", urls[i].Name)
+		}
+		pods, err := runningServicePods(client, svc)
+		if err != nil {
+			return nil, err
+		}
+		podNodes := map[string]bool{}
+		for _, p := range pods {
+			podNodes[p.Spec.NodeName] = true
+		}
+
+		endpoints, err := nodeEndpoints(svc, nodes.Items, podNodes, t)
+		if err != nil {
+			return nil, err
+		}
+		var filtered []NodeEndpoint
+		for _, e := range endpoints {
+			if (node == "" || e.Node == node) && (family == "" || e.IPFamily == family) {
+				filtered = append(filtered, e)
+			}
+		}
+		urls[i].Endpoints = filtered
+	}
+	return urls, nil
+}
+
+// PrintNodeEndpointList prints the node endpoints of services as a table to a writer
+func PrintNodeEndpointList(writer io.Writer, urls URLs) {
+	table := tablewriter.NewWriter(writer)
+	table.SetHeader([]string{"Namespace", "Name", "Node", "IP Family", "Target Port", "URL", "Pods"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+	table.SetCenterSeparator("|")
+	for _, u := range urls {
+		if len(u.Endpoints) == 0 {
+			table.Append([]string{u.Namespace, u.Name, "", "", "", "No node port", ""})
+			continue
+		}
+		for _, e := range u.Endpoints {
+			pods := "no"
+			if e.HasPods {
+				pods = "yes"
+			}
+			table.Append([]string{u.Namespace, u.Name, e.Node, e.IPFamily, e.PortName, e.URL, pods})
+		}
+	}
+	table.Render()
+}
+
+// nodeEndpoints builds the NodePort URLs of a service for the internal addresses of all nodes,
+// limited to the IP families the service is served on
+func nodeEndpoints(svc *core.Service, nodes []core.Node, podNodes map[string]bool, t *template.Template) ([]NodeEndpoint, error) {
+	if t == nil {
+		return nil, errors.New("Error, attempted to generate service url with nil --format template")
+	}
+
+	families := map[string]bool{}
+	for _, f := range svc.Spec.IPFamilies {
+		families[string(f)] = true
+	}
+
+	var endpoints []NodeEndpoint
+	for _, n := range nodes {
+		for _, addr := range n.Status.Addresses {
+			if addr.Type != core.NodeInternalIP {
+				continue
+			}
+			ip := net.ParseIP(addr.Address)
+			if ip == nil {
+				continue
+			}
+			family, templateIP := IPv4, addr.Address
+			if ip.To4() == nil {
+				family, templateIP = IPv6, "["+addr.Address+"]"
+			}
+			if len(families) > 0 && !families[family] {
+				continue
+			}
+
+			for _, port := range svc.Spec.Ports {
+				if port.NodePort <= 0 {
+					continue
+				}
+				portName := fmt.Sprint(port.Port)
+				if port.Name != "" {
+					portName = fmt.Sprintf("%s/%d", port.Name, port.Port)
+				}
+				var doc bytes.Buffer
+				err := t.Execute(&doc, struct {
+					IP   string
+					Port int32
+					Name string
+				}{
+					templateIP,
+					port.NodePort,
+					portName,
+				})
+				if err != nil {
+					return nil, err
+				}
+				endpoints = append(endpoints, NodeEndpoint{
+					Node:     n.Name,
+					IP:       addr.Address,
+					IPFamily: family,
+					PortName: portName,
+					URL:      doc.String(),
+					HasPods:  podNodes[n.Name],
+				})
+			}
+		}
+	}
+	return endpoints, nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"reflect"
+	"testing"
+	"text/template"
+
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNodeEndpoints(t *testing.T) {
+	node := func(name string, ips ...string) core.Node {
+		n := core.Node{ObjectMeta: meta.ObjectMeta{Name: name}}
+		n.Status.Addresses = append(n.Status.Addresses, core.NodeAddress{Type: core.NodeHostName, Address: name})
+		for _, ip := range ips {
+			n.Status.Addresses = append(n.Status.Addresses, core.NodeAddress{Type: core.NodeInternalIP, Address: ip})
+		}
+		return n
+	}
+	nodes := []core.Node{
+		node("minikube", "192.168.49.2", "fd00::2"),
+		node("minikube-m02", "192.168.49.3", "fd00::3"),
+	}
+	svc := &core.Service{
+		Spec: core.ServiceSpec{
+			Ports: []core.ServicePort{
+				{Name: "http", Port: 80, NodePort: 30080},
+				{Port: 9090},
+			},
+		},
+	}
+	tmpl := template.Must(template.New("svc-template").Parse("http://{{.IP}}:{{.Port}}"))
+
+	tests := []struct {
+		name     string
+		families []core.IPFamily
+		expected []NodeEndpoint
+	}{
+		{
+			name:     "ipv4 only service",
+			families: []core.IPFamily{core.IPv4Protocol},
+			expected: []NodeEndpoint{
+				{Node: "minikube", IP: "192.168.49.2", IPFamily: IPv4, PortName: "http/80", URL: "http://192.168.49.2:30080", HasPods: false},
+				{Node: "minikube-m02", IP: "192.168.49.3", IPFamily: IPv4, PortName: "http/80", URL: "http://192.168.49.3:30080", HasPods: true},
+			},
+		},
+		{
+			name:     "dual stack service",
+			families: []core.IPFamily{core.IPv6Protocol, core.IPv4Protocol},
+			expected: []NodeEndpoint{
+				{Node: "minikube", IP: "192.168.49.2", IPFamily: IPv4, PortName: "http/80", URL: "http://192.168.49.2:30080", HasPods: false},
+				{Node: "minikube", IP: "fd00::2", IPFamily: IPv6, PortName: "http/80", URL: "http://[fd00::2]:30080", HasPods: false},
+				{Node: "minikube-m02", IP: "192.168.49.3", IPFamily: IPv4, PortName: "http/80", URL: "http://192.168.49.3:30080", HasPods: true},
+				{Node: "minikube-m02", IP: "fd00::3", IPFamily: IPv6, PortName: "http/80", URL: "http://[fd00::3]:30080", HasPods: true},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svc.Spec.IPFamilies = tc.families
+			got, err := nodeEndpoints(svc, nodes, map[string]bool{"minikube-m02": true}, tmpl)
+			if err != nil {
+				t.Fatalf("nodeEndpoints() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("nodeEndpoints() = %+v, expected %+v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestParseIPFamily(t *testing.T) {
+	for input, expected := range map[string]string{"": "", "ipv4": IPv4, "IPv6": IPv6, "6": IPv6} {
+		got, err := ParseIPFamily(input)
+		if err != nil || got != expected {
+			t.Errorf("ParseIPFamily(%q) = %q, %v, expected %q", input, got, err, expected)
+		}
+	}
+	if _, err := ParseIPFamily("ipv5"); err == nil {
+		t.Errorf("ParseIPFamily(\"ipv5\") expected an error")
+	}
+}
+
+func TestRunningServicePods(t *testing.T) {
+	pod := func(name, app string, phase core.PodPhase) *core.Pod {
+		return &core.Pod{
+			ObjectMeta: meta.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": app}},
+			Status:     core.PodStatus{Phase: phase},
+		}
+	}
+	client := k8sfake.NewSimpleClientset(
+		pod("web-1", "web", core.PodRunning),
+		pod("web-2", "web", core.PodPending),
+		pod("db-1", "db", core.PodRunning),
+	).CoreV1()
+
+	tests := []struct {
+		description string
+		selector    map[string]string
+		want        []string
+	}{
+		{"selector", map[string]string{"app": "web"}, []string{"web-1"}},
+		{"no selector", nil, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			svc := &core.Service{ObjectMeta: meta.ObjectMeta{Name: "svc", Namespace: "default"}}
+			svc.Spec.Selector = test.selector
+			pods, err := runningServicePods(client, svc)
+			if err != nil {
+				t.Fatalf("runningServicePods: %v", err)
+			}
+			var got []string
+			for _, p := range pods {
+				got = append(got, p.Name)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("runningServicePods() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -82,6 +82,7 @@ type SvcURL struct {
 	Name      string
 	URLs      []string
 	PortNames []string
+	Endpoints []NodeEndpoint `json:",omitempty"` // only set by AddNodeEndpoints
 }
 
 // URLs represents a list of URL
@@ -392,17 +393,39 @@ func CheckServicePods(cname, svcName, namespace string) error {
 	if svc.Spec.Type != core.ServiceTypeNodePort && svc.Spec.Type != core.ServiceTypeLoadBalancer {
 		return nil
 	}
-	pods, err := clientset.Pods(namespace).List(context.Background(), meta.ListOptions{
+	// A service without a selector is backed by endpoints managed outside of
+	// Kubernetes, so there are no pods to check
+	if len(svc.Spec.Selector) == 0 {
+		return nil
+	}
+	pods, err := runningServicePods(clientset, svc)
+	if err != nil {
+		return err
+	}
+	if len(pods) > 0 {
+		return nil
+	}
+	return fmt.Errorf("no running pod for service %s found", svcName)
+}
+
+// runningServicePods returns the running pods selected by a service
+func runningServicePods(clientset typed_core.CoreV1Interface, svc *core.Service) ([]core.Pod, error) {
+	// An empty selector would match every pod in the namespace
+	if len(svc.Spec.Selector) == 0 {
+		return nil, nil
+	}
+	pods, err := clientset.Pods(svc.Namespace).List(context.Background(), meta.ListOptions{
 		LabelSelector: labels.Set(svc.Spec.Selector).AsSelector().String(),
 	})
 	if err != nil {
-		return errors.Wrap(err, "List Pods")
+		return nil, errors.Wrap(err, "List Pods")
 	}
 
+	var running []core.Pod
 	for _, pod := range pods.Items {
 		if pod.Status.Phase == core.PodRunning {
-			return nil
+			running = append(running, pod)
 		}
 	}
-	return fmt.Errorf("no running pod for service %s found", svcName)
+	return running, nil
 }
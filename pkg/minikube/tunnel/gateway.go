@@ -0,0 +1,229 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"context"
+	"fmt"
+
+	core "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	typed_core "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// GatewayNameLabel is the label Gateway API implementations put on the resources (such as the Service) they create for a Gateway
+const GatewayNameLabel = "gateway.networking.k8s.io/gateway-name"
+
+// TunnelAddressAnnotation records the address the tunnel set on a Gateway, so that it only changes and removes its own addresses
+const TunnelAddressAnnotation = "minikube.sigs.k8s.io/tunnel-address"
+
+var (
+	// GatewayGVR is the Gateway API Gateway resource
+	GatewayGVR = schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gateways"}
+	// HTTPRouteGVR is the Gateway API HTTPRoute resource
+	HTTPRouteGVR = schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes"}
+)
+
+// GatewayEmulator emulates the address assignment of a Gateway API implementation, similar to what LoadBalancerEmulator does for services
+type GatewayEmulator struct {
+	client       dynamic.Interface
+	coreV1Client typed_core.CoreV1Interface
+}
+
+// NewGatewayEmulator creates a new GatewayEmulator, a nil client disables Gateway handling
+func NewGatewayEmulator(client dynamic.Interface, corev1Client typed_core.CoreV1Interface) GatewayEmulator {
+	return GatewayEmulator{
+		client:       client,
+		coreV1Client: corev1Client,
+	}
+}
+
+// ListGateways lists all Gateways in the cluster, returning none if the Gateway API CRDs are not installed
+func (g *GatewayEmulator) ListGateways() ([]unstructured.Unstructured, error) {
+	if g.client == nil {
+		return nil, nil
+	}
+	list, err := g.client.Resource(GatewayGVR).Namespace("").List(context.Background(), meta.ListOptions{})
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			klog.V(3).Infof("Gateway API is not installed, skipping gateways.")
+			return nil, nil
+		}
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// PatchGateways sets the address of the Gateways without addresses, or with the one the tunnel set, to the ClusterIP of their backing service.
+// Gateways addressed by their controller are left alone.
+func (g *GatewayEmulator) PatchGateways() ([]string, error) {
+	gateways, err := g.ListGateways()
+	if err != nil {
+		return nil, err
+	}
+
+	var managedGateways []string
+	for _, gw := range gateways {
+		if !tunnelManaged(gw) {
+			klog.V(3).Infof("gateway %s/%s has addresses set by its controller, skipping.", gw.GetNamespace(), gw.GetName())
+			continue
+		}
+		svc, err := GatewayService(g.coreV1Client, gw)
+		if err != nil {
+			klog.Errorf("error finding service for gateway %s/%s: %v", gw.GetNamespace(), gw.GetName(), err)
+			continue
+		}
+		if svc == nil || svc.Spec.ClusterIP == "" || svc.Spec.ClusterIP == core.ClusterIPNone {
+			klog.V(3).Infof("gateway %s/%s has no backing service yet, skipping.", gw.GetNamespace(), gw.GetName())
+			continue
+		}
+		managedGateways = append(managedGateways, gw.GetName())
+		if err := g.PatchGatewayIP(gw, svc.Spec.ClusterIP); err != nil {
+			klog.Errorf("error patching gateway %s/%s: %s", gw.GetNamespace(), gw.GetName(), err)
+		}
+	}
+	return managedGateways, nil
+}
+
+// PatchGatewayIP sets the given ip as the only address of the gateway, unless its controller addressed it
+func (g *GatewayEmulator) PatchGatewayIP(gw unstructured.Unstructured, ip string) error {
+	if len(ip) == 0 || g.client == nil {
+		return nil
+	}
+	if !tunnelManaged(gw) {
+		klog.V(3).Infof("gateway %s/%s has addresses set by its controller, not setting %s.", gw.GetNamespace(), gw.GetName(), ip)
+		return nil
+	}
+	addresses := GatewayAddresses(gw)
+	if len(addresses) == 1 && addresses[0] == ip && gw.GetAnnotations()[TunnelAddressAnnotation] == ip {
+		return nil
+	}
+	klog.V(3).Infof("[%s] setting %s as the Gateway address", gw.GetName(), ip)
+	// the annotation is set first, so that an address is never left without it
+	if err := g.annotate(gw, fmt.Sprintf("%q", ip)); err != nil {
+		return err
+	}
+	patch := fmt.Sprintf(`{"status": {"addresses": [ { "type": "IPAddress", "value": "%s" } ] } }`, ip)
+	_, err := g.client.Resource(GatewayGVR).Namespace(gw.GetNamespace()).Patch(context.Background(), gw.GetName(), types.MergePatchType, []byte(patch), meta.PatchOptions{}, "status")
+	if err != nil {
+		return err
+	}
+	klog.Infof("Patched gateway %s with IP %s", gw.GetName(), ip)
+	return nil
+}
+
+// Cleanup removes the addresses the tunnel set on gateways
+func (g *GatewayEmulator) Cleanup() ([]string, error) {
+	gateways, err := g.ListGateways()
+	if err != nil {
+		return nil, err
+	}
+
+	var managedGateways []string
+	for _, gw := range gateways {
+		if _, ok := gw.GetAnnotations()[TunnelAddressAnnotation]; !ok {
+			continue
+		}
+		managedGateways = append(managedGateways, gw.GetName())
+		// the controller of the gateway may have set its own addresses since
+		if tunnelManaged(gw) && len(GatewayAddresses(gw)) > 0 {
+			patch := `{"status": {"addresses": null } }`
+			_, err := g.client.Resource(GatewayGVR).Namespace(gw.GetNamespace()).Patch(context.Background(), gw.GetName(), types.MergePatchType, []byte(patch), meta.PatchOptions{}, "status")
+			if err != nil {
+				klog.Errorf("error cleaning up gateway %s/%s: %s", gw.GetNamespace(), gw.GetName(), err)
+				continue
+			}
+			klog.Infof("Removed addresses from gateway %s.", gw.GetName())
+		}
+		if err := g.annotate(gw, "null"); err != nil {
+			klog.Errorf("error cleaning up gateway %s/%s: %s", gw.GetNamespace(), gw.GetName(), err)
+		}
+	}
+	return managedGateways, nil
+}
+
+// annotate sets the tunnel address annotation of a gateway to a JSON value, null removing it
+func (g *GatewayEmulator) annotate(gw unstructured.Unstructured, value string) error {
+	patch := fmt.Sprintf(`{"metadata": {"annotations": {%q: %s } } }`, TunnelAddressAnnotation, value)
+	_, err := g.client.Resource(GatewayGVR).Namespace(gw.GetNamespace()).Patch(context.Background(), gw.GetName(), types.MergePatchType, []byte(patch), meta.PatchOptions{})
+	return err
+}
+
+// tunnelManaged returns whether the tunnel may set the addresses of a gateway: it has none, or only the one the tunnel set
+func tunnelManaged(gw unstructured.Unstructured) bool {
+	addresses := GatewayAddresses(gw)
+	if len(addresses) == 0 {
+		return true
+	}
+	ip, ok := gw.GetAnnotations()[TunnelAddressAnnotation]
+	return ok && len(addresses) == 1 && addresses[0] == ip
+}
+
+// GatewayListenerPorts returns the distinct ports of the listeners of a gateway
+func GatewayListenerPorts(gw unstructured.Unstructured) []int32 {
+	listeners, _, _ := unstructured.NestedSlice(gw.Object, "spec", "listeners")
+	seen := map[int64]bool{}
+	var ports []int32
+	for _, l := range listeners {
+		listener, ok := l.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		port, found, err := unstructured.NestedInt64(listener, "port")
+		if !found || err != nil || seen[port] {
+			continue
+		}
+		seen[port] = true
+		ports = append(ports, int32(port))
+	}
+	return ports
+}
+
+// GatewayAddresses returns the addresses in the status of a gateway
+func GatewayAddresses(gw unstructured.Unstructured) []string {
+	addresses, _, _ := unstructured.NestedSlice(gw.Object, "status", "addresses")
+	var values []string
+	for _, a := range addresses {
+		address, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if v, ok := address["value"].(string); ok {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// GatewayService returns the service created by the Gateway API implementation for a gateway, or nil if there is none
+func GatewayService(coreV1Client typed_core.CoreV1Interface, gw unstructured.Unstructured) (*core.Service, error) {
+	selector := fmt.Sprintf("%s=%s", GatewayNameLabel, gw.GetName())
+	services, err := coreV1Client.Services(gw.GetNamespace()).List(context.Background(), meta.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	if len(services.Items) == 0 {
+		return nil, nil
+	}
+	return &services.Items[0], nil
+}
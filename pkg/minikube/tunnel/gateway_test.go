@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestGatewayListenerPorts(t *testing.T) {
+	gw := unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"listeners": []interface{}{
+				map[string]interface{}{"name": "http", "port": int64(80), "protocol": "HTTP"},
+				map[string]interface{}{"name": "http-alt", "port": int64(80), "protocol": "HTTP", "hostname": "foo.example.com"},
+				map[string]interface{}{"name": "https", "port": int64(443), "protocol": "HTTPS"},
+			},
+		},
+	}}
+
+	got := GatewayListenerPorts(gw)
+	expected := []int32{80, 443}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("GatewayListenerPorts() = %v, expected %v", got, expected)
+	}
+}
+
+func TestGatewayAddresses(t *testing.T) {
+	gw := unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"addresses": []interface{}{
+				map[string]interface{}{"type": "IPAddress", "value": "10.96.0.20"},
+			},
+		},
+	}}
+
+	got := GatewayAddresses(gw)
+	expected := []string{"10.96.0.20"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("GatewayAddresses() = %v, expected %v", got, expected)
+	}
+
+	if got := GatewayAddresses(unstructured.Unstructured{Object: map[string]interface{}{}}); len(got) != 0 {
+		t.Errorf("GatewayAddresses() of gateway without status = %v, expected none", got)
+	}
+}
+
+func TestGatewayEmulatorWithoutClient(t *testing.T) {
+	g := NewGatewayEmulator(nil, newStubCoreClient(nil))
+	gateways, err := g.PatchGateways()
+	if len(gateways) != 0 || err != nil {
+		t.Errorf("Expected: [], nil\n Got: %v, %s", gateways, err)
+	}
+}
+
+// testGateway returns a gateway with the given addresses and tunnel address annotation
+func testGateway(name string, annotation string, addresses ...string) *unstructured.Unstructured {
+	gw := &unstructured.Unstructured{}
+	gw.SetAPIVersion("gateway.networking.k8s.io/v1")
+	gw.SetKind("Gateway")
+	gw.SetNamespace("default")
+	gw.SetName(name)
+	if annotation != "" {
+		gw.SetAnnotations(map[string]string{TunnelAddressAnnotation: annotation})
+	}
+	var as []interface{}
+	for _, a := range addresses {
+		as = append(as, map[string]interface{}{"type": "IPAddress", "value": a})
+	}
+	if len(as) > 0 {
+		gw.Object["status"] = map[string]interface{}{"addresses": as}
+	}
+	return gw
+}
+
+func TestGatewayEmulatorOwnsItsAddresses(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{GatewayGVR: "GatewayList"})
+	// created rather than passed to the client, which would guess the wrong resource for Gateway
+	for _, gw := range []*unstructured.Unstructured{
+		testGateway("new", ""),
+		testGateway("tunnel", "10.96.0.10", "10.96.0.10"),
+		testGateway("controller", "", "192.168.49.240"),
+	} {
+		if _, err := client.Resource(GatewayGVR).Namespace("default").Create(context.Background(), gw, meta.CreateOptions{}); err != nil {
+			t.Fatalf("creating %s: %v", gw.GetName(), err)
+		}
+	}
+	svc := core.Service{Spec: core.ServiceSpec{ClusterIP: "10.96.0.20"}}
+	g := NewGatewayEmulator(client, newStubCoreClient(&core.ServiceList{Items: []core.Service{svc}}))
+
+	patched, err := g.PatchGateways()
+	if err != nil {
+		t.Fatalf("PatchGateways: %v", err)
+	}
+	if expected := []string{"new", "tunnel"}; !reflect.DeepEqual(patched, expected) {
+		t.Errorf("PatchGateways() = %v, expected %v", patched, expected)
+	}
+
+	addresses := func(name string) []string {
+		gw, err := client.Resource(GatewayGVR).Namespace("default").Get(context.Background(), name, meta.GetOptions{})
+		if err != nil {
+			t.Fatalf("getting %s: %v", name, err)
+		}
+		return GatewayAddresses(*gw)
+	}
+	for name, expected := range map[string][]string{"new": {"10.96.0.20"}, "tunnel": {"10.96.0.20"}, "controller": {"192.168.49.240"}} {
+		if got := addresses(name); !reflect.DeepEqual(got, expected) {
+			t.Errorf("addresses of %s = %v, expected %v", name, got, expected)
+		}
+	}
+
+	// the ssh tunnel of the docker driver sets 127.0.0.1 on each gateway it forwards
+	controller, err := client.Resource(GatewayGVR).Namespace("default").Get(context.Background(), "controller", meta.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting controller: %v", err)
+	}
+	if err := g.PatchGatewayIP(*controller, "127.0.0.1"); err != nil {
+		t.Fatalf("PatchGatewayIP: %v", err)
+	}
+	if got, expected := addresses("controller"), []string{"192.168.49.240"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("addresses of controller after PatchGatewayIP = %v, expected %v", got, expected)
+	}
+
+	cleaned, err := g.Cleanup()
+	if err != nil {
+		t.Fatalf("Cleanup: %v", err)
+	}
+	if expected := []string{"new", "tunnel"}; !reflect.DeepEqual(cleaned, expected) {
+		t.Errorf("Cleanup() = %v, expected %v", cleaned, expected)
+	}
+	for name, expected := range map[string][]string{"new": nil, "tunnel": nil, "controller": {"192.168.49.240"}} {
+		if got := addresses(name); !reflect.DeepEqual(got, expected) {
+			t.Errorf("addresses of %s after cleanup = %v, expected %v", name, got, expected)
+		}
+	}
+}
@@ -25,6 +25,8 @@ import (
 	v1 "k8s.io/api/core/v1"
 	v1_networking "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
 	typed_core "k8s.io/client-go/kubernetes/typed/core/v1"
 	typed_networking "k8s.io/client-go/kubernetes/typed/networking/v1"
 
@@ -41,12 +43,13 @@ type SSHTunnel struct {
 	v1Core               typed_core.CoreV1Interface
 	v1Networking         typed_networking.NetworkingV1Interface
 	LoadBalancerEmulator tunnel.LoadBalancerEmulator
+	GatewayEmulator      tunnel.GatewayEmulator
 	conns                map[string]*sshConn
 	connsToStop          map[string]*sshConn
 }
 
 // NewSSHTunnel ...
-func NewSSHTunnel(ctx context.Context, sshPort, sshKey, bindAddress string, v1Core typed_core.CoreV1Interface, v1Networking typed_networking.NetworkingV1Interface) *SSHTunnel {
+func NewSSHTunnel(ctx context.Context, sshPort, sshKey, bindAddress string, v1Core typed_core.CoreV1Interface, v1Networking typed_networking.NetworkingV1Interface, dynamicClient dynamic.Interface) *SSHTunnel {
 	return &SSHTunnel{
 		ctx:                  ctx,
 		sshPort:              sshPort,
@@ -54,6 +57,7 @@ func NewSSHTunnel(ctx context.Context, sshPort, sshKey, bindAddress string, v1Co
 		bindAddress:          bindAddress,
 		v1Core:               v1Core,
 		LoadBalancerEmulator: tunnel.NewLoadBalancerEmulator(v1Core),
+		GatewayEmulator:      tunnel.NewGatewayEmulator(dynamicClient, v1Core),
 		v1Networking:         v1Networking,
 		conns:                make(map[string]*sshConn),
 		connsToStop:          make(map[string]*sshConn),
@@ -69,6 +73,10 @@ func (t *SSHTunnel) Start() error {
 			if err != nil {
 				klog.Errorf("error cleaning up: %v", err)
 			}
+			_, err = t.GatewayEmulator.Cleanup()
+			if err != nil {
+				klog.Errorf("error cleaning up gateways: %v", err)
+			}
 			t.stopActiveConnections()
 			return err
 		default:
@@ -84,6 +92,11 @@ func (t *SSHTunnel) Start() error {
 			klog.Errorf("error listing ingresses: %v", err)
 		}
 
+		gateways, err := t.GatewayEmulator.ListGateways()
+		if err != nil {
+			klog.Errorf("error listing gateways: %v", err)
+		}
+
 		t.markConnectionsToBeStopped()
 
 		for _, svc := range services.Items {
@@ -96,6 +109,10 @@ func (t *SSHTunnel) Start() error {
 			t.startConnectionIngress(ingress)
 		}
 
+		for _, gateway := range gateways {
+			t.startConnectionGateway(gateway)
+		}
+
 		t.stopMarkedConnections()
 
 		// TODO: which time to use?
@@ -167,6 +184,49 @@ func (t *SSHTunnel) startConnectionIngress(ingress v1_networking.Ingress) {
 	}()
 }
 
+func (t *SSHTunnel) startConnectionGateway(gateway unstructured.Unstructured) {
+	resourcePorts := tunnel.GatewayListenerPorts(gateway)
+	if len(resourcePorts) == 0 {
+		return
+	}
+
+	// forward to the service created by the gateway implementation if there is one,
+	// otherwise assume the listeners are exposed on the node like the ingress addon does
+	resourceIP := "127.0.0.1"
+	svc, err := tunnel.GatewayService(t.v1Core, gateway)
+	if err != nil {
+		klog.Errorf("error finding service for gateway %s: %v", gateway.GetName(), err)
+	}
+	if svc != nil && svc.Spec.ClusterIP != "" && svc.Spec.ClusterIP != v1.ClusterIPNone {
+		resourceIP = svc.Spec.ClusterIP
+	}
+
+	uniqName := sshConnUniqNameGateway(gateway, resourceIP, resourcePorts)
+	existingSSHConn, ok := t.conns[uniqName]
+
+	if ok {
+		// if the gateway still exist we remove the conn from the stopping list
+		delete(t.connsToStop, existingSSHConn.name)
+		return
+	}
+
+	// create new ssh conn
+	newSSHConn := createSSHConn(uniqName, t.sshPort, t.sshKey, t.bindAddress, resourcePorts, resourceIP, gateway.GetName())
+	t.conns[newSSHConn.name] = newSSHConn
+
+	go func() {
+		err := newSSHConn.startAndWait()
+		if err != nil {
+			klog.Errorf("error starting ssh tunnel: %v", err)
+		}
+	}()
+
+	err = t.GatewayEmulator.PatchGatewayIP(gateway, "127.0.0.1")
+	if err != nil {
+		klog.Errorf("error patching gateway: %v", err)
+	}
+}
+
 func (t *SSHTunnel) stopActiveConnections() {
 	for _, conn := range t.conns {
 		err := conn.stop()
@@ -213,3 +273,22 @@ func sshConnUniqNameIngress(ingress v1_networking.Ingress) string {
 
 	return strings.Join(n, "")
 }
+
+// sshConnUniqNameGateway creates a uniq name for the gateway tunnel, using its namespace/name/target IP/listener ports,
+// so that the tunnel is recreated when listeners are changed.
+func sshConnUniqNameGateway(gateway unstructured.Unstructured, resourceIP string, ports []int32) string {
+	n := []string{
+		"gateway-",
+		gateway.GetNamespace(),
+		"-",
+		gateway.GetName(),
+		"-",
+		resourceIP,
+	}
+
+	for _, port := range ports {
+		n = append(n, fmt.Sprintf("-%d", port))
+	}
+
+	return strings.Join(n, "")
+}
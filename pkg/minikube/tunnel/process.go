@@ -20,7 +20,12 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"strconv"
+	"strings"
 	"syscall"
+
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/localpath"
 )
 
 var checkIfRunning func(pid int) (bool, error)
@@ -31,6 +36,43 @@ func init() {
 	getPid = osGetPid
 }
 
+// IsRunning returns true if the process with the given pid is still running,
+// it is used by other long running minikube processes that record their pid, such as port forwards
+func IsRunning(pid int) (bool, error) {
+	return checkIfRunning(pid)
+}
+
+// RecordPid records this process as the minikube tunnel of a profile, for ProfileTunnelRunning
+func RecordPid(profile string) error {
+	return os.WriteFile(localpath.TunnelPid(profile), []byte(strconv.Itoa(getPid())), 0644)
+}
+
+// RemovePid removes the pid recorded by RecordPid
+func RemovePid(profile string) {
+	if err := os.Remove(localpath.TunnelPid(profile)); err != nil && !os.IsNotExist(err) {
+		klog.Warningf("unable to remove the tunnel pid of %s: %v", profile, err)
+	}
+}
+
+// ProfileTunnelRunning returns if the minikube tunnel of a profile is running, from its recorded pid.
+// It does not take the lock of the tunnel, a tunnel starting at the same time would fail to get it.
+func ProfileTunnelRunning(profile string) bool {
+	data, err := os.ReadFile(localpath.TunnelPid(profile))
+	if err != nil {
+		return false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		klog.Warningf("invalid tunnel pid of %s: %v", profile, err)
+		return false
+	}
+	running, err := checkIfRunning(pid)
+	if err != nil {
+		klog.Warningf("unable to check if tunnel process %d is running: %v", pid, err)
+	}
+	return running
+}
+
 func osGetPid() int {
 	return os.Getpid()
 }
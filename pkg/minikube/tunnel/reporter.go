@@ -47,6 +47,8 @@ func (r *simpleReporter) Report(tunnelState *Status) {
 
 	managedServices := fmt.Sprintf("[%s]", strings.Join(tunnelState.PatchedServices, ", "))
 
+	managedGateways := fmt.Sprintf("[%s]", strings.Join(tunnelState.PatchedGateways, ", "))
+
 	lbError := noErrors
 	if tunnelState.LoadBalancerEmulatorError != nil {
 		lbError = tunnelState.LoadBalancerEmulatorError.Error()
@@ -57,6 +59,11 @@ func (r *simpleReporter) Report(tunnelState *Status) {
 		minikubeError = tunnelState.MinikubeError.Error()
 	}
 
+	gatewayError := noErrors
+	if tunnelState.GatewayEmulatorError != nil {
+		gatewayError = tunnelState.GatewayEmulatorError.Error()
+	}
+
 	routerError := noErrors
 	if tunnelState.RouteError != nil {
 		routerError = tunnelState.RouteError.Error()
@@ -66,7 +73,8 @@ func (r *simpleReporter) Report(tunnelState *Status) {
 		minikube: %s
 		router: %s
 		loadbalancer emulator: %s
-`, minikubeError, routerError, lbError)
+		gateway emulator: %s
+`, minikubeError, routerError, lbError, gatewayError)
 
 	_, err := r.out.Write([]byte(fmt.Sprintf(
 		`Status:	
@@ -75,11 +83,13 @@ func (r *simpleReporter) Report(tunnelState *Status) {
 	route: %s
 	minikube: %s
 	services: %s
+	gateways: %s
 %s`, tunnelState.TunnelID.MachineName,
 		tunnelState.TunnelID.Pid,
 		tunnelState.TunnelID.Route,
 		minikubeState,
 		managedServices,
+		managedGateways,
 		errors)))
 	if err != nil {
 		klog.Errorf("failed to report state %s", err)
@@ -51,10 +51,12 @@ func TestReporter(t *testing.T) {
 	route: 10.96.0.0/12 -> 1.2.3.4
 	minikube: Running
 	services: [svc1, svc2]
+	gateways: []
     errors: 
 		minikube: no errors
 		router: no errors
 		loadbalancer emulator: no errors
+		gateway emulator: no errors
 `,
 		},
 		{
@@ -79,10 +81,12 @@ func TestReporter(t *testing.T) {
 	route: 10.96.0.0/12 -> 1.2.3.4
 	minikube: Unknown
 	services: []
+	gateways: []
     errors: 
 		minikube: minikubeerror
 		router: route error
 		loadbalancer emulator: lberror
+		gateway emulator: no errors
 `,
 		},
 	}
@@ -26,6 +26,7 @@ import (
 	"github.com/docker/machine/libmachine"
 	"github.com/docker/machine/libmachine/host"
 	"github.com/pkg/errors"
+	"k8s.io/client-go/dynamic"
 	typed_core "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/config"
@@ -44,7 +45,7 @@ func errorTunnelAlreadyExists(id *ID) error {
 	return fmt.Errorf("there is already a running tunnel for this machine: %s", id)
 }
 
-func newTunnel(machineName string, machineAPI libmachine.API, configLoader config.Loader, v1Core typed_core.CoreV1Interface, registry *persistentRegistry, router router) (*tunnel, error) {
+func newTunnel(machineName string, machineAPI libmachine.API, configLoader config.Loader, v1Core typed_core.CoreV1Interface, dynamicClient dynamic.Interface, registry *persistentRegistry, router router) (*tunnel, error) {
 	ci := &clusterInspector{
 		machineName:  machineName,
 		machineAPI:   machineAPI,
@@ -73,6 +74,7 @@ func newTunnel(machineName string, machineAPI libmachine.API, configLoader confi
 		router:               router,
 		registry:             registry,
 		LoadBalancerEmulator: NewLoadBalancerEmulator(v1Core),
+		GatewayEmulator:      NewGatewayEmulator(dynamicClient, v1Core),
 		status: &Status{
 			TunnelID:      id,
 			MinikubeState: state,
@@ -89,6 +91,7 @@ type tunnel struct {
 	clusterInspector     *clusterInspector
 	router               router
 	LoadBalancerEmulator LoadBalancerEmulator
+	GatewayEmulator      GatewayEmulator
 	reporter             reporter
 	registry             *persistentRegistry
 
@@ -109,6 +112,7 @@ func (t *tunnel) cleanup() *Status {
 	}
 	if t.status.MinikubeState == Running {
 		t.status.PatchedServices, t.status.LoadBalancerEmulatorError = t.LoadBalancerEmulator.Cleanup()
+		t.status.PatchedGateways, t.status.GatewayEmulatorError = t.GatewayEmulator.Cleanup()
 	}
 	return t.status
 }
@@ -123,6 +127,7 @@ func (t *tunnel) update() *Status {
 		setupRoute(t, h)
 		if t.status.RouteError == nil {
 			t.status.PatchedServices, t.status.LoadBalancerEmulatorError = t.LoadBalancerEmulator.PatchServices()
+			t.status.PatchedGateways, t.status.GatewayEmulatorError = t.GatewayEmulator.PatchGateways()
 		}
 	}
 	klog.V(3).Infof("sending report %s", t.status)
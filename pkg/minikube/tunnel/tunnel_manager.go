@@ -24,6 +24,7 @@ import (
 	"fmt"
 
 	"github.com/docker/machine/libmachine"
+	"k8s.io/client-go/dynamic"
 	typed_core "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/config"
@@ -58,9 +59,9 @@ func NewManager() *Manager {
 	}
 }
 
-// StartTunnel starts the tunnel
-func (mgr *Manager) StartTunnel(ctx context.Context, machineName string, machineAPI libmachine.API, configLoader config.Loader, v1Core typed_core.CoreV1Interface) (done chan bool, err error) {
-	tunnel, err := newTunnel(machineName, machineAPI, configLoader, v1Core, mgr.registry, mgr.router)
+// StartTunnel starts the tunnel, dynamicClient is used to handle Gateway API resources and may be nil
+func (mgr *Manager) StartTunnel(ctx context.Context, machineName string, machineAPI libmachine.API, configLoader config.Loader, v1Core typed_core.CoreV1Interface, dynamicClient dynamic.Interface) (done chan bool, err error) {
+	tunnel, err := newTunnel(machineName, machineAPI, configLoader, v1Core, dynamicClient, mgr.registry, mgr.router)
 	if err != nil {
 		return nil, fmt.Errorf("error creating tunnel: %s", err)
 	}
@@ -22,6 +22,7 @@ import (
 	"github.com/docker/machine/libmachine/host"
 	"github.com/docker/machine/libmachine/state"
 	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/tests"
 
 	"fmt"
@@ -432,7 +433,7 @@ func TestTunnel(t *testing.T) {
 			registry, cleanup := createTestRegistry(t)
 			defer cleanup()
 
-			tunnel, err := newTunnel(machineName, machineAPI, configLoader, newStubCoreClient(nil), registry, &fakeRouter{})
+			tunnel, err := newTunnel(machineName, machineAPI, configLoader, newStubCoreClient(nil), nil, registry, &fakeRouter{})
 			if err != nil {
 				t.Errorf("error creating tunnel: %s", err)
 				return
@@ -494,8 +495,42 @@ func TestErrorCreatingTunnel(t *testing.T) {
 		path: f.Name(),
 	}
 
-	_, err = newTunnel(machineName, store, configLoader, newStubCoreClient(nil), registry, &fakeRouter{})
+	_, err = newTunnel(machineName, store, configLoader, newStubCoreClient(nil), nil, registry, &fakeRouter{})
 	if err == nil || !strings.Contains(err.Error(), "error loading machine") {
 		t.Errorf("expected error containing 'error loading machine', got %s", err)
 	}
 }
+
+func TestProfileTunnelRunning(t *testing.T) {
+	t.Setenv(localpath.MinikubeHome, t.TempDir())
+	if err := os.MkdirAll(localpath.Profile("p1"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	origPidChecker := checkIfRunning
+	checkIfRunning = mockPidChecker
+	defer func() { checkIfRunning = origPidChecker }()
+	origPidGetter := getPid
+	defer func() { getPid = origPidGetter }()
+
+	if ProfileTunnelRunning("p1") {
+		t.Errorf("expected no tunnel without a recorded pid")
+	}
+	getPid = func() int { return RunningPid1 }
+	if err := RecordPid("p1"); err != nil {
+		t.Fatalf("RecordPid() error = %v", err)
+	}
+	if !ProfileTunnelRunning("p1") {
+		t.Errorf("expected the tunnel of the recorded pid to run")
+	}
+	getPid = func() int { return NotRunningPid }
+	if err := RecordPid("p1"); err != nil {
+		t.Fatalf("RecordPid() error = %v", err)
+	}
+	if ProfileTunnelRunning("p1") {
+		t.Errorf("expected no tunnel for a pid that is not running")
+	}
+	RemovePid("p1")
+	if _, err := os.Stat(localpath.TunnelPid("p1")); !os.IsNotExist(err) {
+		t.Errorf("expected the pid file to be removed: %v", err)
+	}
+}
@@ -34,6 +34,9 @@ type Status struct {
 
 	PatchedServices           []string
 	LoadBalancerEmulatorError error
+
+	PatchedGateways      []string
+	GatewayEmulatorError error
 }
 
 // Clone clones an existing Status
@@ -45,18 +48,22 @@ func (t *Status) Clone() *Status {
 		RouteError:                t.RouteError,
 		PatchedServices:           t.PatchedServices,
 		LoadBalancerEmulatorError: t.LoadBalancerEmulatorError,
+		PatchedGateways:           t.PatchedGateways,
+		GatewayEmulatorError:      t.GatewayEmulatorError,
 	}
 }
 
 func (t *Status) String() string {
-	return fmt.Sprintf("id(%v), minikube(%s, e:%s), route(%s, e:%s), services(%s, e:%s)",
+	return fmt.Sprintf("id(%v), minikube(%s, e:%s), route(%s, e:%s), services(%s, e:%s), gateways(%s, e:%s)",
 		t.TunnelID,
 		t.MinikubeState,
 		t.MinikubeError,
 		t.TunnelID.Route,
 		t.RouteError,
 		t.PatchedServices,
-		t.LoadBalancerEmulatorError)
+		t.LoadBalancerEmulatorError,
+		t.PatchedGateways,
+		t.GatewayEmulatorError)
 }
 
 // Route represents a route
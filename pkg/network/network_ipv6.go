@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+
+	"k8s.io/klog/v2"
+)
+
+// defaultIPv6Prefix is the prefix length assumed for IPv6 addresses without one, the smallest subnet SLAAC works with
+const defaultIPv6Prefix = 64
+
+// InspectIPv6 initialises IPv6 network parameters struct from given address addr.
+// addr can be single address (like "fd00:192:168:49::2"), network address (like "fd00:192:168:49::") or in CIDR form (like "fd00:192:168:49::/64").
+// IPv6 has no broadcast address, so ClientMax is the last network IP address and Broadcast is empty.
+var InspectIPv6 = func(addr string) (*Parameters, error) {
+	ip, network, err := ParseAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+	if ip.To4() != nil {
+		return nil, fmt.Errorf("%s is not an IPv6 address", addr)
+	}
+	if network == nil {
+		network = &net.IPNet{
+			IP:   ip.Mask(net.CIDRMask(defaultIPv6Prefix, 128)),
+			Mask: net.CIDRMask(defaultIPv6Prefix, 128),
+		}
+	}
+
+	n := &Parameters{
+		IP:        network.IP.String(),
+		Netmask:   net.IP(network.Mask).String(),
+		CIDR:      network.String(),
+		IsPrivate: network.IP.IsPrivate(),
+	}
+	n.Prefix, _ = network.Mask.Size()
+
+	first := ipToInt(network.IP)
+	last := new(big.Int).Or(first, new(big.Int).Not(ipToInt(net.IP(network.Mask))))
+	last.And(last, maxIPv6)
+
+	gateway := new(big.Int).Add(first, big.NewInt(1)) // assume first network IP address
+	n.Gateway = intToIP(gateway).String()
+	n.ClientMin = intToIP(new(big.Int).Add(gateway, big.NewInt(1))).String()
+	n.ClientMax = intToIP(last).String()
+
+	return n, nil
+}
+
+// FreeSubnetIPv6 will try to find free private IPv6 network beginning with startSubnet, incrementing the subnet id (the last 16 bits of the /64 routing prefix) in steps up to number of tries.
+func FreeSubnetIPv6(startSubnet string, step, tries int) (*Parameters, error) {
+	currSubnet := startSubnet
+	for try := 0; try < tries; try++ {
+		n, err := InspectIPv6(currSubnet)
+		if err != nil {
+			return nil, err
+		}
+		subnet := n.IP
+		if n.IsPrivate {
+			taken, err := isSubnetTaken(subnet)
+			if err != nil {
+				return nil, err
+			}
+			if !taken {
+				if reservation, err := reserveSubnet(subnet); err == nil {
+					n.reservation = reservation
+					klog.Infof("using free private IPv6 subnet %s: %+v", n.CIDR, n)
+					return n, nil
+				}
+				klog.Infof("skipping IPv6 subnet %s that is reserved: %+v", n.CIDR, n)
+			} else {
+				klog.Infof("skipping IPv6 subnet %s that is taken: %+v", n.CIDR, n)
+			}
+		} else {
+			klog.Infof("skipping IPv6 subnet %s that is not private", n.CIDR)
+		}
+		next := ipToInt(net.ParseIP(n.IP))
+		next.Add(next, new(big.Int).Lsh(big.NewInt(int64(step)), 128-defaultIPv6Prefix))
+		currSubnet = fmt.Sprintf("%s/%d", intToIP(next), n.Prefix)
+	}
+	return nil, fmt.Errorf("no free private IPv6 network subnets found with given parameters (start: %q, step: %d, tries: %d)", startSubnet, step, tries)
+}
+
+// IPv6Offset returns the address offset addresses after ip, used to calculate static container addresses from the gateway
+func IPv6Offset(ip net.IP, offset int) net.IP {
+	return intToIP(new(big.Int).Add(ipToInt(ip), big.NewInt(int64(offset))))
+}
+
+var maxIPv6 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+
+func ipToInt(ip net.IP) *big.Int {
+	return new(big.Int).SetBytes(ip.To16())
+}
+
+func intToIP(i *big.Int) net.IP {
+	b := new(big.Int).And(i, maxIPv6).Bytes()
+	ip := make(net.IP, net.IPv6len)
+	copy(ip[net.IPv6len-len(b):], b)
+	return ip
+}
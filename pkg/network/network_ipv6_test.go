@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"net"
+	"testing"
+
+	"github.com/juju/mutex/v2"
+)
+
+func TestInspectIPv6(t *testing.T) {
+	tests := []struct {
+		addr      string
+		cidr      string
+		gateway   string
+		clientMin string
+		clientMax string
+		private   bool
+	}{
+		{"fd00:192:168:49::", "fd00:192:168:49::/64", "fd00:192:168:49::1", "fd00:192:168:49::2", "fd00:192:168:49:ffff:ffff:ffff:ffff", true},
+		{"fd00:192:168:49::42/120", "fd00:192:168:49::/120", "fd00:192:168:49::1", "fd00:192:168:49::2", "fd00:192:168:49::ff", true},
+		{"2001:db8::1", "2001:db8::/64", "2001:db8::1", "2001:db8::2", "2001:db8::ffff:ffff:ffff:ffff", false},
+	}
+	for _, tc := range tests {
+		n, err := InspectIPv6(tc.addr)
+		if err != nil {
+			t.Fatalf("InspectIPv6(%q) error = %v", tc.addr, err)
+		}
+		if n.CIDR != tc.cidr || n.Gateway != tc.gateway || n.ClientMin != tc.clientMin || n.ClientMax != tc.clientMax || n.IsPrivate != tc.private {
+			t.Errorf("InspectIPv6(%q) = %+v, expected CIDR %s, gateway %s, clients %s-%s, private %v", tc.addr, n, tc.cidr, tc.gateway, tc.clientMin, tc.clientMax, tc.private)
+		}
+	}
+
+	if _, err := InspectIPv6("192.168.49.0"); err == nil {
+		t.Errorf("InspectIPv6 of an IPv4 address expected an error")
+	}
+}
+
+func TestFreeSubnetIPv6(t *testing.T) {
+	reserveSubnet = func(_ string) (mutex.Releaser, error) { return nil, nil }
+
+	count := 0
+	originalIsSubnetTaken := isSubnetTaken
+	defer func() {
+		isSubnetTaken = originalIsSubnetTaken
+	}()
+	isSubnetTaken = func(_ string) (bool, error) {
+		count++
+		return count == 1, nil
+	}
+
+	subnet, err := FreeSubnetIPv6("fd00:192:168:49::/64", 9, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "fd00:192:168:52::/64"; subnet.CIDR != expected {
+		t.Errorf("expected CIDR = %q; got = %q", expected, subnet.CIDR)
+	}
+
+	if _, err := FreeSubnetIPv6("2001:db8::", 1, 3); err == nil {
+		t.Errorf("expected an error for a public IPv6 range")
+	}
+}
+
+func TestIPv6Offset(t *testing.T) {
+	got := IPv6Offset(net.ParseIP("fd00:192:168:49::1"), 2)
+	if expected := net.ParseIP("fd00:192:168:49::3"); !got.Equal(expected) {
+		t.Errorf("IPv6Offset() = %s, expected %s", got, expected)
+	}
+}
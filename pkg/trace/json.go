@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"k8s.io/klog/v2"
+
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+const (
+	// FileEnvVar is the name of the env variable that overrides the file written by the json tracer
+	FileEnvVar = "MINIKUBE_TRACE_FILE"
+)
+
+// JSONSpan is a finished span as written by the json tracer, one per line
+type JSONSpan struct {
+	Name         string            `json:"name"`
+	TraceID      string            `json:"traceId"`
+	SpanID       string            `json:"spanId"`
+	ParentSpanID string            `json:"parentSpanId,omitempty"`
+	StartTime    time.Time         `json:"startTime"`
+	EndTime      time.Time         `json:"endTime"`
+	Duration     float64           `json:"durationSeconds"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+}
+
+// jsonExporter writes spans to a file for offline analysis
+type jsonExporter struct {
+	mu sync.Mutex
+	w  io.WriteCloser
+}
+
+func newJSONSpan(s sdktrace.ReadOnlySpan) JSONSpan {
+	js := JSONSpan{
+		Name:      s.Name(),
+		TraceID:   s.SpanContext().TraceID().String(),
+		SpanID:    s.SpanContext().SpanID().String(),
+		StartTime: s.StartTime(),
+		EndTime:   s.EndTime(),
+		Duration:  s.EndTime().Sub(s.StartTime()).Seconds(),
+	}
+	if s.Parent().IsValid() {
+		js.ParentSpanID = s.Parent().SpanID().String()
+	}
+	if attrs := s.Attributes(); len(attrs) > 0 {
+		js.Attributes = map[string]string{}
+		for _, a := range attrs {
+			js.Attributes[string(a.Key)] = a.Value.Emit()
+		}
+	}
+	return js
+}
+
+// ExportSpans writes spans as JSON, one per line
+func (e *jsonExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	enc := json.NewEncoder(e.w)
+	for _, s := range spans {
+		if err := enc.Encode(newJSONSpan(s)); err != nil {
+			return errors.Wrap(err, "encoding span")
+		}
+	}
+	return nil
+}
+
+// Shutdown closes the file
+func (e *jsonExporter) Shutdown(_ context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.w.Close()
+}
+
+// traceFile returns the file written by the json tracer
+func traceFile() string {
+	if f := os.Getenv(FileEnvVar); f != "" {
+		return f
+	}
+	return localpath.TraceFile()
+}
+
+func initJSONTracer() (*otelTracer, error) {
+	path := traceFile()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, errors.Wrap(err, "creating trace dir")
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating trace file")
+	}
+	klog.Infof("writing trace to %s", path)
+	return newOtelTracer(&jsonExporter{w: f})
+}
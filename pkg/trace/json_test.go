@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestJSONTracer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.json")
+	t.Setenv(FileEnvVar, path)
+
+	if err := Initialize("json"); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	defer func() { tracer = nil }()
+
+	StartSpan("Starting Node")
+	node := Start("node.Start", attribute.String("node", "minikube"))
+	download := StartAsync("download.Preload")
+	host := Start("machine.StartHost")
+	download.End()
+	host.End()
+	addons := StartAsync("addons.Enable")
+	addon := addons.Child("addons.RunCallbacks", attribute.String("addon", "storage-provisioner"))
+	wait := Start("bootstrapper.WaitForNode")
+	addon.End()
+	addons.End()
+	wait.End()
+	node.End()
+	EndSpan("Starting Node")
+	Cleanup()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer f.Close()
+
+	spans := map[string]JSONSpan{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var s JSONSpan
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
+			t.Fatalf("unmarshal %q: %v", scanner.Text(), err)
+		}
+		spans[s.Name] = s
+	}
+	if len(spans) != 8 {
+		t.Fatalf("got %d spans, want 8: %+v", len(spans), spans)
+	}
+
+	parents := map[string]string{
+		"Starting Node":            parentSpanName,
+		"node.Start":               parentSpanName,
+		"download.Preload":         "node.Start",
+		"machine.StartHost":        "node.Start",
+		"addons.Enable":            "node.Start",
+		"addons.RunCallbacks":      "addons.Enable",
+		"bootstrapper.WaitForNode": "node.Start",
+	}
+	for name, parent := range parents {
+		if got, want := spans[name].ParentSpanID, spans[parent].SpanID; got != want {
+			t.Errorf("parent of %q = %q, want %q (%s)", name, got, want, parent)
+		}
+	}
+	if spans[parentSpanName].ParentSpanID != "" {
+		t.Errorf("root span has parent %q", spans[parentSpanName].ParentSpanID)
+	}
+	if got := spans["addons.RunCallbacks"].Attributes["addon"]; got != "storage-provisioner" {
+		t.Errorf("addon attribute = %q, want storage-provisioner", got)
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/klog/v2"
+
+	"k8s.io/minikube/pkg/version"
+)
+
+const (
+	// this is the name of the parent span to help identify it
+	// in the tracing UI.
+	parentSpanName = "minikube start"
+)
+
+// otelTracer sends the spans of `minikube start` to an OpenTelemetry exporter
+type otelTracer struct {
+	parentCtx context.Context
+	trace.Tracer
+	cleanup func(context.Context) error
+
+	mu sync.Mutex
+	// spans are the named steps of `minikube start`, see StartSpan
+	spans map[string]trace.Span
+	// open are the spans started via Start that have not ended yet, innermost last.
+	// Spans of concurrent work are not tracked here, so they do not become the parent of unrelated spans.
+	open []*Span
+}
+
+// Span is a single timed operation, nested under the span it was started from
+type Span struct {
+	t    *otelTracer
+	ctx  context.Context
+	span trace.Span
+}
+
+// StartSpan starts a span for the next step of
+// `minikube start` via the tracer
+func (t *otelTracer) StartSpan(name string) {
+	_, span := t.Tracer.Start(t.parentCtx, name)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.spans[name] = span
+}
+
+// EndSpan ends the most recent span, indicating
+// that one step of `minikube start` has completed
+func (t *otelTracer) EndSpan(name string) {
+	t.mu.Lock()
+	span, ok := t.spans[name]
+	t.mu.Unlock()
+	if !ok {
+		klog.Warningf("cannot end span %s as it was never started", name)
+		return
+	}
+	span.End()
+}
+
+// Start starts a span nested under the innermost open span
+func (t *otelTracer) Start(name string, attrs ...attribute.KeyValue) *Span {
+	return t.start(t.innermost(), name, true, attrs...)
+}
+
+// StartAsync starts a span nested under the innermost open span, without becoming the innermost one
+func (t *otelTracer) StartAsync(name string, attrs ...attribute.KeyValue) *Span {
+	return t.start(t.innermost(), name, false, attrs...)
+}
+
+// innermost returns the context of the innermost open span, or of the root span
+func (t *otelTracer) innermost() context.Context {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.open) > 0 {
+		return t.open[len(t.open)-1].ctx
+	}
+	return t.parentCtx
+}
+
+func (t *otelTracer) start(parent context.Context, name string, open bool, attrs ...attribute.KeyValue) *Span {
+	ctx, span := t.Tracer.Start(parent, name, trace.WithAttributes(attrs...))
+	s := &Span{t: t, ctx: ctx, span: span}
+	if open {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.open = append(t.open, s)
+	}
+	return s
+}
+
+// Child starts a span nested under s, without becoming the innermost one.
+// Use it for work that s runs concurrently, so that siblings do not nest under each other.
+func (s *Span) Child(name string, attrs ...attribute.KeyValue) *Span {
+	if s == nil {
+		return nil
+	}
+	return s.t.start(s.ctx, name, false, attrs...)
+}
+
+// End ends the span
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.t.mu.Lock()
+	for i := len(s.t.open) - 1; i >= 0; i-- {
+		if s.t.open[i] == s {
+			s.t.open = append(s.t.open[:i], s.t.open[i+1:]...)
+			break
+		}
+	}
+	s.t.mu.Unlock()
+	s.span.End()
+}
+
+func (t *otelTracer) Cleanup() {
+	t.mu.Lock()
+	open := t.open
+	t.open = nil
+	span, ok := t.spans[parentSpanName]
+	t.mu.Unlock()
+	// end whatever was left open by an early exit, so it is still exported
+	for i := len(open) - 1; i >= 0; i-- {
+		open[i].span.End()
+	}
+	if ok {
+		span.End()
+	}
+	if err := t.cleanup(context.Background()); err != nil {
+		klog.Warningf("Fail to cleanup the trace: %s", err)
+	}
+}
+
+// newOtelTracer starts the root span of a tracer that sends its spans to exporter
+func newOtelTracer(exporter sdktrace.SpanExporter) (*otelTracer, error) {
+	// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES take precedence over the defaults
+	res, err := resource.New(context.Background(),
+		resource.WithAttributes(
+			attribute.String("service.name", "minikube"),
+			attribute.String("service.version", version.GetVersion()),
+		),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating resource")
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithResource(res),
+	)
+
+	otel.SetTracerProvider(tp)
+
+	t := otel.Tracer(parentSpanName)
+
+	ctx, span := t.Start(context.Background(), parentSpanName)
+	return &otelTracer{
+		parentCtx: ctx,
+		cleanup:   tp.Shutdown,
+		Tracer:    t,
+		spans: map[string]trace.Span{
+			parentSpanName: span,
+		},
+	}, nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+)
+
+const (
+	// ProtocolEnvVar is the standard OpenTelemetry env variable selecting the OTLP transport
+	ProtocolEnvVar = "OTEL_EXPORTER_OTLP_PROTOCOL"
+	// TracesProtocolEnvVar overrides ProtocolEnvVar for traces only
+	TracesProtocolEnvVar = "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL"
+)
+
+// otlpProtocol returns the OTLP transport requested via the standard env variables,
+// defaulting to http/protobuf as the OpenTelemetry specification does
+func otlpProtocol() string {
+	if p := os.Getenv(TracesProtocolEnvVar); p != "" {
+		return p
+	}
+	if p := os.Getenv(ProtocolEnvVar); p != "" {
+		return p
+	}
+	return "http/protobuf"
+}
+
+// initOTLPTracer sends the spans to an OTLP collector. The endpoint, headers, TLS and timeouts
+// are read by the exporter from the standard OTEL_EXPORTER_OTLP_* env variables.
+func initOTLPTracer() (*otelTracer, error) {
+	var client otlptrace.Client
+	switch p := otlpProtocol(); p {
+	case "grpc":
+		client = otlptracegrpc.NewClient()
+	case "http/protobuf":
+		client = otlptracehttp.NewClient()
+	default:
+		return nil, fmt.Errorf("OTLP protocol %q set via %s is not supported, valid protocols include: [grpc, http/protobuf]", p, ProtocolEnvVar)
+	}
+
+	exporter, err := otlptrace.New(context.Background(), client)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating OTLP exporter")
+	}
+	return newOtelTracer(exporter)
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 var (
@@ -29,6 +30,8 @@ var (
 type minikubeTracer interface {
 	StartSpan(string)
 	EndSpan(string)
+	Start(string, ...attribute.KeyValue) *Span
+	StartAsync(string, ...attribute.KeyValue) *Span
 	Cleanup()
 }
 
@@ -46,10 +49,14 @@ func getTracer(t string) (minikubeTracer, error) {
 	switch t {
 	case "gcp":
 		return initGCPTracer()
+	case "otlp":
+		return initOTLPTracer()
+	case "json":
+		return initJSONTracer()
 	case "":
 		return nil, nil
 	}
-	return nil, fmt.Errorf("%s is not a valid tracer, valid tracers include: [gcp]", t)
+	return nil, fmt.Errorf("%s is not a valid tracer, valid tracers include: [gcp, otlp, json]", t)
 }
 
 // StartSpan starts a span with the given name
@@ -68,6 +75,25 @@ func EndSpan(name string) {
 	tracer.EndSpan(name)
 }
 
+// Start starts a span nested under the innermost span that is still open,
+// or under the root span if there is none. The returned span must be ended,
+// typically via `defer trace.Start(...).End()`. It is nil if tracing is disabled.
+func Start(name string, attrs ...attribute.KeyValue) *Span {
+	if tracer == nil {
+		return nil
+	}
+	return tracer.Start(name, attrs...)
+}
+
+// StartAsync starts a span like Start, but spans started afterwards are not nested under it.
+// Use it for work running in its own goroutine, or work that does not start spans itself.
+func StartAsync(name string, attrs ...attribute.KeyValue) *Span {
+	if tracer == nil {
+		return nil
+	}
+	return tracer.StartAsync(name, attrs...)
+}
+
 // Cleanup is responsible for trace related cleanup,
 // such as flushing all data
 func Cleanup() {
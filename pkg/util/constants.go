@@ -41,8 +41,11 @@ func ServiceClusterIP(serviceCIDR string) (net.IP, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "parsing default service cidr")
 	}
-	ip = ip.To4()
-	ip[3]++
+	// IPv6 service CIDRs are used by IPv6 and dual-stack clusters
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	ip[len(ip)-1]++
 	return ip, nil
 }
 
@@ -31,7 +31,6 @@ import (
 	"time"
 
 	"github.com/docker/machine/libmachine/state"
-	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/util/retry"
 )
@@ -50,13 +49,10 @@ func TestScheduledStopWindows(t *testing.T) {
 	stopMinikube(ctx, t, profile, []string{"--schedule", "5m"})
 	// make sure timeToStop is present in status
 	ensureMinikubeScheduledTime(ctx, t, profile, 5*time.Minute)
-	// make sure the systemd service is running
-	rr, err := Run(t, exec.CommandContext(ctx, Target(), []string{"ssh", "-p", profile, "--", "sudo", "systemctl", "show", constants.ScheduledStopSystemdService, "--no-page"}...))
-	if err != nil {
-		t.Fatalf("getting minikube-scheduled-stop status: %v\n%s", err, rr.Output())
-	}
-	if !strings.Contains(rr.Output(), "ActiveState=active") {
-		t.Fatalf("minikube-scheduled-stop is not running: %v", rr.Output())
+	// make sure the schedule daemon is running
+	pid := checkPID(t)
+	if !processRunning(t, pid) {
+		t.Fatalf("schedule daemon %v is not running", pid)
 	}
 
 	// reschedule stop for 5 seconds from now
@@ -83,21 +79,23 @@ func TestScheduledStopUnix(t *testing.T) {
 	defer CleanupWithLogs(t, profile, cancel)
 	startMinikube(ctx, t, profile)
 
-	// schedule a stop for 5 min from now and make sure PID is created
+	// schedule a stop for 5 min from now and make sure the schedule daemon runs
 	stopMinikube(ctx, t, profile, []string{"--schedule", "5m"})
 	// make sure timeToStop is present in status
 	ensureMinikubeScheduledTime(ctx, t, profile, 5*time.Minute)
-	pid := checkPID(t, profile)
+	pid := checkPID(t)
 	if !processRunning(t, pid) {
-		t.Fatalf("process %v is not running", pid)
+		t.Fatalf("schedule daemon %v is not running", pid)
 	}
 
-	// schedule a second stop which should cancel the first scheduled stop
+	// schedule a second stop which should replace the first scheduled stop, in the same daemon
 	stopMinikube(ctx, t, profile, []string{"--schedule", "15s"})
-	if processRunning(t, pid) {
-		t.Fatalf("process %v running but should have been killed on reschedule of stop", pid)
+	if !processRunning(t, pid) {
+		t.Fatalf("schedule daemon %v is not running anymore after the reschedule of stop", pid)
+	}
+	if got := checkPID(t); got != pid {
+		t.Fatalf("schedule daemon %v was replaced by %v on reschedule of stop", pid, got)
 	}
-	pid = checkPID(t, profile)
 
 	// cancel the shutdown and make sure minikube is still running after 8 seconds
 	// sleep 12 just to be safe
@@ -111,9 +109,6 @@ func TestScheduledStopUnix(t *testing.T) {
 	// schedule another stop, make sure minikube status is "Stopped"
 	stopMinikube(ctx, t, profile, []string{"--schedule", "15s"})
 	time.Sleep(15 * time.Second)
-	if processRunning(t, pid) {
-		t.Fatalf("process %v running but should have been killed on reschedule of stop", pid)
-	}
 
 	// wait for stop to complete
 	time.Sleep(30 * time.Second)
@@ -121,6 +116,16 @@ func TestScheduledStopUnix(t *testing.T) {
 	ensureTimeToStopNotPresent(ctx, t, profile)
 	// make sure minikube status is "Stopped"
 	ensureMinikubeStatus(ctx, t, profile, "Host", state.Stopped.String())
+	// the daemon exits once no schedule is left
+	daemonExited := func() error {
+		if processRunning(t, pid) {
+			return fmt.Errorf("schedule daemon %v is still running", pid)
+		}
+		return nil
+	}
+	if err := retry.Expo(daemonExited, time.Second, 30*time.Second); err != nil {
+		t.Fatalf("error %v", err)
+	}
 }
 
 func startMinikube(ctx context.Context, t *testing.T, profile string) {
@@ -140,8 +145,8 @@ func stopMinikube(ctx context.Context, t *testing.T, profile string, additionalA
 	}
 }
 
-func checkPID(t *testing.T, profile string) string {
-	file := localpath.PID(profile)
+func checkPID(t *testing.T) string {
+	file := localpath.SchedulerPID()
 	var contents []byte
 	getContents := func() error {
 		var err error
@@ -165,6 +170,10 @@ func processRunning(t *testing.T, pid string) bool {
 	if err != nil {
 		return false
 	}
+	// on windows, finding a process opens it, which fails once it exited
+	if runtime.GOOS == "windows" {
+		return process.Release() == nil
+	}
 	err = process.Signal(syscall.Signal(0))
 	t.Log("signal error was: ", err)
 	return err == nil